@@ -1,34 +1,149 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
+	"log"
 	"os"
+	_ "time/tzdata"
 
 	"github.com/gin-gonic/gin"
 	"github.com/sirawatc/simple-gin-crud/database"
 	"github.com/sirawatc/simple-gin-crud/internal/shared/config"
 	"github.com/sirawatc/simple-gin-crud/pkg/logger"
+	"github.com/sirawatc/simple-gin-crud/pkg/metrics"
+	"github.com/sirawatc/simple-gin-crud/pkg/tracing"
 	"github.com/sirawatc/simple-gin-crud/server"
+	"github.com/sirupsen/logrus"
 )
 
 func main() {
-	cfg := config.NewConfig()
+	cfg, flags, err := config.Load(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	if flags.PrintConfig {
+		if err = config.Print(os.Stdout, cfg); err != nil {
+			log.Fatalf("Failed to print configuration: %v", err)
+		}
+		return
+	}
 
-	logger := logger.NewLogger(cfg.ServiceName)
+	logger, err := logger.NewLoggerWithOptions(cfg.ServiceName, logger.Options{
+		Level:    cfg.Log.Level,
+		Format:   cfg.Log.Format,
+		TimeZone: cfg.Log.TimeZone,
+		Output:   cfg.Log.Output,
+		File: logger.FileOptions{
+			Path:       cfg.Log.FilePath,
+			MaxSizeMB:  cfg.Log.FileMaxSizeMB,
+			MaxBackups: cfg.Log.FileMaxBackups,
+			MaxAgeDays: cfg.Log.FileMaxAgeDays,
+			Compress:   cfg.Log.FileCompress,
+		},
+	})
+	if err != nil {
+		log.Fatalf("Failed to initialize logger: %v", err)
+	}
 
 	gin.SetMode(cfg.Mode)
 
-	db, err := database.NewPostgres(cfg)
+	shutdownTracing, err := tracing.Init(context.Background(), tracing.Options{
+		ServiceName:  cfg.ServiceName,
+		Exporter:     cfg.Tracing.Exporter,
+		OTLPEndpoint: cfg.Tracing.OTLPEndpoint,
+		OTLPInsecure: cfg.Tracing.OTLPInsecure,
+		SampleRatio:  cfg.Tracing.SampleRatio,
+	})
+	if err != nil {
+		logger.Errorf("Failed to initialize tracing: %v", err)
+		os.Exit(1)
+	}
+
+	db, err := database.New(cfg, logger)
 	if err != nil {
 		logger.Errorf("Failed to initialize database: %v", err)
 		os.Exit(1)
 	}
 
+	if cfg.Metrics.Enabled {
+		dbName := cfg.Database.DBName
+		if cfg.Database.Driver == database.DriverSQLite {
+			dbName = cfg.Database.SQLitePath
+		}
+		if err = metrics.Instrument(db, dbName); err != nil {
+			logger.Errorf("Failed to instrument database metrics: %v", err)
+			os.Exit(1)
+		}
+	}
+
+	if err = db.Use(tracing.NewGormPlugin()); err != nil {
+		logger.Errorf("Failed to instrument database tracing: %v", err)
+		os.Exit(1)
+	}
+
+	replicaResolver, err := database.NewReplicaResolver(cfg, logger)
+	if err != nil {
+		logger.Errorf("Failed to initialize database replicas: %v", err)
+		os.Exit(1)
+	}
+	if replicaResolver != nil {
+		if err = db.Use(replicaResolver); err != nil {
+			logger.Errorf("Failed to install database replica routing: %v", err)
+			os.Exit(1)
+		}
+		replicaCtx, stopReplicaChecks := context.WithCancel(context.Background())
+		defer stopReplicaChecks()
+		go replicaResolver.Run(replicaCtx)
+	}
+
 	if cfg.Database.AutoMigrate {
-		if err = database.Migrate(db); err != nil {
+		if err = database.Migrate(context.Background(), db); err != nil {
 			logger.Errorf("Failed to migrate database: %v", err)
 			os.Exit(1)
 		}
+	} else if pending, err := database.PendingMigrations(context.Background(), db); err != nil {
+		logger.Errorf("Failed to check pending migrations: %v", err)
+	} else if len(pending) > 0 {
+		logger.Warnf("%d pending migration(s) %v: run `go run cmd/migrate/main.go up` or set DB_AUTO_MIGRATE=true", len(pending), pending)
+	}
+
+	watcher := config.NewWatcher(cfg, func() (*config.Config, error) {
+		cfg, _, err := config.Load(os.Args[1:])
+		return cfg, err
+	}, logger)
+	config.Watch(watcher, func(cfg *config.Config) string { return cfg.Log.Level }, func(level string) {
+		if parsed, err := logrus.ParseLevel(level); err == nil {
+			logger.SetLevel(parsed)
+		}
+	})
+	watcherCtx, stopWatcher := context.WithCancel(context.Background())
+	defer stopWatcher()
+	go watcher.Run(watcherCtx, flags.ConfigFile, cfg.Reload.WatchInterval)
+
+	srv, err := server.InitServer(watcher, db, logger)
+	if err != nil {
+		logger.Errorf("Failed to initialize server: %v", err)
+		os.Exit(1)
+	}
+	err = srv.Run(context.Background())
+
+	if shutdownErr := shutdownTracing(context.Background()); shutdownErr != nil {
+		logger.Errorf("Failed to shutdown tracing: %v", shutdownErr)
+	}
+
+	if replicaResolver != nil {
+		if closeErr := replicaResolver.Close(); closeErr != nil {
+			logger.Errorf("Failed to close database replicas: %v", closeErr)
+		}
 	}
 
-	server.InitServer(cfg, db, logger)
+	if err != nil {
+		logger.Errorf("Server exited with error: %v", err)
+		os.Exit(1)
+	}
 }
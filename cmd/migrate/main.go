@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/sirawatc/simple-gin-crud/database"
+	"github.com/sirawatc/simple-gin-crud/internal/shared/config"
+	"github.com/sirawatc/simple-gin-crud/pkg/logger"
+	"github.com/sirawatc/simple-gin-crud/pkg/migrate"
+)
+
+const usage = `Usage: migrate <command> [args]
+
+Commands:
+  up              apply all pending migrations
+  down [N]        revert the last N applied migrations (default 1)
+  status          list migrations and whether they are applied
+  goto VERSION    migrate up or down to VERSION (0 reverts everything)
+`
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprint(os.Stderr, usage)
+	}
+	flag.Parse()
+
+	if flag.NArg() < 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	cfg, _, err := config.Load(nil)
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	db, err := database.New(cfg, logger.NewLogger(cfg.ServiceName))
+	if err != nil {
+		log.Fatalf("Failed to initialize database: %v", err)
+	}
+
+	migrator, err := database.NewMigrator(db)
+	if err != nil {
+		log.Fatalf("Failed to load migrations: %v", err)
+	}
+
+	if err := run(context.Background(), migrator, flag.Arg(0), flag.Args()[1:]); err != nil {
+		log.Fatalf("Migration %s failed: %v", flag.Arg(0), err)
+	}
+}
+
+func run(ctx context.Context, migrator *migrate.Migrator, command string, args []string) error {
+	switch command {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		printChanged("Applied", applied)
+		return err
+	case "down":
+		steps := 1
+		if len(args) > 0 {
+			n, err := strconv.Atoi(args[0])
+			if err != nil || n < 1 {
+				return fmt.Errorf("invalid step count %q", args[0])
+			}
+			steps = n
+		}
+		reverted, err := migrator.Down(ctx, steps)
+		printChanged("Reverted", reverted)
+		return err
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		printStatus(statuses)
+		return nil
+	case "goto":
+		if len(args) < 1 {
+			return fmt.Errorf("goto requires a target version")
+		}
+		version, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid version %q", args[0])
+		}
+		changed, err := migrator.Goto(ctx, version)
+		printChanged("Migrated", changed)
+		return err
+	default:
+		flag.Usage()
+		return fmt.Errorf("unknown command %q", command)
+	}
+}
+
+func printChanged(action string, migrations []migrate.Migration) {
+	if len(migrations) == 0 {
+		fmt.Println("No migrations to run")
+		return
+	}
+	for _, migration := range migrations {
+		fmt.Printf("%s %d_%s\n", action, migration.Version, migration.Name)
+	}
+}
+
+func printStatus(statuses []migrate.Status) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+	for _, status := range statuses {
+		state := "pending"
+		appliedAt := "-"
+		if status.Applied {
+			state = "applied"
+			if status.Modified {
+				state = "modified"
+			}
+			appliedAt = status.AppliedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", status.Version, status.Name, state, appliedAt)
+	}
+	w.Flush()
+}
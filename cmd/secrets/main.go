@@ -0,0 +1,83 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/sirawatc/simple-gin-crud/internal/shared/config"
+)
+
+const usage = `Usage: secrets <command> [args]
+
+Commands:
+  keygen                  print a new key for SECRETS_KEY
+  encrypt [IN [OUT]]      encrypt a YAML map of secrets (default stdin to stdout)
+
+The key is read from SECRETS_KEY or the file named by SECRETS_KEY_FILE.
+`
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprint(os.Stderr, usage)
+	}
+	flag.Parse()
+
+	switch flag.Arg(0) {
+	case "keygen":
+		key, err := config.GenerateSecretsKey()
+		if err != nil {
+			log.Fatalf("Failed to generate key: %v", err)
+		}
+		fmt.Println(key)
+	case "encrypt":
+		if err := encrypt(flag.Arg(1), flag.Arg(2)); err != nil {
+			log.Fatalf("Failed to encrypt secrets: %v", err)
+		}
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+}
+
+func encrypt(in string, out string) error {
+	key, err := loadKey()
+	if err != nil {
+		return err
+	}
+
+	var plaintext []byte
+	if in == "" || in == "-" {
+		plaintext, err = io.ReadAll(os.Stdin)
+	} else {
+		plaintext, err = os.ReadFile(in)
+	}
+	if err != nil {
+		return err
+	}
+
+	sealed, err := config.EncryptSecrets(plaintext, key)
+	if err != nil {
+		return err
+	}
+
+	if out == "" || out == "-" {
+		_, err = os.Stdout.Write(sealed)
+		return err
+	}
+	return os.WriteFile(out, sealed, 0o600)
+}
+
+func loadKey() (string, error) {
+	if path := os.Getenv("SECRETS_KEY_FILE"); path != "" {
+		key, err := os.ReadFile(path)
+		return strings.TrimSpace(string(key)), err
+	}
+	if key := os.Getenv("SECRETS_KEY"); key != "" {
+		return key, nil
+	}
+	return "", fmt.Errorf("SECRETS_KEY or SECRETS_KEY_FILE must be set")
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"os"
+	"time"
+
+	"github.com/sirawatc/simple-gin-crud/database"
+	"github.com/sirawatc/simple-gin-crud/internal/author"
+	"github.com/sirawatc/simple-gin-crud/internal/book"
+	"github.com/sirawatc/simple-gin-crud/internal/seed"
+	"github.com/sirawatc/simple-gin-crud/internal/shared/config"
+	"github.com/sirawatc/simple-gin-crud/pkg/logger"
+	"github.com/sirawatc/simple-gin-crud/pkg/repository"
+)
+
+func main() {
+	fake := flag.Int("fake", 0, "generate N fake authors instead of loading fixture files")
+	booksPerAuthor := flag.Int("books-per-author", 3, "number of fake books generated per author")
+	randomSeed := flag.Uint64("seed", 0, "random seed for fake data (default: current time)")
+	upsert := flag.Bool("upsert", false, "update authors and books that already exist instead of failing")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: seed [flags] [fixture.yaml|fixture.json ...]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if *fake <= 0 && flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	cfg, _, err := config.Load(nil)
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	logger, err := logger.NewLoggerWithOptions(cfg.ServiceName, logger.Options{
+		Level:    "warn",
+		Format:   cfg.Log.Format,
+		TimeZone: cfg.Log.TimeZone,
+	})
+	if err != nil {
+		log.Fatalf("Failed to initialize logger: %v", err)
+	}
+
+	db, err := database.New(cfg, logger)
+	if err != nil {
+		log.Fatalf("Failed to initialize database: %v", err)
+	}
+
+	transactionManager := repository.NewTransactionManager(db)
+	authorService := author.NewService(author.NewRepository(transactionManager, logger), transactionManager, logger)
+	bookService := book.NewService(book.NewRepository(transactionManager, logger), authorService, transactionManager, logger)
+	seeder := seed.NewSeeder(authorService, bookService, logger, *upsert)
+
+	fixtures := []*seed.Fixture{}
+	for _, path := range flag.Args() {
+		fixture, err := seed.LoadFixture(path)
+		if err != nil {
+			log.Fatalf("Failed to load fixture: %v", err)
+		}
+		fixtures = append(fixtures, fixture)
+	}
+	if *fake > 0 {
+		if *randomSeed == 0 {
+			*randomSeed = uint64(time.Now().UnixNano())
+		}
+		fmt.Printf("Generating %d fake authors with seed %d\n", *fake, *randomSeed)
+		r := rand.New(rand.NewPCG(*randomSeed, *randomSeed))
+		fixtures = append(fixtures, seed.Fake(r, *fake, *booksPerAuthor))
+	}
+
+	total := seed.Result{}
+	for _, fixture := range fixtures {
+		result, err := seeder.Seed(context.Background(), fixture)
+		total.AuthorsCreated += result.AuthorsCreated
+		total.AuthorsUpdated += result.AuthorsUpdated
+		total.BooksCreated += result.BooksCreated
+		total.BooksUpdated += result.BooksUpdated
+		if err != nil {
+			printResult(total)
+			log.Fatalf("Seeding failed: %v", err)
+		}
+	}
+	printResult(total)
+}
+
+func printResult(result seed.Result) {
+	fmt.Printf("Authors: %d created, %d updated\n", result.AuthorsCreated, result.AuthorsUpdated)
+	fmt.Printf("Books:   %d created, %d updated\n", result.BooksCreated, result.BooksUpdated)
+}
@@ -0,0 +1,60 @@
+package database
+
+import (
+	"fmt"
+
+	"github.com/sirawatc/simple-gin-crud/internal/shared/config"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+)
+
+func New(cfg *config.Config, logger *logrus.Logger) (*gorm.DB, error) {
+	var open func() (*gorm.DB, error)
+	switch cfg.Database.Driver {
+	case "", DriverPostgres:
+		open = func() (*gorm.DB, error) { return NewPostgres(cfg, logger) }
+	case DriverSQLite:
+		open = func() (*gorm.DB, error) { return NewSQLite(cfg, logger) }
+	default:
+		return nil, fmt.Errorf("unsupported database driver %q", cfg.Database.Driver)
+	}
+
+	return connectWithRetry(retryOptions{
+		Attempts:   cfg.Database.ConnectAttempts,
+		Backoff:    cfg.Database.ConnectBackoff,
+		MaxBackoff: cfg.Database.ConnectMaxBackoff,
+	}, logger, open)
+}
+
+func newGormConfig(cfg config.DatabaseConfig, logger *logrus.Logger) (*gorm.Config, error) {
+	level, err := ParseLogLevel(cfg.LogLevel)
+	if err != nil {
+		return nil, err
+	}
+
+	return &gorm.Config{
+		Logger:      NewQueryLogger(logger, level, cfg.SlowQueryThreshold),
+		PrepareStmt: cfg.PrepareStmt,
+		// Unique violations come back as gorm.ErrDuplicatedKey, so services
+		// can report conflicts that slip past their own checks.
+		TranslateError: true,
+	}, nil
+}
+
+func configurePool(db *gorm.DB, cfg config.DatabaseConfig) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+	return nil
+}
@@ -0,0 +1,256 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirawatc/simple-gin-crud/internal/shared/config"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	gormLogger "gorm.io/gorm/logger"
+)
+
+func testLogger() *logrus.Logger {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	return logger
+}
+
+func TestConnectWithRetry(t *testing.T) {
+	var waits []time.Duration
+	sleep = func(d time.Duration) { waits = append(waits, d) }
+	defer func() { sleep = time.Sleep }()
+
+	tests := []struct {
+		name          string
+		failures      int
+		expectedErr   bool
+		expectedCalls int
+		expectedWaits []time.Duration
+	}{
+		{
+			name:          "first attempt succeeds",
+			failures:      0,
+			expectedCalls: 1,
+			expectedWaits: nil,
+		},
+		{
+			name:          "succeeds after backing off",
+			failures:      3,
+			expectedCalls: 4,
+			expectedWaits: []time.Duration{time.Second, 2 * time.Second, 3 * time.Second},
+		},
+		{
+			name:          "gives up after all attempts",
+			failures:      10,
+			expectedErr:   true,
+			expectedCalls: 5,
+			expectedWaits: []time.Duration{time.Second, 2 * time.Second, 3 * time.Second, 3 * time.Second},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			waits = nil
+			calls := 0
+			db, err := connectWithRetry(retryOptions{Attempts: 5, Backoff: time.Second, MaxBackoff: 3 * time.Second}, testLogger(), func() (*gorm.DB, error) {
+				calls++
+				if calls <= tt.failures {
+					return nil, errors.New("connection refused")
+				}
+				return &gorm.DB{}, nil
+			})
+
+			if tt.expectedErr {
+				assert.EqualError(t, err, "connection refused")
+				assert.Nil(t, db)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, db)
+			}
+			assert.Equal(t, tt.expectedCalls, calls)
+			assert.Equal(t, tt.expectedWaits, waits)
+		})
+	}
+}
+
+func TestParseLogLevel(t *testing.T) {
+	tests := []struct {
+		level       string
+		expected    gormLogger.LogLevel
+		expectedErr bool
+	}{
+		{level: "silent", expected: gormLogger.Silent},
+		{level: "error", expected: gormLogger.Error},
+		{level: "", expected: gormLogger.Warn},
+		{level: "WARN", expected: gormLogger.Warn},
+		{level: "info", expected: gormLogger.Info},
+		{level: "debug", expectedErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.level, func(t *testing.T) {
+			level, err := ParseLogLevel(tt.level)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, level)
+		})
+	}
+}
+
+func TestPostgresDSN(t *testing.T) {
+	tests := []struct {
+		name     string
+		dsn      string
+		timeout  time.Duration
+		expected string
+	}{
+		{name: "no timeout", dsn: "host=db", timeout: 0, expected: "host=db"},
+		{name: "keyword value", dsn: "host=db", timeout: 30 * time.Second, expected: "host=db statement_timeout=30000"},
+		{name: "url", dsn: "postgres://db/app", timeout: time.Second, expected: "postgres://db/app?statement_timeout=1000"},
+		{name: "url with query", dsn: "postgres://db/app?sslmode=disable", timeout: time.Second, expected: "postgres://db/app?sslmode=disable&statement_timeout=1000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, postgresDSN(tt.dsn, config.DatabaseConfig{StatementTimeout: tt.timeout}))
+		})
+	}
+}
+
+func TestQueryLogger_Trace(t *testing.T) {
+	tests := []struct {
+		name          string
+		level         gormLogger.LogLevel
+		elapsed       time.Duration
+		err           error
+		expectedLevel string
+	}{
+		{name: "error", level: gormLogger.Warn, err: errors.New("boom"), expectedLevel: "error"},
+		{name: "record not found is not an error", level: gormLogger.Warn, err: gorm.ErrRecordNotFound},
+		{name: "slow query", level: gormLogger.Warn, elapsed: time.Second, expectedLevel: "warning"},
+		{name: "slow query hidden at error level", level: gormLogger.Error, elapsed: time.Second},
+		{name: "fast query", level: gormLogger.Warn},
+		{name: "fast query at info level", level: gormLogger.Info, expectedLevel: "info"},
+		{name: "silent", level: gormLogger.Silent, err: errors.New("boom")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := logrus.New()
+			logger.SetOutput(&buf)
+			logger.SetFormatter(&logrus.JSONFormatter{})
+
+			queryLogger := NewQueryLogger(logger, tt.level, 500*time.Millisecond)
+			queryLogger.Trace(context.Background(), time.Now().Add(-tt.elapsed), func() (string, int64) {
+				return "SELECT 1", 1
+			}, tt.err)
+
+			if tt.expectedLevel == "" {
+				assert.Empty(t, buf.String())
+				return
+			}
+			assert.Contains(t, buf.String(), `"level":"`+tt.expectedLevel+`"`)
+			assert.Contains(t, buf.String(), `"sql":"SELECT 1"`)
+		})
+	}
+}
+
+func TestNew_InvalidLogLevel(t *testing.T) {
+	_, err := New(&config.Config{Database: config.DatabaseConfig{
+		Driver:     DriverSQLite,
+		SQLitePath: filepath.Join(t.TempDir(), "test.db"),
+		LogLevel:   "verbose",
+	}}, testLogger())
+
+	assert.EqualError(t, err, `unsupported database log level "verbose"`)
+}
+
+func TestNewSQLite_Pool(t *testing.T) {
+	db, err := New(&config.Config{Database: config.DatabaseConfig{
+		Driver:          DriverSQLite,
+		SQLitePath:      filepath.Join(t.TempDir(), "test.db"),
+		MaxOpenConns:    25,
+		ConnMaxLifetime: time.Minute,
+	}}, testLogger())
+	assert.NoError(t, err)
+
+	sqlDB, err := db.DB()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sqlDB.Stats().MaxOpenConnections)
+}
+
+func TestNewPasswordFunc(t *testing.T) {
+	t.Run("static password", func(t *testing.T) {
+		cfg := &config.Config{Database: config.DatabaseConfig{Password: "postgres"}}
+
+		password, err := newPasswordFunc(cfg, testLogger())
+		assert.NoError(t, err)
+		value, err := password(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, "postgres", value)
+	})
+
+	t.Run("rotated secret", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "db_password")
+		assert.NoError(t, os.WriteFile(path, []byte("first\n"), 0o600))
+		cfg := &config.Config{
+			Database: config.DatabaseConfig{Password: "ignored", PasswordSecret: "db_password"},
+			Secrets:  config.SecretsConfig{Provider: config.SecretProviderFile, Dir: dir},
+		}
+
+		password, err := newPasswordFunc(cfg, testLogger())
+		assert.NoError(t, err)
+		value, err := password(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, "first", value)
+
+		assert.NoError(t, os.WriteFile(path, []byte("second\n"), 0o600))
+		value, err = password(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, "second", value)
+
+		assert.NoError(t, os.Remove(path))
+		_, err = password(context.Background())
+		assert.ErrorContains(t, err, "failed to resolve database password")
+	})
+
+	t.Run("rotated password file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "db_password")
+		assert.NoError(t, os.WriteFile(path, []byte("first\n"), 0o600))
+		cfg := &config.Config{Database: config.DatabaseConfig{Password: "first", PasswordFile: path}}
+
+		password, err := newPasswordFunc(cfg, testLogger())
+		assert.NoError(t, err)
+		value, err := password(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, "first", value)
+
+		assert.NoError(t, os.WriteFile(path, []byte("second\n"), 0o600))
+		value, err = password(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, "second", value)
+	})
+
+	t.Run("unsupported provider", func(t *testing.T) {
+		cfg := &config.Config{
+			Database: config.DatabaseConfig{PasswordSecret: "db_password"},
+			Secrets:  config.SecretsConfig{Provider: "vault"},
+		}
+
+		_, err := newPasswordFunc(cfg, testLogger())
+		assert.EqualError(t, err, `unsupported secret provider "vault"`)
+	})
+}
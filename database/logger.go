@@ -0,0 +1,96 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirawatc/simple-gin-crud/pkg/logger"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	gormLogger "gorm.io/gorm/logger"
+)
+
+// queryLogger writes GORM logs through logrus so SQL errors and slow
+// queries carry the request id like the rest of the application logs.
+type queryLogger struct {
+	logger        *logrus.Logger
+	level         gormLogger.LogLevel
+	slowThreshold time.Duration
+}
+
+func NewQueryLogger(logger *logrus.Logger, level gormLogger.LogLevel, slowThreshold time.Duration) gormLogger.Interface {
+	return &queryLogger{
+		logger:        logger,
+		level:         level,
+		slowThreshold: slowThreshold,
+	}
+}
+
+// ParseLogLevel maps silent, error, warn and info to GORM log levels.
+func ParseLogLevel(level string) (gormLogger.LogLevel, error) {
+	switch strings.ToLower(level) {
+	case "silent":
+		return gormLogger.Silent, nil
+	case "error":
+		return gormLogger.Error, nil
+	case "", "warn":
+		return gormLogger.Warn, nil
+	case "info":
+		return gormLogger.Info, nil
+	default:
+		return 0, fmt.Errorf("unsupported database log level %q", level)
+	}
+}
+
+func (l *queryLogger) LogMode(level gormLogger.LogLevel) gormLogger.Interface {
+	clone := *l
+	clone.level = level
+	return &clone
+}
+
+func (l *queryLogger) Info(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= gormLogger.Info {
+		logger.InjectRequestIDWithLogger(ctx, l.logger).Infof("[GORM] "+msg, data...)
+	}
+}
+
+func (l *queryLogger) Warn(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= gormLogger.Warn {
+		logger.InjectRequestIDWithLogger(ctx, l.logger).Warnf("[GORM] "+msg, data...)
+	}
+}
+
+func (l *queryLogger) Error(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= gormLogger.Error {
+		logger.InjectRequestIDWithLogger(ctx, l.logger).Errorf("[GORM] "+msg, data...)
+	}
+}
+
+func (l *queryLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	if l.level <= gormLogger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	fields := func() logrus.Fields {
+		sql, rows := fc()
+		return logrus.Fields{
+			"sql":       sql,
+			"rows":      rows,
+			"latencyMs": float64(elapsed.Microseconds()) / 1000,
+		}
+	}
+	entry := logger.InjectRequestIDWithLogger(ctx, l.logger)
+
+	switch {
+	case err != nil && l.level >= gormLogger.Error && !errors.Is(err, gorm.ErrRecordNotFound):
+		entry.WithFields(fields()).Errorf("[GORM] Query failed: %v", err)
+	case l.slowThreshold > 0 && elapsed > l.slowThreshold && l.level >= gormLogger.Warn:
+		entry.WithFields(fields()).Warnf("[GORM] Slow query over %v", l.slowThreshold)
+	case l.level >= gormLogger.Info:
+		entry.WithFields(fields()).Info("[GORM] Query")
+	}
+}
@@ -1,12 +1,49 @@
 package database
 
 import (
-	"github.com/sirawatc/simple-gin-crud/internal/author"
+	"context"
+	"embed"
+	"fmt"
+
+	"github.com/sirawatc/simple-gin-crud/pkg/migrate"
 	"gorm.io/gorm"
 )
 
-func Migrate(db *gorm.DB) error {
-	return db.Migrator().AutoMigrate(
-		&author.Author{},
-	)
+//go:embed migrations/postgres/*.sql migrations/sqlite/*.sql
+var migrationFS embed.FS
+
+var migrationDialects = map[string]migrate.Dialect{
+	DriverPostgres: migrate.Postgres{},
+	DriverSQLite:   migrate.SQLite{},
+}
+
+func NewMigrator(db *gorm.DB) (*migrate.Migrator, error) {
+	driver := db.Dialector.Name()
+	dialect, ok := migrationDialects[driver]
+	if !ok {
+		return nil, fmt.Errorf("no migrations for database driver %q", driver)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	return migrate.New(sqlDB, migrationFS, "migrations/"+driver, migrate.Options{Dialect: dialect})
+}
+
+func Migrate(ctx context.Context, db *gorm.DB) error {
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		return err
+	}
+	_, err = migrator.Up(ctx)
+	return err
+}
+
+func PendingMigrations(ctx context.Context, db *gorm.DB) ([]string, error) {
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		return nil, err
+	}
+	return migrator.Pending(ctx)
 }
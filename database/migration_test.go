@@ -0,0 +1,25 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/sirawatc/simple-gin-crud/pkg/migrate"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmbeddedMigrations(t *testing.T) {
+	postgres, err := migrate.Load(migrationFS, "migrations/"+DriverPostgres)
+	assert.NoError(t, err)
+	sqlite, err := migrate.Load(migrationFS, "migrations/"+DriverSQLite)
+	assert.NoError(t, err)
+
+	assert.NotEmpty(t, postgres)
+	assert.Len(t, sqlite, len(postgres), "every driver must ship the same migration versions")
+	for i, migration := range postgres {
+		assert.Equal(t, int64(i+1), migration.Version, "migration versions must be sequential")
+		assert.NotEmpty(t, migration.Down, "migration %d_%s has no down script", migration.Version, migration.Name)
+		assert.Equal(t, migration.Version, sqlite[i].Version)
+		assert.Equal(t, migration.Name, sqlite[i].Name)
+		assert.NotEmpty(t, sqlite[i].Down)
+	}
+}
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS authors (
+    id UUID NOT NULL DEFAULT uuid_generate_v4(),
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+    pen_name TEXT NOT NULL,
+    birth_year BIGINT NOT NULL,
+    CONSTRAINT authors_pkey PRIMARY KEY (id),
+    CONSTRAINT uni_authors_pen_name UNIQUE (pen_name)
+);
+
+CREATE INDEX IF NOT EXISTS idx_authors_deleted_at ON authors (deleted_at);
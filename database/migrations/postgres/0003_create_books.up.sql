@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS books (
+    id UUID NOT NULL DEFAULT uuid_generate_v4(),
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+    author_id UUID NOT NULL,
+    name TEXT NOT NULL,
+    isbn TEXT NOT NULL,
+    CONSTRAINT books_pkey PRIMARY KEY (id),
+    CONSTRAINT uni_books_isbn UNIQUE (isbn),
+    CONSTRAINT fk_books_author FOREIGN KEY (author_id) REFERENCES authors (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_books_author_id ON books (author_id);
+CREATE INDEX IF NOT EXISTS idx_books_deleted_at ON books (deleted_at);
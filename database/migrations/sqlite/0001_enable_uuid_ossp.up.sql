@@ -0,0 +1,3 @@
+-- uuid-ossp is a Postgres extension. SQLite IDs are generated by the
+-- application, so this version is a no-op kept to align version numbers.
+SELECT 1;
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS authors (
+    id TEXT NOT NULL,
+    created_at DATETIME,
+    updated_at DATETIME,
+    deleted_at DATETIME,
+    pen_name TEXT NOT NULL,
+    birth_year INTEGER NOT NULL,
+    CONSTRAINT authors_pkey PRIMARY KEY (id),
+    CONSTRAINT uni_authors_pen_name UNIQUE (pen_name)
+);
+
+CREATE INDEX IF NOT EXISTS idx_authors_deleted_at ON authors (deleted_at);
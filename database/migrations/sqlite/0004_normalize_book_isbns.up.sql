@@ -0,0 +1,14 @@
+-- ISBNs used to be stored exactly as sent; they are now stored without
+-- hyphens or spaces. A row whose normalised ISBN is already held by another
+-- book, or by a row with a lower id, keeps its original value so the unique
+-- constraint holds; the normalised row still guards that ISBN against new
+-- duplicates.
+UPDATE books
+SET isbn = REPLACE(REPLACE(isbn, '-', ''), ' ', '')
+WHERE isbn <> REPLACE(REPLACE(isbn, '-', ''), ' ', '')
+  AND NOT EXISTS (
+    SELECT 1 FROM books other
+    WHERE other.id <> books.id
+      AND REPLACE(REPLACE(other.isbn, '-', ''), ' ', '') = REPLACE(REPLACE(books.isbn, '-', ''), ' ', '')
+      AND (other.isbn = REPLACE(REPLACE(other.isbn, '-', ''), ' ', '') OR other.id < books.id)
+  );
@@ -1,27 +1,135 @@
 package database
 
 import (
+	"context"
 	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/sirawatc/simple-gin-crud/internal/shared/config"
+	"github.com/sirupsen/logrus"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
-func NewPostgres(cfg *config.Config) (*gorm.DB, error) {
-	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=%s TimeZone=%s",
+// passwordFunc returns the password for a new connection.
+type passwordFunc func(ctx context.Context) (string, error)
+
+func NewPostgres(cfg *config.Config, logger *logrus.Logger) (*gorm.DB, error) {
+	dsn := fmt.Sprintf("host=%s user=%s dbname=%s port=%s sslmode=%s TimeZone=%s",
 		cfg.Database.Host,
 		cfg.Database.User,
-		cfg.Database.Password,
 		cfg.Database.DBName,
 		cfg.Database.Port,
 		cfg.Database.SSLMode,
 		cfg.Database.TimeZone,
 	)
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+
+	password, err := newPasswordFunc(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	gormConfig, err := newGormConfig(cfg.Database, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return openPostgres(dsn, cfg.Database, gormConfig, password)
+}
+
+// newPasswordFunc resolves the password through the secret provider when
+// Database.PasswordSecret is set, or from Database.PasswordFile, so every
+// new connection uses the current value of a rotated secret.
+func newPasswordFunc(cfg *config.Config, logger *logrus.Logger) (passwordFunc, error) {
+	var provider config.SecretProvider
+	var name string
+	switch {
+	case cfg.Database.PasswordSecret != "":
+		var err error
+		provider, err = config.NewSecretProvider(cfg.Secrets)
+		if err != nil {
+			return nil, err
+		}
+		name = cfg.Database.PasswordSecret
+	case cfg.Database.PasswordFile != "":
+		provider = config.NewFileSecretProvider(filepath.Dir(cfg.Database.PasswordFile))
+		name = filepath.Base(cfg.Database.PasswordFile)
+	default:
+		password := cfg.Database.Password
+		return func(context.Context) (string, error) { return password, nil }, nil
+	}
+
+	var mu sync.Mutex
+	var last string
+	return func(ctx context.Context) (string, error) {
+		password, err := provider.Secret(ctx, name)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve database password: %w", err)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if last != "" && password != last {
+			logger.Info("[Database] Database password rotated, using it for new connections")
+		}
+		last = password
+		return password, nil
+	}, nil
+}
+
+// openPostgres opens dsn through pgx so password, when not nil, can be
+// resolved for each new connection.
+func openPostgres(dsn string, cfg config.DatabaseConfig, gormConfig *gorm.Config, password passwordFunc) (*gorm.DB, error) {
+	connConfig, err := pgx.ParseConfig(postgresDSN(dsn, cfg))
+	if err != nil {
+		return nil, err
+	}
+
+	var opts []stdlib.OptionOpenDB
+	if password != nil {
+		opts = append(opts, stdlib.OptionBeforeConnect(func(ctx context.Context, connConfig *pgx.ConnConfig) error {
+			value, err := password(ctx)
+			if err != nil {
+				return err
+			}
+			connConfig.Password = value
+			return nil
+		}))
+	}
+
+	sqlDB := stdlib.OpenDB(*connConfig, opts...)
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), gormConfig)
 	if err != nil {
+		_ = sqlDB.Close()
+		return nil, err
+	}
+
+	if err := configurePool(db, cfg); err != nil {
+		_ = sqlDB.Close()
 		return nil, err
 	}
 
 	return db, nil
 }
+
+// postgresDSN appends session settings to a keyword/value or URL DSN. pgx
+// sends keys it does not recognise to the server as runtime parameters.
+func postgresDSN(dsn string, cfg config.DatabaseConfig) string {
+	if cfg.StatementTimeout <= 0 {
+		return dsn
+	}
+
+	timeout := cfg.StatementTimeout.Milliseconds()
+	if !strings.Contains(dsn, "://") {
+		return fmt.Sprintf("%s statement_timeout=%d", dsn, timeout)
+	}
+	separator := "?"
+	if strings.Contains(dsn, "?") {
+		separator = "&"
+	}
+	return fmt.Sprintf("%s%sstatement_timeout=%d", dsn, separator, timeout)
+}
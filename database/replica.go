@@ -0,0 +1,59 @@
+package database
+
+import (
+	"fmt"
+
+	"github.com/sirawatc/simple-gin-crud/internal/shared/config"
+	"github.com/sirawatc/simple-gin-crud/pkg/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// NewReplicaResolver opens the configured read replicas. It returns nil when
+// none are configured.
+func NewReplicaResolver(cfg *config.Config, logger *logrus.Logger) (*repository.ReplicaResolver, error) {
+	if len(cfg.Database.ReplicaDSNs) == 0 {
+		return nil, nil
+	}
+	if cfg.Database.Driver != "" && cfg.Database.Driver != DriverPostgres {
+		return nil, fmt.Errorf("read replicas are not supported for database driver %q", cfg.Database.Driver)
+	}
+
+	replicas := make([]repository.Replica, 0, len(cfg.Database.ReplicaDSNs))
+	for i, dsn := range cfg.Database.ReplicaDSNs {
+		// Names avoid leaking credentials from the DSN into logs.
+		name := fmt.Sprintf("replica-%d", i+1)
+
+		gormConfig, err := newGormConfig(cfg.Database, logger)
+		if err != nil {
+			closeReplicas(replicas)
+			return nil, err
+		}
+		// A replica that is down at startup is ejected by the health check
+		// instead of failing the boot.
+		gormConfig.DisableAutomaticPing = true
+
+		db, err := openPostgres(dsn, cfg.Database, gormConfig, nil)
+		if err != nil {
+			closeReplicas(replicas)
+			return nil, fmt.Errorf("failed to open %s: %w", name, err)
+		}
+		sqlDB, err := db.DB()
+		if err != nil {
+			closeReplicas(replicas)
+			return nil, fmt.Errorf("failed to open %s: %w", name, err)
+		}
+		replicas = append(replicas, repository.Replica{Name: name, DB: sqlDB})
+	}
+
+	return repository.NewReplicaResolver(replicas, repository.ReplicaOptions{
+		StickyWindow:        cfg.Database.ReplicaStickyWindow,
+		HealthCheckInterval: cfg.Database.ReplicaHealthInterval,
+		HealthCheckTimeout:  cfg.Database.ReplicaHealthTimeout,
+	}, logger), nil
+}
+
+func closeReplicas(replicas []repository.Replica) {
+	for _, replica := range replicas {
+		_ = replica.DB.Close()
+	}
+}
@@ -0,0 +1,39 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/sirawatc/simple-gin-crud/internal/shared/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewReplicaResolver(t *testing.T) {
+	tests := []struct {
+		name        string
+		database    config.DatabaseConfig
+		expectedErr string
+	}{
+		{
+			name:     "no replicas configured",
+			database: config.DatabaseConfig{Driver: DriverPostgres},
+		},
+		{
+			name:        "unsupported driver",
+			database:    config.DatabaseConfig{Driver: DriverSQLite, ReplicaDSNs: []string{"replica.db"}},
+			expectedErr: `read replicas are not supported for database driver "sqlite"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolver, err := NewReplicaResolver(&config.Config{Database: tt.database}, testLogger())
+
+			assert.Nil(t, resolver)
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
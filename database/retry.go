@@ -0,0 +1,42 @@
+package database
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// sleep is replaced in tests.
+var sleep = time.Sleep
+
+type retryOptions struct {
+	Attempts   int
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+// connectWithRetry calls open until it succeeds or attempts run out,
+// doubling the wait between attempts up to MaxBackoff. It lets the service
+// start alongside a database that is still booting.
+func connectWithRetry(opts retryOptions, logger *logrus.Logger, open func() (*gorm.DB, error)) (*gorm.DB, error) {
+	attempts := max(opts.Attempts, 1)
+	backoff := opts.Backoff
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		var db *gorm.DB
+		if db, err = open(); err == nil {
+			return db, nil
+		}
+		if attempt == attempts {
+			return nil, err
+		}
+
+		logger.Warnf("[Database] Connection attempt %d/%d failed, retrying in %v: %v", attempt, attempts, backoff, err)
+		sleep(backoff)
+		if backoff *= 2; opts.MaxBackoff > 0 && backoff > opts.MaxBackoff {
+			backoff = opts.MaxBackoff
+		}
+	}
+}
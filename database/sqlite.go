@@ -0,0 +1,48 @@
+package database
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/glebarez/sqlite"
+	"github.com/sirawatc/simple-gin-crud/internal/shared/config"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const sqliteMemory = ":memory:"
+
+func NewSQLite(cfg *config.Config, logger *logrus.Logger) (*gorm.DB, error) {
+	path := cfg.Database.SQLitePath
+	if path != sqliteMemory {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return nil, err
+		}
+	}
+
+	dsn := fmt.Sprintf("%s?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)", path)
+	gormConfig, err := newGormConfig(cfg.Database, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := gorm.Open(sqlite.Open(dsn), gormConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	// SQLite allows a single writer; one connection avoids SQLITE_BUSY errors
+	// and keeps an in-memory database alive for the lifetime of the pool, so
+	// it must never be closed for being idle or too old.
+	poolConfig := cfg.Database
+	poolConfig.MaxOpenConns = 1
+	poolConfig.MaxIdleConns = 1
+	poolConfig.ConnMaxLifetime = 0
+	poolConfig.ConnMaxIdleTime = 0
+	if err := configurePool(db, poolConfig); err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
@@ -0,0 +1,103 @@
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/sirawatc/simple-gin-crud/internal/author"
+	"github.com/sirawatc/simple-gin-crud/internal/book"
+	"github.com/sirawatc/simple-gin-crud/internal/shared/config"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestNew_UnsupportedDriver(t *testing.T) {
+	_, err := New(&config.Config{Database: config.DatabaseConfig{Driver: "mysql"}}, testLogger())
+
+	assert.EqualError(t, err, `unsupported database driver "mysql"`)
+}
+
+func TestSQLiteMigrations(t *testing.T) {
+	ctx := context.Background()
+	db, err := New(&config.Config{Database: config.DatabaseConfig{
+		Driver:     DriverSQLite,
+		SQLitePath: filepath.Join(t.TempDir(), "nested", "test.db"),
+	}}, testLogger())
+	assert.NoError(t, err)
+
+	pending, err := PendingMigrations(ctx, db)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1_enable_uuid_ossp", "2_create_authors", "3_create_books", "4_normalize_book_isbns"}, pending)
+
+	assert.NoError(t, Migrate(ctx, db))
+
+	pending, err = PendingMigrations(ctx, db)
+	assert.NoError(t, err)
+	assert.Empty(t, pending)
+
+	created := &author.Author{PenName: "Haruki Murakami", BirthYear: 1949}
+	assert.NoError(t, db.Create(created).Error)
+	assert.NoError(t, db.Create(&book.Book{AuthorID: created.ID, Name: "Norwegian Wood", ISBN: "9780375704024"}).Error)
+
+	found := &book.Book{}
+	assert.NoError(t, db.Preload("Author").First(found, "isbn = ?", "9780375704024").Error)
+	assert.Equal(t, created.ID, found.AuthorID)
+	assert.Equal(t, "Haruki Murakami", found.Author.PenName)
+	assert.False(t, found.CreatedAt.IsZero())
+
+	assert.ErrorIs(t, db.Create(&author.Author{PenName: "Haruki Murakami", BirthYear: 1950}).Error, gorm.ErrDuplicatedKey, "pen name must be unique")
+	assert.ErrorIs(t, db.Create(&book.Book{AuthorID: created.ID, Name: "Norwegian Wood", ISBN: "9780375704024"}).Error, gorm.ErrDuplicatedKey, "ISBN must be unique")
+	assert.Error(t, db.Create(&book.Book{AuthorID: uuid.New(), Name: "Orphan", ISBN: "9781400078776"}).Error, "foreign keys must be enforced")
+
+	migrator, err := NewMigrator(db)
+	assert.NoError(t, err)
+	reverted, err := migrator.Goto(ctx, 0)
+	assert.NoError(t, err)
+	assert.Len(t, reverted, 4)
+	assert.False(t, db.Migrator().HasTable("authors"))
+}
+
+func TestSQLiteMigrations_NormalizeBookISBNs(t *testing.T) {
+	ctx := context.Background()
+	db, err := New(&config.Config{Database: config.DatabaseConfig{
+		Driver:     DriverSQLite,
+		SQLitePath: filepath.Join(t.TempDir(), "test.db"),
+	}}, testLogger())
+	assert.NoError(t, err)
+	migrator, err := NewMigrator(db)
+	assert.NoError(t, err)
+	_, err = migrator.Goto(ctx, 3)
+	assert.NoError(t, err)
+
+	writer := &author.Author{PenName: "Haruki Murakami", BirthYear: 1949}
+	assert.NoError(t, db.Create(writer).Error)
+	books := []*book.Book{
+		{AuthorID: writer.ID, Name: "Hyphenated", ISBN: "978-0-375-70402-4"},
+		{AuthorID: writer.ID, Name: "Bare", ISBN: "9781400079278"},
+		{AuthorID: writer.ID, Name: "Collides with bare", ISBN: "978-1-4000-7927-8"},
+		{AuthorID: writer.ID, Name: "Spaced", ISBN: "978 0 547 77374 2"},
+		{AuthorID: writer.ID, Name: "Collides with spaced", ISBN: "978-0-547-77374-2"},
+	}
+	for _, b := range books {
+		assert.NoError(t, db.Create(b).Error)
+	}
+
+	assert.NoError(t, Migrate(ctx, db))
+
+	isbns := map[string]string{}
+	for _, b := range books {
+		found := &book.Book{}
+		assert.NoError(t, db.First(found, "id = ?", b.ID).Error)
+		isbns[b.Name] = found.ISBN
+	}
+	assert.Equal(t, "9780375704024", isbns["Hyphenated"])
+	assert.Equal(t, "9781400079278", isbns["Bare"])
+	assert.Equal(t, "978-1-4000-7927-8", isbns["Collides with bare"])
+	// Of two rows that normalise alike, only the one with the lower id changes.
+	assert.Contains(t, [][]string{
+		{"9780547773742", "978-0-547-77374-2"},
+		{"978 0 547 77374 2", "9780547773742"},
+	}, []string{isbns["Spaced"], isbns["Collides with spaced"]})
+}
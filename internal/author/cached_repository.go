@@ -0,0 +1,70 @@
+package author
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/sirawatc/simple-gin-crud/pkg/cache"
+	repoPkg "github.com/sirawatc/simple-gin-crud/pkg/repository"
+)
+
+// cachedRepository decorates an IRepository with read-through caching of
+// GetByID. Reads inside a transaction bypass the cache, and every write
+// invalidates the affected ID.
+type cachedRepository struct {
+	IRepository
+	loader *cache.Loader[Author]
+}
+
+func NewCachedRepository(repo IRepository, backend cache.Cache, opts cache.Options) *cachedRepository {
+	return &cachedRepository{
+		IRepository: repo,
+		loader:      cache.NewLoader[Author]("author", backend, opts),
+	}
+}
+
+// SetCacheOptions changes the TTLs used for entries cached from now on.
+func (r *cachedRepository) SetCacheOptions(opts cache.Options) {
+	r.loader.SetOptions(opts)
+}
+
+func (r *cachedRepository) Create(ctx context.Context, author *Author) error {
+	if err := r.IRepository.Create(ctx, author); err != nil {
+		return err
+	}
+	return r.invalidate(ctx, author.ID)
+}
+
+func (r *cachedRepository) GetByID(ctx context.Context, id uuid.UUID) (*Author, error) {
+	if repoPkg.InTransaction(ctx) {
+		return r.IRepository.GetByID(ctx, id)
+	}
+	return r.loader.Get(ctx, id.String(), func(ctx context.Context) (*Author, error) {
+		return r.IRepository.GetByID(ctx, id)
+	})
+}
+
+func (r *cachedRepository) Update(ctx context.Context, id uuid.UUID, author *Author) error {
+	if err := r.IRepository.Update(ctx, id, author); err != nil {
+		return err
+	}
+	return r.invalidate(ctx, id)
+}
+
+func (r *cachedRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.IRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	return r.invalidate(ctx, id)
+}
+
+// invalidate drops the entry now and again once any surrounding transaction
+// commits, so a read racing the commit cannot leave the old row cached.
+func (r *cachedRepository) invalidate(ctx context.Context, id uuid.UUID) error {
+	if repoPkg.InTransaction(ctx) {
+		repoPkg.AfterCommit(ctx, func(ctx context.Context) {
+			_ = r.loader.Invalidate(ctx, id.String())
+		})
+	}
+	return r.loader.Invalidate(ctx, id.String())
+}
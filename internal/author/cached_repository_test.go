@@ -0,0 +1,107 @@
+package author
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirawatc/simple-gin-crud/internal/shared/models"
+	"github.com/sirawatc/simple-gin-crud/pkg/cache"
+	repoPkg "github.com/sirawatc/simple-gin-crud/pkg/repository"
+	"github.com/stretchr/testify/suite"
+)
+
+type countingRepository struct {
+	IRepository
+	getByIDCalls int
+}
+
+func (r *countingRepository) GetByID(ctx context.Context, id uuid.UUID) (*Author, error) {
+	r.getByIDCalls++
+	return r.IRepository.GetByID(ctx, id)
+}
+
+type CachedRepositoryTestSuite struct {
+	suite.Suite
+	inner *countingRepository
+	repo  *cachedRepository
+	ctx   context.Context
+}
+
+func (suite *CachedRepositoryTestSuite) SetupTest() {
+	suite.ctx = context.Background()
+	suite.inner = &countingRepository{IRepository: NewMemoryRepository(repoPkg.NewMemoryTransactionManager())}
+	suite.repo = NewCachedRepository(suite.inner, cache.NewLRU(100), cache.Options{TTL: time.Minute, NegativeTTL: time.Minute})
+}
+
+func (suite *CachedRepositoryTestSuite) create(penName string) *Author {
+	author := &Author{PenName: penName, BirthYear: 1990}
+	suite.Require().NoError(suite.repo.Create(suite.ctx, author))
+	return author
+}
+
+func (suite *CachedRepositoryTestSuite) TestGetByID_CachesResult() {
+	author := suite.create("Test Author")
+
+	for i := 0; i < 3; i++ {
+		got, err := suite.repo.GetByID(suite.ctx, author.ID)
+		suite.NoError(err)
+		suite.Equal("Test Author", got.PenName)
+	}
+	suite.Equal(1, suite.inner.getByIDCalls)
+}
+
+func (suite *CachedRepositoryTestSuite) TestGetByID_CachesNotFound() {
+	id := uuid.New()
+
+	for i := 0; i < 2; i++ {
+		got, err := suite.repo.GetByID(suite.ctx, id)
+		suite.NoError(err)
+		suite.Nil(got)
+	}
+	suite.Equal(1, suite.inner.getByIDCalls)
+
+	suite.Require().NoError(suite.repo.Create(suite.ctx, &Author{BaseModel: models.BaseModel{ID: id}, PenName: "Late Author", BirthYear: 1990}))
+	got, err := suite.repo.GetByID(suite.ctx, id)
+	suite.NoError(err)
+	suite.Equal("Late Author", got.PenName)
+}
+
+func (suite *CachedRepositoryTestSuite) TestGetByID_ContextTransactionBypassesCache() {
+	author := suite.create("Test Author")
+	_, _ = suite.repo.GetByID(suite.ctx, author.ID)
+
+	err := repoPkg.NewMemoryTransactionManager().WithinTransaction(suite.ctx, func(ctx context.Context) error {
+		_, err := suite.repo.GetByID(ctx, author.ID)
+		return err
+	})
+	suite.NoError(err)
+	suite.Equal(2, suite.inner.getByIDCalls)
+}
+
+func (suite *CachedRepositoryTestSuite) TestUpdate_Invalidates() {
+	author := suite.create("Test Author")
+	_, _ = suite.repo.GetByID(suite.ctx, author.ID)
+
+	suite.Require().NoError(suite.repo.Update(suite.ctx, author.ID, &Author{PenName: "Renamed"}))
+
+	got, err := suite.repo.GetByID(suite.ctx, author.ID)
+	suite.NoError(err)
+	suite.Equal("Renamed", got.PenName)
+}
+
+func (suite *CachedRepositoryTestSuite) TestDelete_Invalidates() {
+	author := suite.create("Test Author")
+	_, _ = suite.repo.GetByID(suite.ctx, author.ID)
+
+	suite.Require().NoError(suite.repo.Delete(suite.ctx, author.ID))
+
+	got, err := suite.repo.GetByID(suite.ctx, author.ID)
+	suite.NoError(err)
+	suite.Nil(got)
+}
+
+func TestCachedRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(CachedRepositoryTestSuite))
+}
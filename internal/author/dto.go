@@ -5,13 +5,13 @@ import (
 )
 
 type CreateAuthorRequest struct {
-	PenName   string `json:"penName" binding:"required" validate:"required,min=1,max=255"`
-	BirthYear int    `json:"birthYear" binding:"required" validate:"required,min=1800,max=2600"`
+	PenName   string `json:"penName" binding:"required" validate:"required,min=1,max=255,penname"`
+	BirthYear int    `json:"birthYear" binding:"required" validate:"required,min=1800,notfutureyear"`
 }
 
 type UpdateAuthorRequest struct {
-	PenName   string `json:"penName" binding:"required" validate:"required,min=1,max=255"`
-	BirthYear int    `json:"birthYear" binding:"required" validate:"required,min=1800,max=2600"`
+	PenName   string `json:"penName" binding:"required" validate:"required,min=1,max=255,penname"`
+	BirthYear int    `json:"birthYear" binding:"required" validate:"required,min=1800,notfutureyear"`
 }
 
 type AuthorResponse struct {
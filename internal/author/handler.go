@@ -7,20 +7,23 @@ import (
 	"github.com/google/uuid"
 	"github.com/sirawatc/simple-gin-crud/internal/shared/dto"
 	pkgDto "github.com/sirawatc/simple-gin-crud/pkg/dto"
+	"github.com/sirawatc/simple-gin-crud/pkg/httpcache"
 	"github.com/sirawatc/simple-gin-crud/pkg/logger"
 	"github.com/sirawatc/simple-gin-crud/pkg/validator"
 	"github.com/sirupsen/logrus"
 )
 
 type Handler struct {
-	service IService
-	logger  *logrus.Logger
+	service   IService
+	validator *validator.Validator
+	logger    *logrus.Logger
 }
 
-func NewHandler(service IService, logger *logrus.Logger) *Handler {
+func NewHandler(service IService, validator *validator.Validator, logger *logrus.Logger) *Handler {
 	return &Handler{
-		service: service,
-		logger:  logger,
+		service:   service,
+		validator: validator,
+		logger:    logger,
 	}
 }
 
@@ -33,24 +36,24 @@ func (h *Handler) CreateAuthor(c *gin.Context) {
 	var req CreateAuthorRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		logger.Errorf("%s Invalid request body: %v", logPrefix, err)
-		c.JSON(http.StatusBadRequest, dto.BuildBaseResponse(dto.BindingError, err.Error()))
+		dto.RespondBindingError(c, h.validator.BindingErrors(ctx, err))
 		return
 	}
 
-	if errors := validator.NewValidator().Validate(req); errors != nil {
+	if errors := h.validator.Validate(ctx, req); errors != nil {
 		logger.Errorf("%s Validation failed: %v", logPrefix, errors)
-		c.JSON(http.StatusBadRequest, dto.BuildBaseResponse(dto.ValidationError, errors))
+		dto.RespondError(c, http.StatusBadRequest, dto.ValidationError, errors)
 		return
 	}
 
 	author, code := h.service.CreateAuthor(ctx, &req)
 	if code != dto.Success {
 		logger.Errorf("%s Failed to create author: %v", logPrefix, dto.CodeMessage[code])
-		c.JSON(code.GetHTTPCode(), dto.BuildBaseResponse(code, nil))
+		dto.RespondError(c, code.GetHTTPCode(), code, nil)
 		return
 	}
 
-	c.JSON(http.StatusCreated, dto.BuildBaseResponse(dto.Created, author))
+	dto.Respond(c, http.StatusCreated, dto.Created, author)
 }
 
 func (h *Handler) GetAuthor(c *gin.Context) {
@@ -62,18 +65,22 @@ func (h *Handler) GetAuthor(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		logger.Errorf("%s Invalid author ID format: %v", logPrefix, err)
-		c.JSON(http.StatusBadRequest, dto.BuildBaseResponse(dto.UUIDFormatInvalid, nil))
+		dto.RespondError(c, http.StatusBadRequest, dto.UUIDFormatInvalid, nil)
 		return
 	}
 
 	author, code := h.service.GetAuthorByID(ctx, id)
 	if code != dto.Success {
 		logger.Errorf("%s Failed to get author: %v", logPrefix, dto.CodeMessage[code])
-		c.JSON(code.GetHTTPCode(), dto.BuildBaseResponse(code, nil))
+		dto.RespondError(c, code.GetHTTPCode(), code, nil)
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.BuildBaseResponse(dto.Success, author))
+	if author != nil && httpcache.NotModified(c, author.UpdatedAt) {
+		return
+	}
+
+	dto.Respond(c, http.StatusOK, dto.Success, author)
 }
 
 func (h *Handler) GetAllAuthors(c *gin.Context) {
@@ -82,21 +89,21 @@ func (h *Handler) GetAllAuthors(c *gin.Context) {
 	ctx := c.Request.Context()
 	logger := logger.InjectRequestIDWithLogger(ctx, h.logger)
 
-	pagination, errors := pkgDto.NewPaginationRequest(c.Query("page"), c.Query("pageSize"))
+	pagination, errors := pkgDto.NewPaginationRequest(ctx, c.Query("page"), c.Query("pageSize"))
 	if len(errors) > 0 {
 		logger.Errorf("%s Invalid pagination parameters: %v", logPrefix, errors)
-		c.JSON(http.StatusBadRequest, dto.BuildBaseResponse(dto.ValidationError, errors))
+		dto.RespondError(c, http.StatusBadRequest, dto.ValidationError, errors)
 		return
 	}
 
 	authors, code := h.service.GetAllAuthors(ctx, pagination)
 	if code != dto.Success {
 		logger.Errorf("%s Failed to get all authors: %v", logPrefix, dto.CodeMessage[code])
-		c.JSON(code.GetHTTPCode(), dto.BuildBaseResponse(code, nil))
+		dto.RespondError(c, code.GetHTTPCode(), code, nil)
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.BuildBaseResponse(dto.Success, authors))
+	dto.Respond(c, http.StatusOK, dto.Success, authors)
 }
 
 func (h *Handler) UpdateAuthor(c *gin.Context) {
@@ -108,31 +115,31 @@ func (h *Handler) UpdateAuthor(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		logger.Errorf("%s Invalid author ID format: %v", logPrefix, err)
-		c.JSON(http.StatusBadRequest, dto.BuildBaseResponse(dto.UUIDFormatInvalid, nil))
+		dto.RespondError(c, http.StatusBadRequest, dto.UUIDFormatInvalid, nil)
 		return
 	}
 
 	var req UpdateAuthorRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		logger.Errorf("%s Invalid request body: %v", logPrefix, err)
-		c.JSON(http.StatusBadRequest, dto.BuildBaseResponse(dto.BindingError, err.Error()))
+		dto.RespondBindingError(c, h.validator.BindingErrors(ctx, err))
 		return
 	}
 
-	if errors := validator.NewValidator().Validate(req); errors != nil {
+	if errors := h.validator.Validate(ctx, req); errors != nil {
 		logger.Errorf("%s Validation failed: %v", logPrefix, errors)
-		c.JSON(http.StatusBadRequest, dto.BuildBaseResponse(dto.ValidationError, errors))
+		dto.RespondError(c, http.StatusBadRequest, dto.ValidationError, errors)
 		return
 	}
 
 	code := h.service.UpdateAuthor(ctx, id, &req)
 	if code != dto.Success {
 		logger.Errorf("%s Failed to update author: %v", logPrefix, dto.CodeMessage[code])
-		c.JSON(code.GetHTTPCode(), dto.BuildBaseResponse(code, nil))
+		dto.RespondError(c, code.GetHTTPCode(), code, nil)
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.BuildBaseResponse(dto.Updated, nil))
+	dto.Respond(c, http.StatusOK, dto.Updated, nil)
 }
 
 func (h *Handler) DeleteAuthor(c *gin.Context) {
@@ -144,16 +151,16 @@ func (h *Handler) DeleteAuthor(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		logger.Errorf("%s Invalid author ID format: %v", logPrefix, err)
-		c.JSON(http.StatusBadRequest, dto.BuildBaseResponse(dto.UUIDFormatInvalid, nil))
+		dto.RespondError(c, http.StatusBadRequest, dto.UUIDFormatInvalid, nil)
 		return
 	}
 
 	code := h.service.DeleteAuthor(ctx, id)
 	if code != dto.Success {
 		logger.Errorf("%s Failed to delete author: %v", logPrefix, dto.CodeMessage[code])
-		c.JSON(code.GetHTTPCode(), dto.BuildBaseResponse(code, nil))
+		dto.RespondError(c, code.GetHTTPCode(), code, nil)
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.BuildBaseResponse(dto.Deleted, nil))
+	dto.Respond(c, http.StatusOK, dto.Deleted, nil)
 }
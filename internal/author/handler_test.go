@@ -8,12 +8,14 @@ import (
 	"net/http/httptest"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/sirawatc/simple-gin-crud/internal/shared/dto"
 	"github.com/sirawatc/simple-gin-crud/internal/shared/models"
 	pkgDto "github.com/sirawatc/simple-gin-crud/pkg/dto"
+	"github.com/sirawatc/simple-gin-crud/pkg/validator"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -39,6 +41,14 @@ func (m *MockService) GetAuthorByID(ctx context.Context, id uuid.UUID) (*Author,
 	return args.Get(0).(*Author), args.Get(1).(dto.Code)
 }
 
+func (m *MockService) GetAuthorByPenName(ctx context.Context, penName string) (*Author, dto.Code) {
+	args := m.Called(ctx, penName)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(dto.Code)
+	}
+	return args.Get(0).(*Author), args.Get(1).(dto.Code)
+}
+
 func (m *MockService) GetAllAuthors(ctx context.Context, pagination *pkgDto.PaginationRequest) (*pkgDto.PaginationDataResponse[Author], dto.Code) {
 	args := m.Called(ctx, pagination)
 	if args.Get(0) == nil {
@@ -68,7 +78,9 @@ func (suite *HandlerTestSuite) SetupTest() {
 	mockService := new(MockService)
 	logger := logrus.New()
 	logger.SetLevel(logrus.ErrorLevel)
-	handler := NewHandler(mockService, logger)
+	requestValidator := validator.NewValidator()
+	requestValidator.MustRegister(ValidationRules()...)
+	handler := NewHandler(mockService, requestValidator, logger)
 
 	suite.handler = handler
 	suite.mockService = mockService
@@ -85,7 +97,9 @@ func (suite *HandlerTestSuite) setupGinContext() (*gin.Context, *httptest.Respon
 func (suite *HandlerTestSuite) TestNewHandler() {
 	mockService := new(MockService)
 	logger := logrus.New()
-	handler := NewHandler(mockService, logger)
+	requestValidator := validator.NewValidator()
+	requestValidator.MustRegister(ValidationRules()...)
+	handler := NewHandler(mockService, requestValidator, logger)
 
 	suite.NotNil(handler)
 	suite.Equal(mockService, handler.service)
@@ -167,6 +181,13 @@ func (suite *HandlerTestSuite) TestCreateAuthor_BindingError() {
 
 	suite.Equal(http.StatusBadRequest, w.Code)
 	suite.Equal(dto.BindingError, response.Code)
+	suite.Equal([]interface{}{map[string]interface{}{
+		"field":   "birthYear",
+		"path":    "/birthYear",
+		"rule":    "type",
+		"param":   "number",
+		"message": "birthYear must be a number",
+	}}, response.Data)
 }
 
 func (suite *HandlerTestSuite) TestCreateAuthor_ValidationError() {
@@ -191,7 +212,79 @@ func (suite *HandlerTestSuite) TestCreateAuthor_ValidationError() {
 
 	suite.Equal(http.StatusBadRequest, w.Code)
 	suite.Equal(dto.ValidationError, response.Code)
-	suite.Equal([]interface{}{"BirthYear must be 1,800 or greater"}, response.Data)
+	suite.Equal([]interface{}{map[string]interface{}{
+		"field":   "birthYear",
+		"path":    "/birthYear",
+		"rule":    "min",
+		"param":   "1800",
+		"message": "birthYear must be 1,800 or greater",
+	}}, response.Data)
+}
+
+func (suite *HandlerTestSuite) TestCreateAuthor_DomainRules() {
+	c, w := suite.setupGinContext()
+
+	req := CreateAuthorRequest{
+		PenName:   " Rowling",
+		BirthYear: 3000,
+	}
+
+	reqBody, _ := json.Marshal(req)
+	c.Request = httptest.NewRequest("POST", "/authors", bytes.NewBuffer(reqBody))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	suite.handler.CreateAuthor(c)
+
+	var response dto.BaseResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	suite.NoError(err)
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.Equal(dto.ValidationError, response.Code)
+	suite.Equal([]interface{}{
+		map[string]interface{}{
+			"field":   "penName",
+			"path":    "/penName",
+			"rule":    "penname",
+			"message": "penName must not contain control characters or surrounding spaces and must be NFC-normalized",
+		},
+		map[string]interface{}{
+			"field":   "birthYear",
+			"path":    "/birthYear",
+			"rule":    "notfutureyear",
+			"message": "birthYear must not be in the future",
+		},
+	}, response.Data)
+	suite.mockService.AssertNotCalled(suite.T(), "CreateAuthor", mock.Anything, mock.Anything)
+}
+
+func (suite *HandlerTestSuite) TestCreateAuthor_ValidationError_Problem() {
+	c, w := suite.setupGinContext()
+
+	req := CreateAuthorRequest{
+		PenName:   "penName",
+		BirthYear: 1000,
+	}
+
+	reqBody, _ := json.Marshal(req)
+	c.Request = httptest.NewRequest("POST", "/authors", bytes.NewBuffer(reqBody))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Request.Header.Set("Accept", dto.ProblemContentType)
+
+	suite.handler.CreateAuthor(c)
+
+	var problem dto.Problem
+	err := json.Unmarshal(w.Body.Bytes(), &problem)
+	suite.NoError(err)
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.Equal(dto.ProblemContentType, w.Header().Get("Content-Type"))
+	suite.Equal("/problems/validation-error", problem.Type)
+	suite.Equal(http.StatusBadRequest, problem.Status)
+	suite.Equal(dto.ValidationError, problem.Code)
+	suite.Equal([]validator.FieldError{
+		{Field: "birthYear", Path: "/birthYear", Rule: "min", Param: "1800", Message: "birthYear must be 1,800 or greater"},
+	}, problem.Errors)
 }
 
 func (suite *HandlerTestSuite) TestCreateAuthor_AuthorAlreadyExists() {
@@ -278,6 +371,41 @@ func (suite *HandlerTestSuite) TestGetAuthor_Success() {
 	suite.mockService.AssertExpectations(suite.T())
 }
 
+func (suite *HandlerTestSuite) TestGetAuthor_NotModified() {
+	updatedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	authorID := uuid.New()
+	expectedAuthor := &Author{
+		BaseModel: models.BaseModel{ID: authorID, UpdatedAt: updatedAt},
+		PenName:   "Test Author",
+		BirthYear: 1990,
+	}
+
+	suite.mockService.On("GetAuthorByID", mock.Anything, authorID).Return(expectedAuthor, dto.Success)
+
+	tests := []struct {
+		name            string
+		ifModifiedSince time.Time
+		expectedStatus  int
+	}{
+		{name: "unchanged", ifModifiedSince: updatedAt, expectedStatus: http.StatusNotModified},
+		{name: "changed", ifModifiedSince: updatedAt.Add(-time.Minute), expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			c, w := suite.setupGinContext()
+			c.Request = httptest.NewRequest("GET", "/authors/"+authorID.String(), nil)
+			c.Request.Header.Set("If-Modified-Since", tt.ifModifiedSince.Format(http.TimeFormat))
+			c.Params = gin.Params{{Key: "id", Value: authorID.String()}}
+
+			suite.handler.GetAuthor(c)
+
+			suite.Equal(tt.expectedStatus, w.Code)
+			suite.Equal(updatedAt.Format(http.TimeFormat), w.Header().Get("Last-Modified"))
+		})
+	}
+}
+
 func (suite *HandlerTestSuite) TestGetAuthor_InvalidUUID() {
 	c, w := suite.setupGinContext()
 
@@ -558,7 +686,13 @@ func (suite *HandlerTestSuite) TestUpdateAuthor_ValidationError() {
 
 	suite.Equal(http.StatusBadRequest, w.Code)
 	suite.Equal(dto.ValidationError, response.Code)
-	suite.Equal([]interface{}{"BirthYear must be 1,800 or greater"}, response.Data)
+	suite.Equal([]interface{}{map[string]interface{}{
+		"field":   "birthYear",
+		"path":    "/birthYear",
+		"rule":    "min",
+		"param":   "1800",
+		"message": "birthYear must be 1,800 or greater",
+	}}, response.Data)
 }
 
 func (suite *HandlerTestSuite) TestUpdateAuthor_AuthorNotFound() {
@@ -0,0 +1,160 @@
+package author
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirawatc/simple-gin-crud/pkg/dto"
+	repoPkg "github.com/sirawatc/simple-gin-crud/pkg/repository"
+	"gorm.io/gorm"
+)
+
+// memoryRepository is a map-backed IRepository that mirrors the GORM
+// repository: soft-deleted rows are hidden but still hold their unique pen
+// name, and lookups return (nil, nil) when nothing matches.
+type memoryRepository struct {
+	mu      sync.RWMutex
+	authors map[uuid.UUID]Author
+	order   []uuid.UUID
+	now     func() time.Time
+}
+
+func NewMemoryRepository(transactionManager *repoPkg.MemoryTransactionManager) *memoryRepository {
+	repo := &memoryRepository{
+		authors: map[uuid.UUID]Author{},
+		now:     time.Now,
+	}
+	transactionManager.Register(repo)
+	return repo
+}
+
+func (r *memoryRepository) Snapshot() func() {
+	r.mu.RLock()
+	authors := maps.Clone(r.authors)
+	order := slices.Clone(r.order)
+	r.mu.RUnlock()
+
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.authors = authors
+		r.order = order
+	}
+}
+
+func (r *memoryRepository) Create(ctx context.Context, author *Author) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := author.BeforeCreate(nil); err != nil {
+		return err
+	}
+	if _, ok := r.authors[author.ID]; ok || r.penNameTaken(author.PenName, uuid.Nil) {
+		return gorm.ErrDuplicatedKey
+	}
+
+	now := r.now()
+	author.CreatedAt = now
+	author.UpdatedAt = now
+	r.authors[author.ID] = *author
+	r.order = append(r.order, author.ID)
+
+	return nil
+}
+
+func (r *memoryRepository) GetByID(ctx context.Context, id uuid.UUID) (*Author, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	author, ok := r.authors[id]
+	if !ok || author.DeletedAt.Valid {
+		return nil, nil
+	}
+	return &author, nil
+}
+
+func (r *memoryRepository) GetByPenName(ctx context.Context, penName string) (*Author, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, id := range r.order {
+		author := r.authors[id]
+		if !author.DeletedAt.Valid && author.PenName == penName {
+			return &author, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryRepository) GetAll(ctx context.Context, pagination *dto.PaginationRequest) (*dto.PaginationDataResponse[Author], error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	authors := []Author{}
+	for _, id := range r.order {
+		if author := r.authors[id]; !author.DeletedAt.Valid {
+			authors = append(authors, author)
+		}
+	}
+
+	total := int64(len(authors))
+	offset := min(pagination.GetOffset(), len(authors))
+	end := min(offset+pagination.GetLimit(), len(authors))
+
+	return dto.NewPaginationDataResponse(authors[offset:end], pagination, total), nil
+}
+
+func (r *memoryRepository) Update(ctx context.Context, id uuid.UUID, author *Author) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.authors[id]
+	if !ok || existing.DeletedAt.Valid {
+		return nil
+	}
+
+	// Match GORM's Updates with a struct, which skips zero-value fields.
+	if author.PenName != "" {
+		if r.penNameTaken(author.PenName, id) {
+			return gorm.ErrDuplicatedKey
+		}
+		existing.PenName = author.PenName
+	}
+	if author.BirthYear != 0 {
+		existing.BirthYear = author.BirthYear
+	}
+	existing.UpdatedAt = r.now()
+	r.authors[id] = existing
+
+	return nil
+}
+
+func (r *memoryRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.authors[id]
+	if !ok || existing.DeletedAt.Valid {
+		return nil
+	}
+
+	existing.DeletedAt = gorm.DeletedAt{Time: r.now(), Valid: true}
+	r.authors[id] = existing
+
+	return nil
+}
+
+// penNameTaken checks soft-deleted rows too, since the unique constraint in
+// the database does not exclude them.
+func (r *memoryRepository) penNameTaken(penName string, exclude uuid.UUID) bool {
+	for id, author := range r.authors {
+		if id != exclude && author.PenName == penName {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,189 @@
+package author
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/sirawatc/simple-gin-crud/internal/shared/dto"
+	pkgDto "github.com/sirawatc/simple-gin-crud/pkg/dto"
+	repoPkg "github.com/sirawatc/simple-gin-crud/pkg/repository"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type MemoryRepositoryTestSuite struct {
+	suite.Suite
+	tm   *repoPkg.MemoryTransactionManager
+	repo *memoryRepository
+	ctx  context.Context
+}
+
+func (suite *MemoryRepositoryTestSuite) SetupTest() {
+	suite.tm = repoPkg.NewMemoryTransactionManager()
+	suite.repo = NewMemoryRepository(suite.tm)
+	suite.ctx = context.Background()
+}
+
+func (suite *MemoryRepositoryTestSuite) create(penName string) *Author {
+	author := &Author{PenName: penName, BirthYear: 1990}
+	suite.Require().NoError(suite.repo.Create(suite.ctx, author))
+	return author
+}
+
+func (suite *MemoryRepositoryTestSuite) TestImplementsInterface() {
+	suite.Implements((*IRepository)(nil), suite.repo)
+}
+
+func (suite *MemoryRepositoryTestSuite) TestCreate_AssignsIDAndTimestamps() {
+	author := suite.create("Test Author")
+
+	suite.NotEqual(uuid.Nil, author.ID)
+	suite.False(author.CreatedAt.IsZero())
+	suite.Equal(author.CreatedAt, author.UpdatedAt)
+}
+
+func (suite *MemoryRepositoryTestSuite) TestCreate_DuplicatePenName() {
+	suite.create("Test Author")
+
+	err := suite.repo.Create(suite.ctx, &Author{PenName: "Test Author", BirthYear: 1990})
+
+	suite.ErrorIs(err, gorm.ErrDuplicatedKey)
+}
+
+func (suite *MemoryRepositoryTestSuite) TestGetByID() {
+	author := suite.create("Test Author")
+
+	found, err := suite.repo.GetByID(suite.ctx, author.ID)
+	suite.NoError(err)
+	suite.Equal(author.PenName, found.PenName)
+
+	found.PenName = "Mutated"
+	again, _ := suite.repo.GetByID(suite.ctx, author.ID)
+	suite.Equal("Test Author", again.PenName, "returned values must not alias the store")
+
+	missing, err := suite.repo.GetByID(suite.ctx, uuid.New())
+	suite.NoError(err)
+	suite.Nil(missing)
+}
+
+func (suite *MemoryRepositoryTestSuite) TestGetByPenName() {
+	author := suite.create("Test Author")
+
+	found, err := suite.repo.GetByPenName(suite.ctx, "Test Author")
+	suite.NoError(err)
+	suite.Equal(author.ID, found.ID)
+
+	missing, err := suite.repo.GetByPenName(suite.ctx, "Unknown")
+	suite.NoError(err)
+	suite.Nil(missing)
+}
+
+func (suite *MemoryRepositoryTestSuite) TestGetAll_Pagination() {
+	for _, penName := range []string{"A", "B", "C", "D", "E"} {
+		suite.create(penName)
+	}
+
+	tests := []struct {
+		name       string
+		pagination *pkgDto.PaginationRequest
+		expected   []string
+		totalPages int
+	}{
+		{name: "first page", pagination: &pkgDto.PaginationRequest{Page: 1, PageSize: 2}, expected: []string{"A", "B"}, totalPages: 3},
+		{name: "last partial page", pagination: &pkgDto.PaginationRequest{Page: 3, PageSize: 2}, expected: []string{"E"}, totalPages: 3},
+		{name: "page past the end", pagination: &pkgDto.PaginationRequest{Page: 4, PageSize: 2}, expected: []string{}, totalPages: 3},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			result, err := suite.repo.GetAll(suite.ctx, tt.pagination)
+
+			suite.NoError(err)
+			penNames := []string{}
+			for _, author := range result.Items {
+				penNames = append(penNames, author.PenName)
+			}
+			suite.Equal(tt.expected, penNames)
+			suite.Equal(int64(5), result.Pagination.TotalItems)
+			suite.Equal(tt.totalPages, result.Pagination.TotalPages)
+		})
+	}
+}
+
+func (suite *MemoryRepositoryTestSuite) TestUpdate() {
+	author := suite.create("Test Author")
+	other := suite.create("Other Author")
+
+	suite.NoError(suite.repo.Update(suite.ctx, author.ID, &Author{BirthYear: 1975}))
+	found, _ := suite.repo.GetByID(suite.ctx, author.ID)
+	suite.Equal("Test Author", found.PenName, "zero-value fields are not updated")
+	suite.Equal(1975, found.BirthYear)
+
+	err := suite.repo.Update(suite.ctx, author.ID, &Author{PenName: other.PenName})
+	suite.ErrorIs(err, gorm.ErrDuplicatedKey)
+
+	suite.NoError(suite.repo.Update(suite.ctx, uuid.New(), &Author{PenName: "Nobody"}))
+}
+
+func (suite *MemoryRepositoryTestSuite) TestDelete_IsSoft() {
+	author := suite.create("Test Author")
+	suite.create("Other Author")
+
+	suite.NoError(suite.repo.Delete(suite.ctx, author.ID))
+
+	found, err := suite.repo.GetByID(suite.ctx, author.ID)
+	suite.NoError(err)
+	suite.Nil(found)
+
+	found, err = suite.repo.GetByPenName(suite.ctx, "Test Author")
+	suite.NoError(err)
+	suite.Nil(found)
+
+	all, _ := suite.repo.GetAll(suite.ctx, &pkgDto.PaginationRequest{Page: 1, PageSize: 10})
+	suite.Len(all.Items, 1)
+	suite.Equal(int64(1), all.Pagination.TotalItems)
+
+	err = suite.repo.Create(suite.ctx, &Author{PenName: "Test Author", BirthYear: 1990})
+	suite.ErrorIs(err, gorm.ErrDuplicatedKey, "soft-deleted rows keep their unique pen name")
+
+	suite.NoError(suite.repo.Delete(suite.ctx, author.ID))
+}
+
+func (suite *MemoryRepositoryTestSuite) TestTransaction_Rollback() {
+	kept := suite.create("Kept")
+
+	err := suite.tm.WithinTransaction(suite.ctx, func(ctx context.Context) error {
+		suite.Require().NoError(suite.repo.Create(ctx, &Author{PenName: "Rolled Back", BirthYear: 1990}))
+		suite.Require().NoError(suite.repo.Delete(ctx, kept.ID))
+		return errors.New("boom")
+	})
+	suite.Error(err)
+
+	found, _ := suite.repo.GetByPenName(suite.ctx, "Rolled Back")
+	suite.Nil(found)
+	found, _ = suite.repo.GetByID(suite.ctx, kept.ID)
+	suite.NotNil(found)
+}
+
+func (suite *MemoryRepositoryTestSuite) TestService_WithMemoryRepository() {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	service := NewService(suite.repo, suite.tm, logger)
+
+	created, code := service.CreateAuthor(suite.ctx, &CreateAuthorRequest{PenName: "Test Author", BirthYear: 1990})
+	suite.Equal(dto.Success, code)
+
+	_, code = service.CreateAuthor(suite.ctx, &CreateAuthorRequest{PenName: "Test Author", BirthYear: 1991})
+	suite.Equal(dto.AuthorAlreadyExists, code)
+
+	suite.Equal(dto.Success, service.DeleteAuthor(suite.ctx, created.ID))
+	suite.Equal(dto.AuthorNotFound, service.UpdateAuthor(suite.ctx, created.ID, &UpdateAuthorRequest{PenName: "New", BirthYear: 1990}))
+}
+
+func TestMemoryRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(MemoryRepositoryTestSuite))
+}
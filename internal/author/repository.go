@@ -23,11 +23,11 @@ func NewRepository(transactionManager repoPkg.ITransactionManager, logger *logru
 	}
 }
 
-func (r *repository) Create(ctx context.Context, author *Author, tx ...*gorm.DB) error {
+func (r *repository) Create(ctx context.Context, author *Author) error {
 	logPrefix := "[AuthorRepository#Create]"
 	logger := logger.InjectRequestIDWithLogger(ctx, r.logger)
 
-	db := r.transactionManager.GetDB(tx...)
+	db := r.transactionManager.GetDBWithContext(ctx)
 
 	if err := db.Create(author).Error; err != nil {
 		logger.Errorf("%s Failed to create author: %v", logPrefix, err)
@@ -37,11 +37,11 @@ func (r *repository) Create(ctx context.Context, author *Author, tx ...*gorm.DB)
 	return nil
 }
 
-func (r *repository) GetByID(ctx context.Context, id uuid.UUID, tx ...*gorm.DB) (*Author, error) {
+func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*Author, error) {
 	logPrefix := "[AuthorRepository#GetByID]"
 	logger := logger.InjectRequestIDWithLogger(ctx, r.logger)
 
-	db := r.transactionManager.GetDB(tx...)
+	db := r.transactionManager.GetDBWithContext(ctx)
 	var author Author
 
 	if err := db.First(&author, "id = ?", id).Error; err != nil {
@@ -56,11 +56,11 @@ func (r *repository) GetByID(ctx context.Context, id uuid.UUID, tx ...*gorm.DB)
 	return &author, nil
 }
 
-func (r *repository) GetByPenName(ctx context.Context, penName string, tx ...*gorm.DB) (*Author, error) {
+func (r *repository) GetByPenName(ctx context.Context, penName string) (*Author, error) {
 	logPrefix := "[AuthorRepository#GetByPenName]"
 	logger := logger.InjectRequestIDWithLogger(ctx, r.logger)
 
-	db := r.transactionManager.GetDB(tx...)
+	db := r.transactionManager.GetDBWithContext(ctx)
 	var author Author
 
 	if err := db.First(&author, "pen_name = ?", penName).Error; err != nil {
@@ -75,11 +75,11 @@ func (r *repository) GetByPenName(ctx context.Context, penName string, tx ...*go
 	return &author, nil
 }
 
-func (r *repository) GetAll(ctx context.Context, pagination *dto.PaginationRequest, tx ...*gorm.DB) (*dto.PaginationDataResponse[Author], error) {
+func (r *repository) GetAll(ctx context.Context, pagination *dto.PaginationRequest) (*dto.PaginationDataResponse[Author], error) {
 	logPrefix := "[AuthorRepository#GetAll]"
 	logger := logger.InjectRequestIDWithLogger(ctx, r.logger)
 
-	db := r.transactionManager.GetDB(tx...)
+	db := r.transactionManager.GetDBWithContext(ctx)
 	var authors []Author
 	var total int64
 
@@ -103,11 +103,11 @@ func (r *repository) GetAll(ctx context.Context, pagination *dto.PaginationReque
 	return dto.NewPaginationDataResponse(authors, pagination, total), nil
 }
 
-func (r *repository) Update(ctx context.Context, id uuid.UUID, author *Author, tx ...*gorm.DB) error {
+func (r *repository) Update(ctx context.Context, id uuid.UUID, author *Author) error {
 	logPrefix := "[AuthorRepository#Update]"
 	logger := logger.InjectRequestIDWithLogger(ctx, r.logger)
 
-	db := r.transactionManager.GetDB(tx...)
+	db := r.transactionManager.GetDBWithContext(ctx)
 
 	if err := db.Model(&Author{}).Where("id = ?", id).Updates(author).Error; err != nil {
 		logger.Errorf("%s Failed to update author: %v", logPrefix, err)
@@ -117,11 +117,11 @@ func (r *repository) Update(ctx context.Context, id uuid.UUID, author *Author, t
 	return nil
 }
 
-func (r *repository) Delete(ctx context.Context, id uuid.UUID, tx ...*gorm.DB) error {
+func (r *repository) Delete(ctx context.Context, id uuid.UUID) error {
 	logPrefix := "[AuthorRepository#Delete]"
 	logger := logger.InjectRequestIDWithLogger(ctx, r.logger)
 
-	db := r.transactionManager.GetDB(tx...)
+	db := r.transactionManager.GetDBWithContext(ctx)
 
 	if err := db.Delete(&Author{}, "id = ?", id).Error; err != nil {
 		logger.Errorf("%s Failed to delete author: %v", logPrefix, err)
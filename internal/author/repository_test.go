@@ -8,6 +8,7 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
 	"github.com/sirawatc/simple-gin-crud/pkg/dto"
+	repoPkg "github.com/sirawatc/simple-gin-crud/pkg/repository"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -19,12 +20,20 @@ type MockTransactionManager struct {
 	mock.Mock
 }
 
-func (m *MockTransactionManager) Transaction(fn func(tx *gorm.DB) error) error {
-	args := m.Called(fn)
+func (m *MockTransactionManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error, opts ...repoPkg.TxOption) error {
+	args := m.Called(ctx, fn)
 	return args.Error(0)
 }
 
-func (m *MockTransactionManager) GetDB(tx ...*gorm.DB) *gorm.DB {
+func (m *MockTransactionManager) GetDB() *gorm.DB {
+	args := m.Called()
+	if db, ok := args.Get(0).(*gorm.DB); ok {
+		return db
+	}
+	return nil
+}
+
+func (m *MockTransactionManager) GetDBWithContext(ctx context.Context) *gorm.DB {
 	args := m.Called()
 	if db, ok := args.Get(0).(*gorm.DB); ok {
 		return db
@@ -81,18 +90,17 @@ func (suite *RepositoryTestSuite) TestCreate_Success() {
 		PenName:   "Test Author",
 		BirthYear: 1990,
 	}
-	addRow := sqlmock.NewRows([]string{"id"}).AddRow(uuid.New())
 
-	suite.mockTM.On("GetDB").Return(suite.db)
+	suite.mockTM.On("GetDBWithContext").Return(suite.db)
 
 	suite.mock.ExpectBegin()
-	suite.mock.ExpectQuery("INSERT INTO \"authors\" (.+)").WillReturnRows(addRow)
+	suite.mock.ExpectExec("INSERT INTO \"authors\" (.+)").WillReturnResult(sqlmock.NewResult(1, 1))
 	suite.mock.ExpectCommit()
 
 	err := suite.repo.Create(context.Background(), author)
 
 	suite.NoError(err)
-	suite.NotNil(author.ID)
+	suite.NotEqual(uuid.Nil, author.ID)
 	suite.NoError(suite.mock.ExpectationsWereMet())
 }
 
@@ -103,10 +111,10 @@ func (suite *RepositoryTestSuite) TestCreate_Error_DuplicateKey() {
 		BirthYear: 1990,
 	}
 
-	suite.mockTM.On("GetDB").Return(suite.db)
+	suite.mockTM.On("GetDBWithContext").Return(suite.db)
 
 	suite.mock.ExpectBegin()
-	suite.mock.ExpectQuery("INSERT INTO \"authors\" (.+)").WillReturnError(errors.New(errMsg))
+	suite.mock.ExpectExec("INSERT INTO \"authors\" (.+)").WillReturnError(errors.New(errMsg))
 	suite.mock.ExpectRollback()
 
 	err := suite.repo.Create(context.Background(), author)
@@ -123,10 +131,10 @@ func (suite *RepositoryTestSuite) TestCreate_Error_ConnectionFailed() {
 		BirthYear: 1990,
 	}
 
-	suite.mockTM.On("GetDB").Return(suite.db)
+	suite.mockTM.On("GetDBWithContext").Return(suite.db)
 
 	suite.mock.ExpectBegin()
-	suite.mock.ExpectQuery("INSERT INTO \"authors\" (.+)").WillReturnError(errors.New(errMsg))
+	suite.mock.ExpectExec("INSERT INTO \"authors\" (.+)").WillReturnError(errors.New(errMsg))
 	suite.mock.ExpectRollback()
 
 	err := suite.repo.Create(context.Background(), author)
@@ -141,7 +149,7 @@ func (suite *RepositoryTestSuite) TestGetByID_Success() {
 	rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "deleted_at", "pen_name", "birth_year"}).
 		AddRow(uuid.New(), nil, nil, nil, "Test Author", 1990)
 
-	suite.mockTM.On("GetDB").Return(suite.db)
+	suite.mockTM.On("GetDBWithContext").Return(suite.db)
 
 	suite.mock.ExpectQuery("SELECT \\* FROM \"authors\" WHERE id = \\$1 (.+)").WillReturnRows(rows)
 
@@ -155,7 +163,7 @@ func (suite *RepositoryTestSuite) TestGetByID_Success() {
 func (suite *RepositoryTestSuite) TestGetByID_NotFound() {
 	authorID := uuid.New()
 
-	suite.mockTM.On("GetDB").Return(suite.db)
+	suite.mockTM.On("GetDBWithContext").Return(suite.db)
 
 	suite.mock.ExpectQuery("SELECT \\* FROM \"authors\" WHERE id = \\$1 (.+)").WillReturnError(gorm.ErrRecordNotFound)
 
@@ -170,7 +178,7 @@ func (suite *RepositoryTestSuite) TestGetByID_DatabaseError() {
 	authorID := uuid.New()
 	errMsg := "connection failed"
 
-	suite.mockTM.On("GetDB").Return(suite.db)
+	suite.mockTM.On("GetDBWithContext").Return(suite.db)
 
 	suite.mock.ExpectQuery("SELECT \\* FROM \"authors\" WHERE id = \\$1 (.+)").WillReturnError(errors.New(errMsg))
 
@@ -187,7 +195,7 @@ func (suite *RepositoryTestSuite) TestGetByPenName_Success() {
 	rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "deleted_at", "pen_name", "birth_year"}).
 		AddRow(uuid.New(), nil, nil, nil, "Test Author", 1990)
 
-	suite.mockTM.On("GetDB").Return(suite.db)
+	suite.mockTM.On("GetDBWithContext").Return(suite.db)
 
 	suite.mock.ExpectQuery("SELECT \\* FROM \"authors\" WHERE pen_name = \\$1 (.+)").WillReturnRows(rows)
 
@@ -201,7 +209,7 @@ func (suite *RepositoryTestSuite) TestGetByPenName_Success() {
 func (suite *RepositoryTestSuite) TestGetByPenName_NotFound() {
 	penName := "Non Existent Author"
 
-	suite.mockTM.On("GetDB").Return(suite.db)
+	suite.mockTM.On("GetDBWithContext").Return(suite.db)
 
 	suite.mock.ExpectQuery("SELECT \\* FROM \"authors\" WHERE pen_name = \\$1 (.+)").WillReturnError(gorm.ErrRecordNotFound)
 
@@ -216,7 +224,7 @@ func (suite *RepositoryTestSuite) TestGetByPenName_DatabaseError() {
 	penName := "Test Author"
 	errMsg := "connection failed"
 
-	suite.mockTM.On("GetDB").Return(suite.db)
+	suite.mockTM.On("GetDBWithContext").Return(suite.db)
 
 	suite.mock.ExpectQuery("SELECT \\* FROM \"authors\" WHERE pen_name = \\$1 (.+)").WillReturnError(errors.New(errMsg))
 
@@ -239,7 +247,7 @@ func (suite *RepositoryTestSuite) TestGetAll_Success() {
 		AddRow(uuid.New(), nil, nil, nil, "Author 1", 1990).
 		AddRow(uuid.New(), nil, nil, nil, "Author 2", 1985)
 
-	suite.mockTM.On("GetDB").Return(suite.db)
+	suite.mockTM.On("GetDBWithContext").Return(suite.db)
 
 	suite.mock.ExpectQuery("SELECT count\\(\\*\\) FROM \"authors\" (.+)").WillReturnRows(countRows)
 	suite.mock.ExpectQuery("SELECT \\* FROM \"authors\" (.+)").WillReturnRows(dataRows)
@@ -263,7 +271,7 @@ func (suite *RepositoryTestSuite) TestGetAll_EmptyResult() {
 	countRows := sqlmock.NewRows([]string{"count"}).AddRow(0)
 	dataRows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "deleted_at", "pen_name", "birth_year"})
 
-	suite.mockTM.On("GetDB").Return(suite.db)
+	suite.mockTM.On("GetDBWithContext").Return(suite.db)
 
 	suite.mock.ExpectQuery("SELECT count\\(\\*\\) FROM \"authors\" (.+)").WillReturnRows(countRows)
 	suite.mock.ExpectQuery("SELECT \\* FROM \"authors\" (.+)").WillReturnRows(dataRows)
@@ -285,7 +293,7 @@ func (suite *RepositoryTestSuite) TestGetAll_DatabaseError() {
 	}
 	errMsg := "connection failed"
 
-	suite.mockTM.On("GetDB").Return(suite.db)
+	suite.mockTM.On("GetDBWithContext").Return(suite.db)
 
 	suite.mock.ExpectQuery("SELECT count\\(\\*\\) FROM \"authors\" (.+)").WillReturnError(errors.New(errMsg))
 
@@ -304,7 +312,7 @@ func (suite *RepositoryTestSuite) TestUpdate_Success() {
 		BirthYear: 1995,
 	}
 
-	suite.mockTM.On("GetDB").Return(suite.db)
+	suite.mockTM.On("GetDBWithContext").Return(suite.db)
 
 	suite.mock.ExpectBegin()
 	suite.mock.ExpectExec("UPDATE \"authors\" SET (.+) WHERE id = (.+)").WillReturnResult(sqlmock.NewResult(1, 1))
@@ -323,7 +331,7 @@ func (suite *RepositoryTestSuite) TestUpdate_NotFound() {
 		BirthYear: 1995,
 	}
 
-	suite.mockTM.On("GetDB").Return(suite.db)
+	suite.mockTM.On("GetDBWithContext").Return(suite.db)
 
 	suite.mock.ExpectBegin()
 	suite.mock.ExpectExec("UPDATE \"authors\" SET (.+) WHERE id = (.+)").WillReturnResult(sqlmock.NewResult(0, 0))
@@ -343,7 +351,7 @@ func (suite *RepositoryTestSuite) TestUpdate_DatabaseError() {
 	}
 	errMsg := "connection failed"
 
-	suite.mockTM.On("GetDB").Return(suite.db)
+	suite.mockTM.On("GetDBWithContext").Return(suite.db)
 
 	suite.mock.ExpectBegin()
 	suite.mock.ExpectExec("UPDATE \"authors\" SET (.+) WHERE id = (.+)").WillReturnError(errors.New(errMsg))
@@ -359,7 +367,7 @@ func (suite *RepositoryTestSuite) TestUpdate_DatabaseError() {
 func (suite *RepositoryTestSuite) TestDelete_Success() {
 	authorID := uuid.New()
 
-	suite.mockTM.On("GetDB").Return(suite.db)
+	suite.mockTM.On("GetDBWithContext").Return(suite.db)
 
 	suite.mock.ExpectBegin()
 	suite.mock.ExpectExec("UPDATE \"authors\" SET \"deleted_at\"=(.+) WHERE id = (.+)").WillReturnResult(sqlmock.NewResult(1, 1))
@@ -374,7 +382,7 @@ func (suite *RepositoryTestSuite) TestDelete_Success() {
 func (suite *RepositoryTestSuite) TestDelete_NotFound() {
 	authorID := uuid.New()
 
-	suite.mockTM.On("GetDB").Return(suite.db)
+	suite.mockTM.On("GetDBWithContext").Return(suite.db)
 
 	suite.mock.ExpectBegin()
 	suite.mock.ExpectExec("UPDATE \"authors\" SET \"deleted_at\"=(.+) WHERE id = (.+)").WillReturnResult(sqlmock.NewResult(0, 0))
@@ -390,7 +398,7 @@ func (suite *RepositoryTestSuite) TestDelete_DatabaseError() {
 	authorID := uuid.New()
 	errMsg := "connection failed"
 
-	suite.mockTM.On("GetDB").Return(suite.db)
+	suite.mockTM.On("GetDBWithContext").Return(suite.db)
 
 	suite.mock.ExpectBegin()
 	suite.mock.ExpectExec("UPDATE \"authors\" SET \"deleted_at\"=(.+) WHERE id = (.+)").WillReturnError(errors.New(errMsg))
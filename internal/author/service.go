@@ -2,58 +2,89 @@ package author
 
 import (
 	"context"
+	"errors"
 
 	"github.com/google/uuid"
 	"github.com/sirawatc/simple-gin-crud/internal/shared/dto"
+	"github.com/sirawatc/simple-gin-crud/internal/shared/transaction"
 	pkgDto "github.com/sirawatc/simple-gin-crud/pkg/dto"
 	"github.com/sirawatc/simple-gin-crud/pkg/logger"
+	"github.com/sirawatc/simple-gin-crud/pkg/metrics"
+	repoPkg "github.com/sirawatc/simple-gin-crud/pkg/repository"
+	"github.com/sirawatc/simple-gin-crud/pkg/tracing"
 	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
 )
 
 type service struct {
-	repo   IRepository
-	logger *logrus.Logger
+	repo               IRepository
+	transactionManager repoPkg.ITransactionManager
+	logger             *logrus.Logger
 }
 
-func NewService(repo IRepository, logger *logrus.Logger) *service {
+func NewService(repo IRepository, transactionManager repoPkg.ITransactionManager, logger *logrus.Logger) *service {
 	return &service{
-		repo:   repo,
-		logger: logger,
+		repo:               repo,
+		transactionManager: transactionManager,
+		logger:             logger,
 	}
 }
 
 func (s *service) CreateAuthor(ctx context.Context, req *CreateAuthorRequest) (*Author, dto.Code) {
+	ctx, span := tracing.StartSpan(ctx, "AuthorService.CreateAuthor")
+	defer span.End()
+
 	logPrefix := "[AuthorService#CreateAuthor]"
 	logger := logger.InjectRequestIDWithLogger(ctx, s.logger)
 
-	author, err := s.repo.GetByPenName(ctx, req.PenName)
+	var author *Author
+	code, err := transaction.Run(ctx, s.transactionManager, func(ctx context.Context) dto.Code {
+		existing, err := s.repo.GetByPenName(ctx, req.PenName)
+		if err != nil {
+			logger.Errorf("%s Failed to get author by pen name: %v", logPrefix, err)
+			return dto.InternalError
+		}
+		if existing != nil {
+			logger.Infof("%s Author already exists: %v", logPrefix, existing.ID)
+			metrics.RecordDomainEvent(metrics.DomainAuthor, metrics.EventAlreadyExists)
+			return dto.AuthorAlreadyExists
+		}
+
+		logger.Infof("%s Creating author: %+v", logPrefix, req)
+
+		author = &Author{
+			PenName:   req.PenName,
+			BirthYear: req.BirthYear,
+		}
+
+		if err := s.repo.Create(ctx, author); err != nil {
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				// A concurrent request created the same pen name after our check.
+				logger.Infof("%s Author already exists: %v", logPrefix, req.PenName)
+				metrics.RecordDomainEvent(metrics.DomainAuthor, metrics.EventAlreadyExists)
+				return dto.AuthorAlreadyExists
+			}
+			logger.Errorf("%s Failed to create author: %v", logPrefix, err)
+			return dto.InternalError
+		}
+		return dto.Success
+	})
 	if err != nil {
-		logger.Errorf("%s Failed to get author by pen name: %v", logPrefix, err)
-		return nil, dto.InternalError
+		logger.Errorf("%s Transaction failed: %v", logPrefix, err)
 	}
-	if author != nil {
-		logger.Infof("%s Author already exists: %v", logPrefix, author.ID)
-		return nil, dto.AuthorAlreadyExists
-	}
-
-	logger.Infof("%s Creating author: %+v", logPrefix, req)
-
-	author = &Author{
-		PenName:   req.PenName,
-		BirthYear: req.BirthYear,
-	}
-
-	err = s.repo.Create(ctx, author)
-	if err != nil {
-		logger.Errorf("%s Failed to create author: %v", logPrefix, err)
-		return nil, dto.InternalError
+	if code != dto.Success {
+		return nil, code
 	}
 
 	logger.Infof("%s Author created successfully: %v", logPrefix, author.ID)
+	metrics.RecordDomainEvent(metrics.DomainAuthor, metrics.EventCreated)
 	return author, dto.Success
 }
 
 func (s *service) GetAuthorByID(ctx context.Context, id uuid.UUID) (*Author, dto.Code) {
+	ctx, span := tracing.StartSpan(ctx, "AuthorService.GetAuthorByID")
+	defer span.End()
+
 	logPrefix := "[AuthorService#GetAuthorByID]"
 	logger := logger.InjectRequestIDWithLogger(ctx, s.logger)
 
@@ -67,6 +98,7 @@ func (s *service) GetAuthorByID(ctx context.Context, id uuid.UUID) (*Author, dto
 
 	if author == nil {
 		logger.Infof("%s Author not found: %v", logPrefix, id)
+		metrics.RecordDomainEvent(metrics.DomainAuthor, metrics.EventNotFound)
 		return nil, dto.Success
 	}
 
@@ -74,7 +106,35 @@ func (s *service) GetAuthorByID(ctx context.Context, id uuid.UUID) (*Author, dto
 	return author, dto.Success
 }
 
+func (s *service) GetAuthorByPenName(ctx context.Context, penName string) (*Author, dto.Code) {
+	ctx, span := tracing.StartSpan(ctx, "AuthorService.GetAuthorByPenName")
+	defer span.End()
+
+	logPrefix := "[AuthorService#GetAuthorByPenName]"
+	logger := logger.InjectRequestIDWithLogger(ctx, s.logger)
+
+	logger.Infof("%s Getting author by pen name: %v", logPrefix, penName)
+
+	author, err := s.repo.GetByPenName(ctx, penName)
+	if err != nil {
+		logger.Errorf("%s Failed to get author by pen name: %v", logPrefix, err)
+		return nil, dto.InternalError
+	}
+
+	if author == nil {
+		logger.Infof("%s Author not found: %v", logPrefix, penName)
+		metrics.RecordDomainEvent(metrics.DomainAuthor, metrics.EventNotFound)
+		return nil, dto.AuthorNotFound
+	}
+
+	logger.Infof("%s Author retrieved successfully: %v", logPrefix, author.ID)
+	return author, dto.Success
+}
+
 func (s *service) GetAllAuthors(ctx context.Context, pagination *pkgDto.PaginationRequest) (*pkgDto.PaginationDataResponse[Author], dto.Code) {
+	ctx, span := tracing.StartSpan(ctx, "AuthorService.GetAllAuthors")
+	defer span.End()
+
 	logPrefix := "[AuthorService#GetAllAuthors]"
 	logger := logger.InjectRequestIDWithLogger(ctx, s.logger)
 
@@ -96,30 +156,58 @@ func (s *service) GetAllAuthors(ctx context.Context, pagination *pkgDto.Paginati
 }
 
 func (s *service) UpdateAuthor(ctx context.Context, id uuid.UUID, req *UpdateAuthorRequest) dto.Code {
+	ctx, span := tracing.StartSpan(ctx, "AuthorService.UpdateAuthor")
+	defer span.End()
+
 	logPrefix := "[AuthorService#UpdateAuthor]"
 	logger := logger.InjectRequestIDWithLogger(ctx, s.logger)
 
-	author, err := s.repo.GetByID(ctx, id)
+	code, err := transaction.Run(ctx, s.transactionManager, func(ctx context.Context) dto.Code {
+		author, err := s.repo.GetByID(ctx, id)
+		if err != nil {
+			logger.Errorf("%s Failed to get author by ID: %v", logPrefix, err)
+			return dto.InternalError
+		}
+		if author == nil {
+			logger.Infof("%s Author not found: %v", logPrefix, id)
+			metrics.RecordDomainEvent(metrics.DomainAuthor, metrics.EventNotFound)
+			return dto.AuthorNotFound
+		}
+
+		existing, err := s.repo.GetByPenName(ctx, req.PenName)
+		if err != nil {
+			logger.Errorf("%s Failed to get author by pen name: %v", logPrefix, err)
+			return dto.InternalError
+		}
+		if existing != nil && existing.ID != id {
+			logger.Infof("%s Author already exists: %v", logPrefix, existing.ID)
+			metrics.RecordDomainEvent(metrics.DomainAuthor, metrics.EventAlreadyExists)
+			return dto.AuthorAlreadyExists
+		}
+
+		logger.Infof("%s Updating author %v: %+v", logPrefix, id, req)
+
+		author = &Author{
+			PenName:   req.PenName,
+			BirthYear: req.BirthYear,
+		}
+
+		if err := s.repo.Update(ctx, id, author); err != nil {
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				logger.Infof("%s Author already exists: %v", logPrefix, req.PenName)
+				metrics.RecordDomainEvent(metrics.DomainAuthor, metrics.EventAlreadyExists)
+				return dto.AuthorAlreadyExists
+			}
+			logger.Errorf("%s Failed to update author: %v", logPrefix, err)
+			return dto.InternalError
+		}
+		return dto.Success
+	})
 	if err != nil {
-		logger.Errorf("%s Failed to get author by ID: %v", logPrefix, err)
-		return dto.InternalError
+		logger.Errorf("%s Transaction failed: %v", logPrefix, err)
 	}
-	if author == nil {
-		logger.Infof("%s Author not found: %v", logPrefix, id)
-		return dto.AuthorNotFound
-	}
-
-	logger.Infof("%s Updating author %v: %+v", logPrefix, id, req)
-
-	author = &Author{
-		PenName:   req.PenName,
-		BirthYear: req.BirthYear,
-	}
-
-	err = s.repo.Update(ctx, id, author)
-	if err != nil {
-		logger.Errorf("%s Failed to update author: %v", logPrefix, err)
-		return dto.InternalError
+	if code != dto.Success {
+		return code
 	}
 
 	logger.Infof("%s Author %v updated successfully", logPrefix, id)
@@ -127,6 +215,9 @@ func (s *service) UpdateAuthor(ctx context.Context, id uuid.UUID, req *UpdateAut
 }
 
 func (s *service) DeleteAuthor(ctx context.Context, id uuid.UUID) dto.Code {
+	ctx, span := tracing.StartSpan(ctx, "AuthorService.DeleteAuthor")
+	defer span.End()
+
 	logPrefix := "[AuthorService#DeleteAuthor]"
 	logger := logger.InjectRequestIDWithLogger(ctx, s.logger)
 
@@ -9,6 +9,7 @@ import (
 	"github.com/sirawatc/simple-gin-crud/internal/shared/dto"
 	"github.com/sirawatc/simple-gin-crud/internal/shared/models"
 	pkgDto "github.com/sirawatc/simple-gin-crud/pkg/dto"
+	repoPkg "github.com/sirawatc/simple-gin-crud/pkg/repository"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -19,72 +20,42 @@ type MockRepository struct {
 	mock.Mock
 }
 
-func (m *MockRepository) Create(ctx context.Context, author *Author, tx ...*gorm.DB) error {
-	var args mock.Arguments
-	if len(tx) > 0 {
-		args = m.Called(ctx, author, tx)
-	} else {
-		args = m.Called(ctx, author)
-	}
+func (m *MockRepository) Create(ctx context.Context, author *Author) error {
+	args := m.Called(ctx, author)
 	return args.Error(0)
 }
 
-func (m *MockRepository) GetByID(ctx context.Context, id uuid.UUID, tx ...*gorm.DB) (*Author, error) {
-	var args mock.Arguments
-	if len(tx) > 0 {
-		args = m.Called(ctx, id, tx)
-	} else {
-		args = m.Called(ctx, id)
-	}
+func (m *MockRepository) GetByID(ctx context.Context, id uuid.UUID) (*Author, error) {
+	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*Author), args.Error(1)
 }
 
-func (m *MockRepository) GetByPenName(ctx context.Context, penName string, tx ...*gorm.DB) (*Author, error) {
-	var args mock.Arguments
-	if len(tx) > 0 {
-		args = m.Called(ctx, penName, tx)
-	} else {
-		args = m.Called(ctx, penName)
-	}
+func (m *MockRepository) GetByPenName(ctx context.Context, penName string) (*Author, error) {
+	args := m.Called(ctx, penName)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*Author), args.Error(1)
 }
 
-func (m *MockRepository) GetAll(ctx context.Context, pagination *pkgDto.PaginationRequest, tx ...*gorm.DB) (*pkgDto.PaginationDataResponse[Author], error) {
-	var args mock.Arguments
-	if len(tx) > 0 {
-		args = m.Called(ctx, pagination, tx)
-	} else {
-		args = m.Called(ctx, pagination)
-	}
+func (m *MockRepository) GetAll(ctx context.Context, pagination *pkgDto.PaginationRequest) (*pkgDto.PaginationDataResponse[Author], error) {
+	args := m.Called(ctx, pagination)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*pkgDto.PaginationDataResponse[Author]), args.Error(1)
 }
 
-func (m *MockRepository) Update(ctx context.Context, id uuid.UUID, author *Author, tx ...*gorm.DB) error {
-	var args mock.Arguments
-	if len(tx) > 0 {
-		args = m.Called(ctx, id, author, tx)
-	} else {
-		args = m.Called(ctx, id, author)
-	}
+func (m *MockRepository) Update(ctx context.Context, id uuid.UUID, author *Author) error {
+	args := m.Called(ctx, id, author)
 	return args.Error(0)
 }
 
-func (m *MockRepository) Delete(ctx context.Context, id uuid.UUID, tx ...*gorm.DB) error {
-	var args mock.Arguments
-	if len(tx) > 0 {
-		args = m.Called(ctx, id, tx)
-	} else {
-		args = m.Called(ctx, id)
-	}
+func (m *MockRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
 	return args.Error(0)
 }
 
@@ -99,7 +70,7 @@ func (suite *ServiceTestSuite) SetupTest() {
 	mockRepo := new(MockRepository)
 	logger := logrus.New()
 	logger.SetLevel(logrus.ErrorLevel)
-	service := NewService(mockRepo, logger)
+	service := NewService(mockRepo, repoPkg.NewMemoryTransactionManager(), logger)
 
 	suite.service = service
 	suite.mockRepo = mockRepo
@@ -109,7 +80,7 @@ func (suite *ServiceTestSuite) SetupTest() {
 func (suite *ServiceTestSuite) TestNewService() {
 	mockRepo := new(MockRepository)
 	logger := logrus.New()
-	service := NewService(mockRepo, logger)
+	service := NewService(mockRepo, repoPkg.NewMemoryTransactionManager(), logger)
 
 	suite.NotNil(service)
 
@@ -124,8 +95,8 @@ func (suite *ServiceTestSuite) TestCreateAuthor_Success() {
 		BirthYear: 1990,
 	}
 
-	suite.mockRepo.On("GetByPenName", suite.ctx, req.PenName).Return((*Author)(nil), nil)
-	suite.mockRepo.On("Create", suite.ctx, mock.AnythingOfType("*author.Author")).Return(nil)
+	suite.mockRepo.On("GetByPenName", mock.Anything, req.PenName).Return((*Author)(nil), nil)
+	suite.mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*author.Author")).Return(nil)
 
 	author, code := suite.service.CreateAuthor(suite.ctx, req)
 
@@ -149,7 +120,7 @@ func (suite *ServiceTestSuite) TestCreateAuthor_AuthorAlreadyExists() {
 		BirthYear: 1990,
 	}
 
-	suite.mockRepo.On("GetByPenName", suite.ctx, req.PenName).Return(existingAuthor, nil)
+	suite.mockRepo.On("GetByPenName", mock.Anything, req.PenName).Return(existingAuthor, nil)
 
 	author, code := suite.service.CreateAuthor(suite.ctx, req)
 
@@ -164,7 +135,7 @@ func (suite *ServiceTestSuite) TestCreateAuthor_GetByPenNameError() {
 		BirthYear: 1990,
 	}
 
-	suite.mockRepo.On("GetByPenName", suite.ctx, req.PenName).Return((*Author)(nil), errors.New("database error"))
+	suite.mockRepo.On("GetByPenName", mock.Anything, req.PenName).Return((*Author)(nil), errors.New("database error"))
 
 	author, code := suite.service.CreateAuthor(suite.ctx, req)
 
@@ -179,8 +150,8 @@ func (suite *ServiceTestSuite) TestCreateAuthor_CreateError() {
 		BirthYear: 1990,
 	}
 
-	suite.mockRepo.On("GetByPenName", suite.ctx, req.PenName).Return((*Author)(nil), nil)
-	suite.mockRepo.On("Create", suite.ctx, mock.AnythingOfType("*author.Author")).Return(errors.New("database error"))
+	suite.mockRepo.On("GetByPenName", mock.Anything, req.PenName).Return((*Author)(nil), nil)
+	suite.mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*author.Author")).Return(errors.New("database error"))
 
 	author, code := suite.service.CreateAuthor(suite.ctx, req)
 
@@ -189,6 +160,22 @@ func (suite *ServiceTestSuite) TestCreateAuthor_CreateError() {
 	suite.mockRepo.AssertExpectations(suite.T())
 }
 
+func (suite *ServiceTestSuite) TestCreateAuthor_DuplicateOnInsert() {
+	req := &CreateAuthorRequest{
+		PenName:   "Test Author",
+		BirthYear: 1990,
+	}
+
+	suite.mockRepo.On("GetByPenName", mock.Anything, req.PenName).Return((*Author)(nil), nil)
+	suite.mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*author.Author")).Return(gorm.ErrDuplicatedKey)
+
+	author, code := suite.service.CreateAuthor(suite.ctx, req)
+
+	suite.Equal(dto.AuthorAlreadyExists, code)
+	suite.Nil(author)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
 func (suite *ServiceTestSuite) TestGetAuthorByID_Success() {
 	authorID := uuid.New()
 	expectedAuthor := &Author{
@@ -197,7 +184,7 @@ func (suite *ServiceTestSuite) TestGetAuthorByID_Success() {
 		BirthYear: 1990,
 	}
 
-	suite.mockRepo.On("GetByID", suite.ctx, authorID).Return(expectedAuthor, nil)
+	suite.mockRepo.On("GetByID", mock.Anything, authorID).Return(expectedAuthor, nil)
 
 	author, code := suite.service.GetAuthorByID(suite.ctx, authorID)
 
@@ -211,7 +198,7 @@ func (suite *ServiceTestSuite) TestGetAuthorByID_Success() {
 func (suite *ServiceTestSuite) TestGetAuthorByID_NotFound() {
 	authorID := uuid.New()
 
-	suite.mockRepo.On("GetByID", suite.ctx, authorID).Return((*Author)(nil), nil)
+	suite.mockRepo.On("GetByID", mock.Anything, authorID).Return((*Author)(nil), nil)
 
 	author, code := suite.service.GetAuthorByID(suite.ctx, authorID)
 
@@ -223,7 +210,7 @@ func (suite *ServiceTestSuite) TestGetAuthorByID_NotFound() {
 func (suite *ServiceTestSuite) TestGetAuthorByID_GetByIDError() {
 	authorID := uuid.New()
 
-	suite.mockRepo.On("GetByID", suite.ctx, authorID).Return((*Author)(nil), errors.New("database error"))
+	suite.mockRepo.On("GetByID", mock.Anything, authorID).Return((*Author)(nil), errors.New("database error"))
 
 	author, code := suite.service.GetAuthorByID(suite.ctx, authorID)
 
@@ -232,6 +219,42 @@ func (suite *ServiceTestSuite) TestGetAuthorByID_GetByIDError() {
 	suite.mockRepo.AssertExpectations(suite.T())
 }
 
+func (suite *ServiceTestSuite) TestGetAuthorByPenName_Success() {
+	expectedAuthor := &Author{
+		BaseModel: models.BaseModel{ID: uuid.New()},
+		PenName:   "Test Author",
+		BirthYear: 1990,
+	}
+
+	suite.mockRepo.On("GetByPenName", mock.Anything, "Test Author").Return(expectedAuthor, nil)
+
+	author, code := suite.service.GetAuthorByPenName(suite.ctx, "Test Author")
+
+	suite.Equal(dto.Success, code)
+	suite.Equal(expectedAuthor, author)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *ServiceTestSuite) TestGetAuthorByPenName_NotFound() {
+	suite.mockRepo.On("GetByPenName", mock.Anything, "Unknown").Return((*Author)(nil), nil)
+
+	author, code := suite.service.GetAuthorByPenName(suite.ctx, "Unknown")
+
+	suite.Equal(dto.AuthorNotFound, code)
+	suite.Nil(author)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *ServiceTestSuite) TestGetAuthorByPenName_GetByPenNameError() {
+	suite.mockRepo.On("GetByPenName", mock.Anything, "Test Author").Return((*Author)(nil), errors.New("database error"))
+
+	author, code := suite.service.GetAuthorByPenName(suite.ctx, "Test Author")
+
+	suite.Equal(dto.InternalError, code)
+	suite.Nil(author)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
 func (suite *ServiceTestSuite) TestGetAllAuthors_Success() {
 	pagination := &pkgDto.PaginationRequest{Page: 1, PageSize: 5}
 	expectedAuthors := &pkgDto.PaginationDataResponse[Author]{
@@ -247,7 +270,7 @@ func (suite *ServiceTestSuite) TestGetAllAuthors_Success() {
 		},
 	}
 
-	suite.mockRepo.On("GetAll", suite.ctx, pagination).Return(expectedAuthors, nil)
+	suite.mockRepo.On("GetAll", mock.Anything, pagination).Return(expectedAuthors, nil)
 
 	authors, code := suite.service.GetAllAuthors(suite.ctx, pagination)
 
@@ -270,7 +293,7 @@ func (suite *ServiceTestSuite) TestGetAllAuthors_EmptyResult() {
 		},
 	}
 
-	suite.mockRepo.On("GetAll", suite.ctx, pagination).Return(expectedAuthors, nil)
+	suite.mockRepo.On("GetAll", mock.Anything, pagination).Return(expectedAuthors, nil)
 
 	authors, code := suite.service.GetAllAuthors(suite.ctx, pagination)
 
@@ -284,7 +307,7 @@ func (suite *ServiceTestSuite) TestGetAllAuthors_EmptyResult() {
 func (suite *ServiceTestSuite) TestGetAllAuthors_GetAllError() {
 	pagination := &pkgDto.PaginationRequest{Page: 1, PageSize: 10}
 
-	suite.mockRepo.On("GetAll", suite.ctx, pagination).Return((*pkgDto.PaginationDataResponse[Author])(nil), errors.New("database error"))
+	suite.mockRepo.On("GetAll", mock.Anything, pagination).Return((*pkgDto.PaginationDataResponse[Author])(nil), errors.New("database error"))
 
 	authors, code := suite.service.GetAllAuthors(suite.ctx, pagination)
 
@@ -306,8 +329,9 @@ func (suite *ServiceTestSuite) TestUpdateAuthor_Success() {
 		BirthYear: 1990,
 	}
 
-	suite.mockRepo.On("GetByID", suite.ctx, authorID).Return(existingAuthor, nil)
-	suite.mockRepo.On("Update", suite.ctx, authorID, mock.AnythingOfType("*author.Author")).Return(nil)
+	suite.mockRepo.On("GetByID", mock.Anything, authorID).Return(existingAuthor, nil)
+	suite.mockRepo.On("GetByPenName", mock.Anything, req.PenName).Return((*Author)(nil), nil)
+	suite.mockRepo.On("Update", mock.Anything, authorID, mock.AnythingOfType("*author.Author")).Return(nil)
 
 	code := suite.service.UpdateAuthor(suite.ctx, authorID, req)
 
@@ -322,7 +346,7 @@ func (suite *ServiceTestSuite) TestUpdateAuthor_AuthorNotFound() {
 		BirthYear: 1985,
 	}
 
-	suite.mockRepo.On("GetByID", suite.ctx, authorID).Return((*Author)(nil), nil)
+	suite.mockRepo.On("GetByID", mock.Anything, authorID).Return((*Author)(nil), nil)
 
 	code := suite.service.UpdateAuthor(suite.ctx, authorID, req)
 
@@ -337,7 +361,7 @@ func (suite *ServiceTestSuite) TestUpdateAuthor_GetByIDError() {
 		BirthYear: 1985,
 	}
 
-	suite.mockRepo.On("GetByID", suite.ctx, authorID).Return((*Author)(nil), errors.New("database error"))
+	suite.mockRepo.On("GetByID", mock.Anything, authorID).Return((*Author)(nil), errors.New("database error"))
 
 	code := suite.service.UpdateAuthor(suite.ctx, authorID, req)
 
@@ -358,8 +382,9 @@ func (suite *ServiceTestSuite) TestUpdateAuthor_UpdateError() {
 		BirthYear: 1990,
 	}
 
-	suite.mockRepo.On("GetByID", suite.ctx, authorID).Return(existingAuthor, nil)
-	suite.mockRepo.On("Update", suite.ctx, authorID, mock.AnythingOfType("*author.Author")).Return(errors.New("database error"))
+	suite.mockRepo.On("GetByID", mock.Anything, authorID).Return(existingAuthor, nil)
+	suite.mockRepo.On("GetByPenName", mock.Anything, req.PenName).Return((*Author)(nil), nil)
+	suite.mockRepo.On("Update", mock.Anything, authorID, mock.AnythingOfType("*author.Author")).Return(errors.New("database error"))
 
 	code := suite.service.UpdateAuthor(suite.ctx, authorID, req)
 
@@ -367,10 +392,84 @@ func (suite *ServiceTestSuite) TestUpdateAuthor_UpdateError() {
 	suite.mockRepo.AssertExpectations(suite.T())
 }
 
+func (suite *ServiceTestSuite) TestUpdateAuthor_AuthorAlreadyExists() {
+	authorID := uuid.New()
+	req := &UpdateAuthorRequest{
+		PenName:   "Taken Author",
+		BirthYear: 1985,
+	}
+
+	existingAuthor := &Author{
+		BaseModel: models.BaseModel{ID: authorID},
+		PenName:   "Original Author",
+		BirthYear: 1990,
+	}
+	otherAuthor := &Author{
+		BaseModel: models.BaseModel{ID: uuid.New()},
+		PenName:   "Taken Author",
+		BirthYear: 1970,
+	}
+
+	suite.mockRepo.On("GetByID", mock.Anything, authorID).Return(existingAuthor, nil)
+	suite.mockRepo.On("GetByPenName", mock.Anything, req.PenName).Return(otherAuthor, nil)
+
+	code := suite.service.UpdateAuthor(suite.ctx, authorID, req)
+
+	suite.Equal(dto.AuthorAlreadyExists, code)
+	suite.mockRepo.AssertExpectations(suite.T())
+	suite.mockRepo.AssertNotCalled(suite.T(), "Update", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *ServiceTestSuite) TestUpdateAuthor_KeepsOwnPenName() {
+	authorID := uuid.New()
+	req := &UpdateAuthorRequest{
+		PenName:   "Original Author",
+		BirthYear: 1985,
+	}
+
+	existingAuthor := &Author{
+		BaseModel: models.BaseModel{ID: authorID},
+		PenName:   "Original Author",
+		BirthYear: 1990,
+	}
+
+	suite.mockRepo.On("GetByID", mock.Anything, authorID).Return(existingAuthor, nil)
+	suite.mockRepo.On("GetByPenName", mock.Anything, req.PenName).Return(existingAuthor, nil)
+	suite.mockRepo.On("Update", mock.Anything, authorID, mock.AnythingOfType("*author.Author")).Return(nil)
+
+	code := suite.service.UpdateAuthor(suite.ctx, authorID, req)
+
+	suite.Equal(dto.Success, code)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *ServiceTestSuite) TestUpdateAuthor_DuplicateOnUpdate() {
+	authorID := uuid.New()
+	req := &UpdateAuthorRequest{
+		PenName:   "Taken Author",
+		BirthYear: 1985,
+	}
+
+	existingAuthor := &Author{
+		BaseModel: models.BaseModel{ID: authorID},
+		PenName:   "Original Author",
+		BirthYear: 1990,
+	}
+
+	suite.mockRepo.On("GetByID", mock.Anything, authorID).Return(existingAuthor, nil)
+	suite.mockRepo.On("GetByPenName", mock.Anything, req.PenName).Return((*Author)(nil), nil)
+	suite.mockRepo.On("Update", mock.Anything, authorID, mock.AnythingOfType("*author.Author")).Return(gorm.ErrDuplicatedKey)
+
+	code := suite.service.UpdateAuthor(suite.ctx, authorID, req)
+
+	suite.Equal(dto.AuthorAlreadyExists, code)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
 func (suite *ServiceTestSuite) TestDeleteAuthor_Success() {
 	authorID := uuid.New()
 
-	suite.mockRepo.On("Delete", suite.ctx, authorID).Return(nil)
+	suite.mockRepo.On("Delete", mock.Anything, authorID).Return(nil)
 
 	code := suite.service.DeleteAuthor(suite.ctx, authorID)
 
@@ -381,7 +480,7 @@ func (suite *ServiceTestSuite) TestDeleteAuthor_Success() {
 func (suite *ServiceTestSuite) TestDeleteAuthor_DeleteError() {
 	authorID := uuid.New()
 
-	suite.mockRepo.On("Delete", suite.ctx, authorID).Return(errors.New("database error"))
+	suite.mockRepo.On("Delete", mock.Anything, authorID).Return(errors.New("database error"))
 
 	code := suite.service.DeleteAuthor(suite.ctx, authorID)
 
@@ -392,3 +491,89 @@ func (suite *ServiceTestSuite) TestDeleteAuthor_DeleteError() {
 func TestServiceTestSuite(t *testing.T) {
 	suite.Run(t, new(ServiceTestSuite))
 }
+
+// MemoryServiceTestSuite runs the service against the in-memory repository,
+// so transactions, uniqueness and soft deletes behave as they do in SQL.
+type MemoryServiceTestSuite struct {
+	suite.Suite
+	service *service
+	ctx     context.Context
+}
+
+func (suite *MemoryServiceTestSuite) SetupTest() {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	transactionManager := repoPkg.NewMemoryTransactionManager()
+	repo := NewMemoryRepository(transactionManager)
+
+	suite.service = NewService(repo, transactionManager, logger)
+	suite.ctx = context.Background()
+}
+
+func (suite *MemoryServiceTestSuite) TestCreateAndGetAuthor() {
+	created, code := suite.service.CreateAuthor(suite.ctx, &CreateAuthorRequest{PenName: "Haruki Murakami", BirthYear: 1949})
+	suite.Equal(dto.Success, code)
+	suite.NotEqual(uuid.Nil, created.ID)
+
+	found, code := suite.service.GetAuthorByID(suite.ctx, created.ID)
+	suite.Equal(dto.Success, code)
+	suite.Equal("Haruki Murakami", found.PenName)
+
+	found, code = suite.service.GetAuthorByPenName(suite.ctx, "Haruki Murakami")
+	suite.Equal(dto.Success, code)
+	suite.Equal(created.ID, found.ID)
+
+	authors, code := suite.service.GetAllAuthors(suite.ctx, &pkgDto.PaginationRequest{Page: 1, PageSize: 10})
+	suite.Equal(dto.Success, code)
+	suite.Equal(int64(1), authors.Pagination.TotalItems)
+}
+
+func (suite *MemoryServiceTestSuite) TestCreateAuthor_AuthorAlreadyExists() {
+	_, code := suite.service.CreateAuthor(suite.ctx, &CreateAuthorRequest{PenName: "Haruki Murakami", BirthYear: 1949})
+	suite.Equal(dto.Success, code)
+
+	author, code := suite.service.CreateAuthor(suite.ctx, &CreateAuthorRequest{PenName: "Haruki Murakami", BirthYear: 1950})
+	suite.Equal(dto.AuthorAlreadyExists, code)
+	suite.Nil(author)
+
+	authors, _ := suite.service.GetAllAuthors(suite.ctx, &pkgDto.PaginationRequest{Page: 1, PageSize: 10})
+	suite.Equal(int64(1), authors.Pagination.TotalItems)
+}
+
+func (suite *MemoryServiceTestSuite) TestUpdateAuthor() {
+	created, _ := suite.service.CreateAuthor(suite.ctx, &CreateAuthorRequest{PenName: "Haruki Murakami", BirthYear: 1949})
+
+	code := suite.service.UpdateAuthor(suite.ctx, created.ID, &UpdateAuthorRequest{PenName: "Murakami Haruki", BirthYear: 1949})
+	suite.Equal(dto.Success, code)
+
+	found, _ := suite.service.GetAuthorByID(suite.ctx, created.ID)
+	suite.Equal("Murakami Haruki", found.PenName)
+
+	code = suite.service.UpdateAuthor(suite.ctx, uuid.New(), &UpdateAuthorRequest{PenName: "Nobody", BirthYear: 1949})
+	suite.Equal(dto.AuthorNotFound, code)
+}
+
+func (suite *MemoryServiceTestSuite) TestUpdateAuthor_AuthorAlreadyExists() {
+	_, _ = suite.service.CreateAuthor(suite.ctx, &CreateAuthorRequest{PenName: "Haruki Murakami", BirthYear: 1949})
+	other, _ := suite.service.CreateAuthor(suite.ctx, &CreateAuthorRequest{PenName: "Banana Yoshimoto", BirthYear: 1964})
+
+	code := suite.service.UpdateAuthor(suite.ctx, other.ID, &UpdateAuthorRequest{PenName: "Haruki Murakami", BirthYear: 1964})
+	suite.Equal(dto.AuthorAlreadyExists, code)
+
+	found, _ := suite.service.GetAuthorByID(suite.ctx, other.ID)
+	suite.Equal("Banana Yoshimoto", found.PenName)
+}
+
+func (suite *MemoryServiceTestSuite) TestDeleteAuthor() {
+	created, _ := suite.service.CreateAuthor(suite.ctx, &CreateAuthorRequest{PenName: "Haruki Murakami", BirthYear: 1949})
+
+	suite.Equal(dto.Success, suite.service.DeleteAuthor(suite.ctx, created.ID))
+
+	found, code := suite.service.GetAuthorByID(suite.ctx, created.ID)
+	suite.Equal(dto.Success, code)
+	suite.Nil(found)
+}
+
+func TestMemoryServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(MemoryServiceTestSuite))
+}
@@ -0,0 +1,36 @@
+package author
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/go-playground/validator/v10"
+	pkgValidator "github.com/sirawatc/simple-gin-crud/pkg/validator"
+	"golang.org/x/text/unicode/norm"
+)
+
+// PenNameRule accepts names without control characters or surrounding
+// whitespace, in Unicode normalization form C.
+var PenNameRule = pkgValidator.Rule{
+	Tag: "penname",
+	Func: func(fl validator.FieldLevel) bool {
+		return isPenName(fl.Field().String())
+	},
+	Messages: map[string]string{
+		"en": "{0} must not contain control characters or surrounding spaces and must be NFC-normalized",
+		"th": "{0} ต้องไม่มีอักขระควบคุมหรือช่องว่างหัวท้าย และต้องอยู่ในรูปแบบ NFC",
+	},
+}
+
+// ValidationRules returns the author rules to register on the shared
+// validator so struct tags can refer to them.
+func ValidationRules() []pkgValidator.Rule {
+	return []pkgValidator.Rule{PenNameRule}
+}
+
+func isPenName(name string) bool {
+	if strings.TrimSpace(name) != name || strings.IndexFunc(name, unicode.IsControl) >= 0 {
+		return false
+	}
+	return norm.NFC.IsNormalString(name)
+}
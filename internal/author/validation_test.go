@@ -0,0 +1,42 @@
+package author
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirawatc/simple-gin-crud/pkg/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsPenName(t *testing.T) {
+	tests := []struct {
+		name     string
+		penName  string
+		expected bool
+	}{
+		{name: "plain", penName: "J. K. Rowling", expected: true},
+		{name: "thai", penName: "ว. วินิจฉัยกุล", expected: true},
+		{name: "composed accent", penName: "José Saramago", expected: true},
+		{name: "decomposed accent", penName: "José Saramago", expected: false},
+		{name: "leading space", penName: " Rowling", expected: false},
+		{name: "trailing newline", penName: "Rowling\n", expected: false},
+		{name: "control character", penName: "Row\x00ling", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isPenName(tt.penName))
+		})
+	}
+}
+
+func TestCreateAuthorRequest_DomainRules(t *testing.T) {
+	v := validator.NewValidator()
+	v.MustRegister(ValidationRules()...)
+
+	errors := v.Validate(context.Background(), CreateAuthorRequest{PenName: " Rowling", BirthYear: 3000})
+	assert.Equal(t, []string{
+		"penName must not contain control characters or surrounding spaces and must be NFC-normalized",
+		"birthYear must not be in the future",
+	}, errors.Messages())
+}
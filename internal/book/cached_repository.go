@@ -0,0 +1,89 @@
+package book
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/sirawatc/simple-gin-crud/internal/author"
+	"github.com/sirawatc/simple-gin-crud/pkg/cache"
+	repoPkg "github.com/sirawatc/simple-gin-crud/pkg/repository"
+)
+
+// cachedRepository decorates an IRepository with read-through caching of
+// GetByID. Books are cached without their Author, which is resolved through
+// authorRepo on every hit so author updates never leave a stale copy behind.
+// Reads inside a transaction bypass the cache, and every write invalidates
+// the affected ID.
+type cachedRepository struct {
+	IRepository
+	authorRepo author.IRepository
+	loader     *cache.Loader[Book]
+}
+
+func NewCachedRepository(repo IRepository, authorRepo author.IRepository, backend cache.Cache, opts cache.Options) *cachedRepository {
+	return &cachedRepository{
+		IRepository: repo,
+		authorRepo:  authorRepo,
+		loader:      cache.NewLoader[Book]("book", backend, opts),
+	}
+}
+
+// SetCacheOptions changes the TTLs used for entries cached from now on.
+func (r *cachedRepository) SetCacheOptions(opts cache.Options) {
+	r.loader.SetOptions(opts)
+}
+
+func (r *cachedRepository) Create(ctx context.Context, book *Book) error {
+	if err := r.IRepository.Create(ctx, book); err != nil {
+		return err
+	}
+	return r.invalidate(ctx, book.ID)
+}
+
+func (r *cachedRepository) GetByID(ctx context.Context, id uuid.UUID) (*Book, error) {
+	if repoPkg.InTransaction(ctx) {
+		return r.IRepository.GetByID(ctx, id)
+	}
+
+	book, err := r.loader.Get(ctx, id.String(), func(ctx context.Context) (*Book, error) {
+		book, err := r.IRepository.GetByID(ctx, id)
+		if book != nil {
+			book.Author = nil
+		}
+		return book, err
+	})
+	if err != nil || book == nil {
+		return nil, err
+	}
+
+	book.Author, err = r.authorRepo.GetByID(ctx, book.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+	return book, nil
+}
+
+func (r *cachedRepository) Update(ctx context.Context, id uuid.UUID, book *Book) error {
+	if err := r.IRepository.Update(ctx, id, book); err != nil {
+		return err
+	}
+	return r.invalidate(ctx, id)
+}
+
+func (r *cachedRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.IRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	return r.invalidate(ctx, id)
+}
+
+// invalidate drops the entry now and again once any surrounding transaction
+// commits, so a read racing the commit cannot leave the old row cached.
+func (r *cachedRepository) invalidate(ctx context.Context, id uuid.UUID) error {
+	if repoPkg.InTransaction(ctx) {
+		repoPkg.AfterCommit(ctx, func(ctx context.Context) {
+			_ = r.loader.Invalidate(ctx, id.String())
+		})
+	}
+	return r.loader.Invalidate(ctx, id.String())
+}
@@ -0,0 +1,114 @@
+package book
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirawatc/simple-gin-crud/internal/author"
+	"github.com/sirawatc/simple-gin-crud/pkg/cache"
+	repoPkg "github.com/sirawatc/simple-gin-crud/pkg/repository"
+	"github.com/stretchr/testify/suite"
+)
+
+type countingRepository struct {
+	IRepository
+	getByIDCalls int
+}
+
+func (r *countingRepository) GetByID(ctx context.Context, id uuid.UUID) (*Book, error) {
+	r.getByIDCalls++
+	return r.IRepository.GetByID(ctx, id)
+}
+
+type CachedRepositoryTestSuite struct {
+	suite.Suite
+	authorRepo author.IRepository
+	inner      *countingRepository
+	repo       *cachedRepository
+	author     *author.Author
+	ctx        context.Context
+}
+
+func (suite *CachedRepositoryTestSuite) SetupTest() {
+	suite.ctx = context.Background()
+	tm := repoPkg.NewMemoryTransactionManager()
+	backend := cache.NewLRU(100)
+	opts := cache.Options{TTL: time.Minute, NegativeTTL: time.Minute}
+
+	suite.authorRepo = author.NewCachedRepository(author.NewMemoryRepository(tm), backend, opts)
+	suite.inner = &countingRepository{IRepository: NewMemoryRepository(tm, suite.authorRepo)}
+	suite.repo = NewCachedRepository(suite.inner, suite.authorRepo, backend, opts)
+
+	suite.author = &author.Author{PenName: "Test Author", BirthYear: 1990}
+	suite.Require().NoError(suite.authorRepo.Create(suite.ctx, suite.author))
+}
+
+func (suite *CachedRepositoryTestSuite) create(isbn string) *Book {
+	book := &Book{AuthorID: suite.author.ID, Name: "Book " + isbn, ISBN: isbn}
+	suite.Require().NoError(suite.repo.Create(suite.ctx, book))
+	return book
+}
+
+func (suite *CachedRepositoryTestSuite) TestGetByID_CachesResult() {
+	book := suite.create("9780000000001")
+
+	for i := 0; i < 3; i++ {
+		got, err := suite.repo.GetByID(suite.ctx, book.ID)
+		suite.NoError(err)
+		suite.Equal(book.Name, got.Name)
+		suite.Require().NotNil(got.Author)
+		suite.Equal("Test Author", got.Author.PenName)
+	}
+	suite.Equal(1, suite.inner.getByIDCalls)
+}
+
+func (suite *CachedRepositoryTestSuite) TestGetByID_ReflectsAuthorUpdate() {
+	book := suite.create("9780000000001")
+	_, _ = suite.repo.GetByID(suite.ctx, book.ID)
+
+	suite.Require().NoError(suite.authorRepo.Update(suite.ctx, suite.author.ID, &author.Author{PenName: "Renamed"}))
+
+	got, err := suite.repo.GetByID(suite.ctx, book.ID)
+	suite.NoError(err)
+	suite.Equal("Renamed", got.Author.PenName)
+	suite.Equal(1, suite.inner.getByIDCalls)
+}
+
+func (suite *CachedRepositoryTestSuite) TestGetByID_CachesNotFound() {
+	id := uuid.New()
+
+	for i := 0; i < 2; i++ {
+		got, err := suite.repo.GetByID(suite.ctx, id)
+		suite.NoError(err)
+		suite.Nil(got)
+	}
+	suite.Equal(1, suite.inner.getByIDCalls)
+}
+
+func (suite *CachedRepositoryTestSuite) TestUpdate_Invalidates() {
+	book := suite.create("9780000000001")
+	_, _ = suite.repo.GetByID(suite.ctx, book.ID)
+
+	suite.Require().NoError(suite.repo.Update(suite.ctx, book.ID, &Book{Name: "Renamed"}))
+
+	got, err := suite.repo.GetByID(suite.ctx, book.ID)
+	suite.NoError(err)
+	suite.Equal("Renamed", got.Name)
+}
+
+func (suite *CachedRepositoryTestSuite) TestDelete_Invalidates() {
+	book := suite.create("9780000000001")
+	_, _ = suite.repo.GetByID(suite.ctx, book.ID)
+
+	suite.Require().NoError(suite.repo.Delete(suite.ctx, book.ID))
+
+	got, err := suite.repo.GetByID(suite.ctx, book.ID)
+	suite.NoError(err)
+	suite.Nil(got)
+}
+
+func TestCachedRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(CachedRepositoryTestSuite))
+}
@@ -6,15 +6,15 @@ import (
 )
 
 type CreateBookRequest struct {
-	AuthorID uuid.UUID `json:"authorId" binding:"required" validate:"required"`
+	AuthorID uuid.UUID `json:"authorId" binding:"required" validate:"required,uuidv4"`
 	Name     string    `json:"name" binding:"required" validate:"required,min=1,max=255"`
-	ISBN     string    `json:"isbn" binding:"required" validate:"required,isbn"`
+	ISBN     string    `json:"isbn" binding:"required" validate:"required,isbn13strict"`
 }
 
 type UpdateBookRequest struct {
-	AuthorID uuid.UUID `json:"authorId" binding:"required" validate:"required"`
+	AuthorID uuid.UUID `json:"authorId" binding:"required" validate:"required,uuidv4"`
 	Name     string    `json:"name" binding:"required" validate:"required,min=1,max=255"`
-	ISBN     string    `json:"isbn" binding:"required" validate:"required,isbn"`
+	ISBN     string    `json:"isbn" binding:"required" validate:"required,isbn13strict"`
 }
 
 type GetBooksByAuthorRequest struct {
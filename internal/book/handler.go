@@ -2,23 +2,26 @@ package book
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/sirawatc/simple-gin-crud/internal/shared/dto"
 	pkgDto "github.com/sirawatc/simple-gin-crud/pkg/dto"
+	"github.com/sirawatc/simple-gin-crud/pkg/httpcache"
 	"github.com/sirawatc/simple-gin-crud/pkg/logger"
 	"github.com/sirawatc/simple-gin-crud/pkg/validator"
 	"github.com/sirupsen/logrus"
 )
 
 type Handler struct {
-	service IService
-	logger  *logrus.Logger
+	service   IService
+	validator *validator.Validator
+	logger    *logrus.Logger
 }
 
-func NewHandler(service IService, logger *logrus.Logger) *Handler {
-	return &Handler{service: service, logger: logger}
+func NewHandler(service IService, validator *validator.Validator, logger *logrus.Logger) *Handler {
+	return &Handler{service: service, validator: validator, logger: logger}
 }
 
 func (h *Handler) CreateBook(c *gin.Context) {
@@ -30,24 +33,24 @@ func (h *Handler) CreateBook(c *gin.Context) {
 	var req CreateBookRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		logger.Errorf("%s Invalid request body: %v", logPrefix, err)
-		c.JSON(http.StatusBadRequest, dto.BuildBaseResponse(dto.BindingError, err.Error()))
+		dto.RespondBindingError(c, h.validator.BindingErrors(ctx, err))
 		return
 	}
 
-	if errors := validator.NewValidator().Validate(req); errors != nil {
+	if errors := h.validator.Validate(ctx, req); errors != nil {
 		logger.Errorf("%s Validation failed: %v", logPrefix, errors)
-		c.JSON(http.StatusBadRequest, dto.BuildBaseResponse(dto.ValidationError, errors))
+		dto.RespondError(c, http.StatusBadRequest, dto.ValidationError, errors)
 		return
 	}
 
 	book, code := h.service.CreateBook(ctx, &req)
 	if code != dto.Success {
 		logger.Errorf("%s Failed to create book: %v", logPrefix, dto.CodeMessage[code])
-		c.JSON(code.GetHTTPCode(), dto.BuildBaseResponse(code, nil))
+		dto.RespondError(c, code.GetHTTPCode(), code, nil)
 		return
 	}
 
-	c.JSON(http.StatusCreated, dto.BuildBaseResponse(dto.Created, book))
+	dto.Respond(c, http.StatusCreated, dto.Created, book)
 }
 
 func (h *Handler) GetBook(c *gin.Context) {
@@ -59,18 +62,22 @@ func (h *Handler) GetBook(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		logger.Errorf("%s Invalid book ID format: %v", logPrefix, err)
-		c.JSON(http.StatusBadRequest, dto.BuildBaseResponse(dto.UUIDFormatInvalid, nil))
+		dto.RespondError(c, http.StatusBadRequest, dto.UUIDFormatInvalid, nil)
 		return
 	}
 
 	book, code := h.service.GetBookByID(ctx, id)
 	if code != dto.Success {
 		logger.Errorf("%s Failed to get book: %v", logPrefix, dto.CodeMessage[code])
-		c.JSON(code.GetHTTPCode(), dto.BuildBaseResponse(code, nil))
+		dto.RespondError(c, code.GetHTTPCode(), code, nil)
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.BuildBaseResponse(dto.Success, book))
+	if book != nil && httpcache.NotModified(c, bookLastModified(book)) {
+		return
+	}
+
+	dto.Respond(c, http.StatusOK, dto.Success, book)
 }
 
 func (h *Handler) GetBooksByAuthorID(c *gin.Context) {
@@ -82,25 +89,25 @@ func (h *Handler) GetBooksByAuthorID(c *gin.Context) {
 	authorID, err := uuid.Parse(c.Param("authorId"))
 	if err != nil {
 		logger.Errorf("%s Invalid author ID format: %v", logPrefix, err)
-		c.JSON(http.StatusBadRequest, dto.BuildBaseResponse(dto.UUIDFormatInvalid, nil))
+		dto.RespondError(c, http.StatusBadRequest, dto.UUIDFormatInvalid, nil)
 		return
 	}
 
-	pagination, errors := pkgDto.NewPaginationRequest(c.Query("page"), c.Query("pageSize"))
+	pagination, errors := pkgDto.NewPaginationRequest(ctx, c.Query("page"), c.Query("pageSize"))
 	if len(errors) > 0 {
 		logger.Errorf("%s Invalid pagination parameters: %v", logPrefix, errors)
-		c.JSON(http.StatusBadRequest, dto.BuildBaseResponse(dto.ValidationError, errors))
+		dto.RespondError(c, http.StatusBadRequest, dto.ValidationError, errors)
 		return
 	}
 
 	books, code := h.service.GetBooksByAuthorID(ctx, authorID, pagination)
 	if code != dto.Success {
 		logger.Errorf("%s Failed to get books by author ID: %v", logPrefix, dto.CodeMessage[code])
-		c.JSON(code.GetHTTPCode(), dto.BuildBaseResponse(code, nil))
+		dto.RespondError(c, code.GetHTTPCode(), code, nil)
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.BuildBaseResponse(dto.Success, books))
+	dto.Respond(c, http.StatusOK, dto.Success, books)
 }
 
 func (h *Handler) GetAllBooks(c *gin.Context) {
@@ -109,21 +116,21 @@ func (h *Handler) GetAllBooks(c *gin.Context) {
 	ctx := c.Request.Context()
 	logger := logger.InjectRequestIDWithLogger(ctx, h.logger)
 
-	pagination, errors := pkgDto.NewPaginationRequest(c.Query("page"), c.Query("pageSize"))
+	pagination, errors := pkgDto.NewPaginationRequest(ctx, c.Query("page"), c.Query("pageSize"))
 	if len(errors) > 0 {
 		logger.Errorf("%s Invalid pagination parameters: %v", logPrefix, errors)
-		c.JSON(http.StatusBadRequest, dto.BuildBaseResponse(dto.ValidationError, errors))
+		dto.RespondError(c, http.StatusBadRequest, dto.ValidationError, errors)
 		return
 	}
 
 	books, code := h.service.GetAllBooks(ctx, pagination)
 	if code != dto.Success {
 		logger.Errorf("%s Failed to get all books: %v", logPrefix, dto.CodeMessage[code])
-		c.JSON(code.GetHTTPCode(), dto.BuildBaseResponse(code, nil))
+		dto.RespondError(c, code.GetHTTPCode(), code, nil)
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.BuildBaseResponse(dto.Success, books))
+	dto.Respond(c, http.StatusOK, dto.Success, books)
 }
 
 func (h *Handler) UpdateBook(c *gin.Context) {
@@ -135,31 +142,31 @@ func (h *Handler) UpdateBook(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		logger.Errorf("%s Invalid book ID format: %v", logPrefix, err)
-		c.JSON(http.StatusBadRequest, dto.BuildBaseResponse(dto.UUIDFormatInvalid, nil))
+		dto.RespondError(c, http.StatusBadRequest, dto.UUIDFormatInvalid, nil)
 		return
 	}
 
 	var req UpdateBookRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		logger.Errorf("%s Invalid request body: %v", logPrefix, err)
-		c.JSON(http.StatusBadRequest, dto.BuildBaseResponse(dto.BindingError, err.Error()))
+		dto.RespondBindingError(c, h.validator.BindingErrors(ctx, err))
 		return
 	}
 
-	if errors := validator.NewValidator().Validate(req); errors != nil {
+	if errors := h.validator.Validate(ctx, req); errors != nil {
 		logger.Errorf("%s Validation failed: %v", logPrefix, errors)
-		c.JSON(http.StatusBadRequest, dto.BuildBaseResponse(dto.ValidationError, errors))
+		dto.RespondError(c, http.StatusBadRequest, dto.ValidationError, errors)
 		return
 	}
 
 	code := h.service.UpdateBook(ctx, id, &req)
 	if code != dto.Success {
 		logger.Errorf("%s Failed to update book: %v", logPrefix, dto.CodeMessage[code])
-		c.JSON(code.GetHTTPCode(), dto.BuildBaseResponse(code, nil))
+		dto.RespondError(c, code.GetHTTPCode(), code, nil)
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.BuildBaseResponse(dto.Updated, nil))
+	dto.Respond(c, http.StatusOK, dto.Updated, nil)
 }
 
 func (h *Handler) DeleteBook(c *gin.Context) {
@@ -171,16 +178,25 @@ func (h *Handler) DeleteBook(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		logger.Errorf("%s Invalid book ID format: %v", logPrefix, err)
-		c.JSON(http.StatusBadRequest, dto.BuildBaseResponse(dto.UUIDFormatInvalid, nil))
+		dto.RespondError(c, http.StatusBadRequest, dto.UUIDFormatInvalid, nil)
 		return
 	}
 
 	code := h.service.DeleteBook(ctx, id)
 	if code != dto.Success {
 		logger.Errorf("%s Failed to delete book: %v", logPrefix, dto.CodeMessage[code])
-		c.JSON(code.GetHTTPCode(), dto.BuildBaseResponse(code, nil))
+		dto.RespondError(c, code.GetHTTPCode(), code, nil)
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.BuildBaseResponse(dto.Deleted, nil))
+	dto.Respond(c, http.StatusOK, dto.Deleted, nil)
+}
+
+// bookLastModified accounts for the embedded author, whose changes also
+// change the response body.
+func bookLastModified(book *Book) time.Time {
+	if book.Author == nil {
+		return book.UpdatedAt
+	}
+	return httpcache.Latest(book.UpdatedAt, book.Author.UpdatedAt)
 }
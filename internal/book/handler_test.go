@@ -8,12 +8,15 @@ import (
 	"net/http/httptest"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/sirawatc/simple-gin-crud/internal/author"
 	"github.com/sirawatc/simple-gin-crud/internal/shared/dto"
 	"github.com/sirawatc/simple-gin-crud/internal/shared/models"
 	pkgDto "github.com/sirawatc/simple-gin-crud/pkg/dto"
+	"github.com/sirawatc/simple-gin-crud/pkg/validator"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -39,6 +42,14 @@ func (m *MockService) GetBookByID(ctx context.Context, id uuid.UUID) (*Book, dto
 	return args.Get(0).(*Book), args.Get(1).(dto.Code)
 }
 
+func (m *MockService) GetBookByISBN(ctx context.Context, isbn string) (*Book, dto.Code) {
+	args := m.Called(ctx, isbn)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(dto.Code)
+	}
+	return args.Get(0).(*Book), args.Get(1).(dto.Code)
+}
+
 func (m *MockService) GetBooksByAuthorID(ctx context.Context, authorID uuid.UUID, pagination *pkgDto.PaginationRequest) (*pkgDto.PaginationDataResponse[Book], dto.Code) {
 	args := m.Called(ctx, authorID, pagination)
 	if args.Get(0) == nil {
@@ -76,7 +87,9 @@ func (suite *HandlerTestSuite) SetupTest() {
 	mockService := new(MockService)
 	logger := logrus.New()
 	logger.SetLevel(logrus.ErrorLevel)
-	handler := NewHandler(mockService, logger)
+	requestValidator := validator.NewValidator()
+	requestValidator.MustRegister(ValidationRules()...)
+	handler := NewHandler(mockService, requestValidator, logger)
 
 	suite.handler = handler
 	suite.mockService = mockService
@@ -93,7 +106,9 @@ func (suite *HandlerTestSuite) setupGinContext() (*gin.Context, *httptest.Respon
 func (suite *HandlerTestSuite) TestNewHandler() {
 	mockService := new(MockService)
 	logger := logrus.New()
-	handler := NewHandler(mockService, logger)
+	requestValidator := validator.NewValidator()
+	requestValidator.MustRegister(ValidationRules()...)
+	handler := NewHandler(mockService, requestValidator, logger)
 
 	suite.NotNil(handler)
 	suite.Equal(mockService, handler.service)
@@ -206,6 +221,57 @@ func (suite *HandlerTestSuite) TestCreateBook_ValidationError() {
 	suite.Equal(dto.ValidationError, response.Code)
 }
 
+// ISBN-10s were accepted by the old `isbn` rule; books now require ISBN-13.
+func (suite *HandlerTestSuite) TestCreateBook_ISBN10Rejected() {
+	c, w := suite.setupGinContext()
+
+	req := CreateBookRequest{
+		AuthorID: uuid.New(),
+		Name:     "name",
+		ISBN:     "0-7475-3269-9",
+	}
+
+	reqBody, _ := json.Marshal(req)
+	c.Request = httptest.NewRequest("POST", "/books", bytes.NewBuffer(reqBody))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	suite.handler.CreateBook(c)
+
+	var response dto.BaseResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	suite.NoError(err)
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.Equal(dto.ValidationError, response.Code)
+	suite.Equal("isbn13strict", response.Data.([]interface{})[0].(map[string]interface{})["rule"])
+	suite.mockService.AssertNotCalled(suite.T(), "CreateBook", mock.Anything, mock.Anything)
+}
+
+func (suite *HandlerTestSuite) TestCreateBook_AuthorIDNotUUIDv4() {
+	c, w := suite.setupGinContext()
+
+	req := CreateBookRequest{
+		AuthorID: uuid.Must(uuid.NewV7()),
+		Name:     "name",
+		ISBN:     "978-0-7475-3269-9",
+	}
+
+	reqBody, _ := json.Marshal(req)
+	c.Request = httptest.NewRequest("POST", "/books", bytes.NewBuffer(reqBody))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	suite.handler.CreateBook(c)
+
+	var response dto.BaseResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	suite.NoError(err)
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.Equal(dto.ValidationError, response.Code)
+	suite.Equal("uuidv4", response.Data.([]interface{})[0].(map[string]interface{})["rule"])
+	suite.mockService.AssertNotCalled(suite.T(), "CreateBook", mock.Anything, mock.Anything)
+}
+
 func (suite *HandlerTestSuite) TestCreateBook_AuthorNotFound() {
 	c, w := suite.setupGinContext()
 
@@ -325,6 +391,48 @@ func (suite *HandlerTestSuite) TestGetBook_Success() {
 	suite.mockService.AssertExpectations(suite.T())
 }
 
+func (suite *HandlerTestSuite) TestGetBook_NotModified() {
+	bookUpdatedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	authorUpdatedAt := bookUpdatedAt.Add(time.Hour)
+	bookID := uuid.New()
+	authorID := uuid.New()
+	expectedBook := &Book{
+		BaseModel: models.BaseModel{ID: bookID, UpdatedAt: bookUpdatedAt},
+		AuthorID:  authorID,
+		Name:      "Test Book",
+		ISBN:      "1234567890123",
+		Author: &author.Author{
+			BaseModel: models.BaseModel{ID: authorID, UpdatedAt: authorUpdatedAt},
+			PenName:   "Test Author",
+		},
+	}
+
+	suite.mockService.On("GetBookByID", mock.Anything, bookID).Return(expectedBook, dto.Success)
+
+	tests := []struct {
+		name            string
+		ifModifiedSince time.Time
+		expectedStatus  int
+	}{
+		{name: "unchanged", ifModifiedSince: authorUpdatedAt, expectedStatus: http.StatusNotModified},
+		{name: "author changed since", ifModifiedSince: bookUpdatedAt, expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			c, w := suite.setupGinContext()
+			c.Request = httptest.NewRequest("GET", "/books/"+bookID.String(), nil)
+			c.Request.Header.Set("If-Modified-Since", tt.ifModifiedSince.Format(http.TimeFormat))
+			c.Params = gin.Params{{Key: "id", Value: bookID.String()}}
+
+			suite.handler.GetBook(c)
+
+			suite.Equal(tt.expectedStatus, w.Code)
+			suite.Equal(authorUpdatedAt.Format(http.TimeFormat), w.Header().Get("Last-Modified"))
+		})
+	}
+}
+
 func (suite *HandlerTestSuite) TestGetBook_InvalidUUID() {
 	c, w := suite.setupGinContext()
 
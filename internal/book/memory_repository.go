@@ -0,0 +1,202 @@
+package book
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirawatc/simple-gin-crud/internal/author"
+	"github.com/sirawatc/simple-gin-crud/pkg/dto"
+	repoPkg "github.com/sirawatc/simple-gin-crud/pkg/repository"
+	"gorm.io/gorm"
+)
+
+// memoryRepository is a map-backed IRepository that mirrors the GORM
+// repository: soft-deleted rows are hidden but still hold their unique ISBN,
+// lookups return (nil, nil) when nothing matches, and the same queries that
+// preload Author in SQL resolve it through the author repository.
+type memoryRepository struct {
+	mu         sync.RWMutex
+	books      map[uuid.UUID]Book
+	order      []uuid.UUID
+	authorRepo author.IRepository
+	now        func() time.Time
+}
+
+func NewMemoryRepository(transactionManager *repoPkg.MemoryTransactionManager, authorRepo author.IRepository) *memoryRepository {
+	repo := &memoryRepository{
+		books:      map[uuid.UUID]Book{},
+		authorRepo: authorRepo,
+		now:        time.Now,
+	}
+	transactionManager.Register(repo)
+	return repo
+}
+
+func (r *memoryRepository) Snapshot() func() {
+	r.mu.RLock()
+	books := maps.Clone(r.books)
+	order := slices.Clone(r.order)
+	r.mu.RUnlock()
+
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.books = books
+		r.order = order
+	}
+}
+
+func (r *memoryRepository) Create(ctx context.Context, book *Book) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := book.BeforeCreate(nil); err != nil {
+		return err
+	}
+	if _, ok := r.books[book.ID]; ok || r.isbnTaken(book.ISBN, uuid.Nil) {
+		return gorm.ErrDuplicatedKey
+	}
+
+	now := r.now()
+	book.CreatedAt = now
+	book.UpdatedAt = now
+	stored := *book
+	stored.Author = nil
+	r.books[book.ID] = stored
+	r.order = append(r.order, book.ID)
+
+	return nil
+}
+
+func (r *memoryRepository) GetByID(ctx context.Context, id uuid.UUID) (*Book, error) {
+	r.mu.RLock()
+	book, ok := r.books[id]
+	r.mu.RUnlock()
+
+	if !ok || book.DeletedAt.Valid {
+		return nil, nil
+	}
+	return r.withAuthor(ctx, book)
+}
+
+func (r *memoryRepository) GetByISBN(ctx context.Context, isbn string) (*Book, error) {
+	r.mu.RLock()
+	var found *Book
+	for _, id := range r.order {
+		if book := r.books[id]; !book.DeletedAt.Valid && book.ISBN == isbn {
+			found = &book
+			break
+		}
+	}
+	r.mu.RUnlock()
+
+	if found == nil {
+		return nil, nil
+	}
+	return r.withAuthor(ctx, *found)
+}
+
+func (r *memoryRepository) GetAll(ctx context.Context, pagination *dto.PaginationRequest) (*dto.PaginationDataResponse[Book], error) {
+	books, total := r.page(pagination, func(book Book) bool { return true })
+
+	for i := range books {
+		withAuthor, err := r.withAuthor(ctx, books[i])
+		if err != nil {
+			return nil, err
+		}
+		books[i] = *withAuthor
+	}
+
+	return dto.NewPaginationDataResponse(books, pagination, total), nil
+}
+
+func (r *memoryRepository) GetByAuthorID(ctx context.Context, authorID uuid.UUID, pagination *dto.PaginationRequest) (*dto.PaginationDataResponse[Book], error) {
+	books, total := r.page(pagination, func(book Book) bool { return book.AuthorID == authorID })
+
+	return dto.NewPaginationDataResponse(books, pagination, total), nil
+}
+
+func (r *memoryRepository) Update(ctx context.Context, id uuid.UUID, book *Book) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.books[id]
+	if !ok || existing.DeletedAt.Valid {
+		return nil
+	}
+
+	// Match GORM's Updates with a struct, which skips zero-value fields.
+	if book.AuthorID != uuid.Nil {
+		existing.AuthorID = book.AuthorID
+	}
+	if book.Name != "" {
+		existing.Name = book.Name
+	}
+	if book.ISBN != "" {
+		if r.isbnTaken(book.ISBN, id) {
+			return gorm.ErrDuplicatedKey
+		}
+		existing.ISBN = book.ISBN
+	}
+	existing.UpdatedAt = r.now()
+	r.books[id] = existing
+
+	return nil
+}
+
+func (r *memoryRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.books[id]
+	if !ok || existing.DeletedAt.Valid {
+		return nil
+	}
+
+	existing.DeletedAt = gorm.DeletedAt{Time: r.now(), Valid: true}
+	r.books[id] = existing
+
+	return nil
+}
+
+func (r *memoryRepository) page(pagination *dto.PaginationRequest, match func(book Book) bool) ([]Book, int64) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	books := []Book{}
+	for _, id := range r.order {
+		if book := r.books[id]; !book.DeletedAt.Valid && match(book) {
+			books = append(books, book)
+		}
+	}
+
+	total := int64(len(books))
+	offset := min(pagination.GetOffset(), len(books))
+	end := min(offset+pagination.GetLimit(), len(books))
+
+	return books[offset:end], total
+}
+
+func (r *memoryRepository) withAuthor(ctx context.Context, book Book) (*Book, error) {
+	found, err := r.authorRepo.GetByID(ctx, book.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+	book.Author = found
+	return &book, nil
+}
+
+// isbnTaken checks soft-deleted rows too, since the unique constraint in the
+// database does not exclude them.
+func (r *memoryRepository) isbnTaken(isbn string, exclude uuid.UUID) bool {
+	for id, book := range r.books {
+		if id != exclude && book.ISBN == isbn {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,181 @@
+package book
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/sirawatc/simple-gin-crud/internal/author"
+	"github.com/sirawatc/simple-gin-crud/internal/shared/dto"
+	pkgDto "github.com/sirawatc/simple-gin-crud/pkg/dto"
+	repoPkg "github.com/sirawatc/simple-gin-crud/pkg/repository"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type MemoryRepositoryTestSuite struct {
+	suite.Suite
+	tm         *repoPkg.MemoryTransactionManager
+	authorRepo author.IRepository
+	repo       *memoryRepository
+	author     *author.Author
+	ctx        context.Context
+}
+
+func (suite *MemoryRepositoryTestSuite) SetupTest() {
+	suite.ctx = context.Background()
+	suite.tm = repoPkg.NewMemoryTransactionManager()
+	suite.authorRepo = author.NewMemoryRepository(suite.tm)
+	suite.repo = NewMemoryRepository(suite.tm, suite.authorRepo)
+
+	suite.author = &author.Author{PenName: "Test Author", BirthYear: 1990}
+	suite.Require().NoError(suite.authorRepo.Create(suite.ctx, suite.author))
+}
+
+func (suite *MemoryRepositoryTestSuite) create(isbn string) *Book {
+	book := &Book{AuthorID: suite.author.ID, Name: "Book " + isbn, ISBN: isbn}
+	suite.Require().NoError(suite.repo.Create(suite.ctx, book))
+	return book
+}
+
+func (suite *MemoryRepositoryTestSuite) TestImplementsInterface() {
+	suite.Implements((*IRepository)(nil), suite.repo)
+}
+
+func (suite *MemoryRepositoryTestSuite) TestCreate_DuplicateISBN() {
+	book := suite.create("9780375704024")
+	suite.NotEqual(uuid.Nil, book.ID)
+
+	err := suite.repo.Create(suite.ctx, &Book{AuthorID: suite.author.ID, Name: "Copy", ISBN: "9780375704024"})
+
+	suite.ErrorIs(err, gorm.ErrDuplicatedKey)
+}
+
+func (suite *MemoryRepositoryTestSuite) TestGetByID_PreloadsAuthor() {
+	book := suite.create("9780375704024")
+
+	found, err := suite.repo.GetByID(suite.ctx, book.ID)
+	suite.NoError(err)
+	suite.Equal(book.ISBN, found.ISBN)
+	suite.Equal("Test Author", found.Author.PenName)
+
+	missing, err := suite.repo.GetByID(suite.ctx, uuid.New())
+	suite.NoError(err)
+	suite.Nil(missing)
+}
+
+func (suite *MemoryRepositoryTestSuite) TestGetByISBN() {
+	book := suite.create("9780375704024")
+
+	found, err := suite.repo.GetByISBN(suite.ctx, "9780375704024")
+	suite.NoError(err)
+	suite.Equal(book.ID, found.ID)
+	suite.NotNil(found.Author)
+
+	missing, err := suite.repo.GetByISBN(suite.ctx, "9781400078776")
+	suite.NoError(err)
+	suite.Nil(missing)
+}
+
+func (suite *MemoryRepositoryTestSuite) TestGetAll_And_GetByAuthorID() {
+	other := &author.Author{PenName: "Other Author", BirthYear: 1980}
+	suite.Require().NoError(suite.authorRepo.Create(suite.ctx, other))
+
+	suite.create("9780375704024")
+	suite.create("9781400079278")
+	suite.Require().NoError(suite.repo.Create(suite.ctx, &Book{AuthorID: other.ID, Name: "Other", ISBN: "9780547773742"}))
+
+	all, err := suite.repo.GetAll(suite.ctx, &pkgDto.PaginationRequest{Page: 2, PageSize: 2})
+	suite.NoError(err)
+	suite.Len(all.Items, 1)
+	suite.Equal("9780547773742", all.Items[0].ISBN)
+	suite.Equal("Other Author", all.Items[0].Author.PenName)
+	suite.Equal(int64(3), all.Pagination.TotalItems)
+	suite.Equal(2, all.Pagination.TotalPages)
+
+	byAuthor, err := suite.repo.GetByAuthorID(suite.ctx, suite.author.ID, &pkgDto.PaginationRequest{Page: 1, PageSize: 10})
+	suite.NoError(err)
+	suite.Len(byAuthor.Items, 2)
+	suite.Equal(int64(2), byAuthor.Pagination.TotalItems)
+	suite.Nil(byAuthor.Items[0].Author, "GetByAuthorID does not preload the author")
+}
+
+func (suite *MemoryRepositoryTestSuite) TestUpdate() {
+	book := suite.create("9780375704024")
+	other := suite.create("9781400079278")
+
+	suite.NoError(suite.repo.Update(suite.ctx, book.ID, &Book{Name: "Renamed"}))
+	found, _ := suite.repo.GetByID(suite.ctx, book.ID)
+	suite.Equal("Renamed", found.Name)
+	suite.Equal("9780375704024", found.ISBN, "zero-value fields are not updated")
+
+	err := suite.repo.Update(suite.ctx, book.ID, &Book{ISBN: other.ISBN})
+	suite.ErrorIs(err, gorm.ErrDuplicatedKey)
+}
+
+func (suite *MemoryRepositoryTestSuite) TestDelete_IsSoft() {
+	book := suite.create("9780375704024")
+
+	suite.NoError(suite.repo.Delete(suite.ctx, book.ID))
+
+	found, err := suite.repo.GetByISBN(suite.ctx, book.ISBN)
+	suite.NoError(err)
+	suite.Nil(found)
+
+	all, _ := suite.repo.GetAll(suite.ctx, &pkgDto.PaginationRequest{Page: 1, PageSize: 10})
+	suite.Empty(all.Items)
+
+	err = suite.repo.Create(suite.ctx, &Book{AuthorID: suite.author.ID, Name: "Again", ISBN: book.ISBN})
+	suite.ErrorIs(err, gorm.ErrDuplicatedKey, "soft-deleted rows keep their unique ISBN")
+}
+
+func (suite *MemoryRepositoryTestSuite) TestDeletedAuthorIsNotPreloaded() {
+	book := suite.create("9780375704024")
+	suite.Require().NoError(suite.authorRepo.Delete(suite.ctx, suite.author.ID))
+
+	found, err := suite.repo.GetByID(suite.ctx, book.ID)
+
+	suite.NoError(err)
+	suite.Nil(found.Author)
+}
+
+func (suite *MemoryRepositoryTestSuite) TestTransaction_RollbackSpansRepositories() {
+	err := suite.tm.WithinTransaction(suite.ctx, func(ctx context.Context) error {
+		newAuthor := &author.Author{PenName: "Rolled Back", BirthYear: 1990}
+		suite.Require().NoError(suite.authorRepo.Create(ctx, newAuthor))
+		suite.Require().NoError(suite.repo.Create(ctx, &Book{AuthorID: newAuthor.ID, Name: "Gone", ISBN: "9780375704024"}))
+		return errors.New("boom")
+	})
+	suite.Error(err)
+
+	found, _ := suite.authorRepo.GetByPenName(suite.ctx, "Rolled Back")
+	suite.Nil(found)
+	book, _ := suite.repo.GetByISBN(suite.ctx, "9780375704024")
+	suite.Nil(book)
+}
+
+func (suite *MemoryRepositoryTestSuite) TestService_WithMemoryRepository() {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	service := NewService(suite.repo, author.NewService(suite.authorRepo, suite.tm, logger), suite.tm, logger)
+
+	created, code := service.CreateBook(suite.ctx, &CreateBookRequest{AuthorID: suite.author.ID, Name: "Book", ISBN: "9780375704024"})
+	suite.Equal(dto.Success, code)
+
+	_, code = service.CreateBook(suite.ctx, &CreateBookRequest{AuthorID: suite.author.ID, Name: "Copy", ISBN: "9780375704024"})
+	suite.Equal(dto.BookAlreadyExists, code)
+
+	_, code = service.CreateBook(suite.ctx, &CreateBookRequest{AuthorID: uuid.New(), Name: "Orphan", ISBN: "9781400078776"})
+	suite.Equal(dto.AuthorNotFound, code)
+
+	found, code := service.GetBookByID(suite.ctx, created.ID)
+	suite.Equal(dto.Success, code)
+	suite.Equal("Test Author", found.Author.PenName)
+}
+
+func TestMemoryRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(MemoryRepositoryTestSuite))
+}
@@ -23,11 +23,11 @@ func NewRepository(transactionManager pkgRepo.ITransactionManager, logger *logru
 	}
 }
 
-func (r *repository) Create(ctx context.Context, book *Book, tx ...*gorm.DB) error {
+func (r *repository) Create(ctx context.Context, book *Book) error {
 	logPrefix := "[BookRepository#Create]"
 	logger := logger.InjectRequestIDWithLogger(ctx, r.logger)
 
-	db := r.transactionManager.GetDB(tx...)
+	db := r.transactionManager.GetDBWithContext(ctx)
 
 	if err := db.Create(book).Error; err != nil {
 		logger.Errorf("%s Failed to create book: %v", logPrefix, err)
@@ -37,11 +37,11 @@ func (r *repository) Create(ctx context.Context, book *Book, tx ...*gorm.DB) err
 	return nil
 }
 
-func (r *repository) GetByID(ctx context.Context, id uuid.UUID, tx ...*gorm.DB) (*Book, error) {
+func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*Book, error) {
 	logPrefix := "[BookRepository#GetByID]"
 	logger := logger.InjectRequestIDWithLogger(ctx, r.logger)
 
-	db := r.transactionManager.GetDB(tx...)
+	db := r.transactionManager.GetDBWithContext(ctx)
 	var book Book
 
 	if err := db.Preload("Author").First(&book, "id = ?", id).Error; err != nil {
@@ -56,11 +56,11 @@ func (r *repository) GetByID(ctx context.Context, id uuid.UUID, tx ...*gorm.DB)
 	return &book, nil
 }
 
-func (r *repository) GetByISBN(ctx context.Context, isbn string, tx ...*gorm.DB) (*Book, error) {
+func (r *repository) GetByISBN(ctx context.Context, isbn string) (*Book, error) {
 	logPrefix := "[BookRepository#GetByISBN]"
 	logger := logger.InjectRequestIDWithLogger(ctx, r.logger)
 
-	db := r.transactionManager.GetDB(tx...)
+	db := r.transactionManager.GetDBWithContext(ctx)
 	var book Book
 
 	if err := db.Preload("Author").First(&book, "isbn = ?", isbn).Error; err != nil {
@@ -75,11 +75,11 @@ func (r *repository) GetByISBN(ctx context.Context, isbn string, tx ...*gorm.DB)
 	return &book, nil
 }
 
-func (r *repository) GetByAuthorID(ctx context.Context, authorID uuid.UUID, pagination *dto.PaginationRequest, tx ...*gorm.DB) (*dto.PaginationDataResponse[Book], error) {
+func (r *repository) GetByAuthorID(ctx context.Context, authorID uuid.UUID, pagination *dto.PaginationRequest) (*dto.PaginationDataResponse[Book], error) {
 	logPrefix := "[BookRepository#GetByAuthorID]"
 	logger := logger.InjectRequestIDWithLogger(ctx, r.logger)
 
-	db := r.transactionManager.GetDB(tx...)
+	db := r.transactionManager.GetDBWithContext(ctx)
 	var books []Book
 	var total int64
 
@@ -103,11 +103,11 @@ func (r *repository) GetByAuthorID(ctx context.Context, authorID uuid.UUID, pagi
 	return dto.NewPaginationDataResponse(books, pagination, total), nil
 }
 
-func (r *repository) GetAll(ctx context.Context, pagination *dto.PaginationRequest, tx ...*gorm.DB) (*dto.PaginationDataResponse[Book], error) {
+func (r *repository) GetAll(ctx context.Context, pagination *dto.PaginationRequest) (*dto.PaginationDataResponse[Book], error) {
 	logPrefix := "[BookRepository#GetAll]"
 	logger := logger.InjectRequestIDWithLogger(ctx, r.logger)
 
-	db := r.transactionManager.GetDB(tx...)
+	db := r.transactionManager.GetDBWithContext(ctx)
 	var books []Book
 	var total int64
 
@@ -131,11 +131,11 @@ func (r *repository) GetAll(ctx context.Context, pagination *dto.PaginationReque
 	return dto.NewPaginationDataResponse(books, pagination, total), nil
 }
 
-func (r *repository) Update(ctx context.Context, id uuid.UUID, book *Book, tx ...*gorm.DB) error {
+func (r *repository) Update(ctx context.Context, id uuid.UUID, book *Book) error {
 	logPrefix := "[BookRepository#Update]"
 	logger := logger.InjectRequestIDWithLogger(ctx, r.logger)
 
-	db := r.transactionManager.GetDB(tx...)
+	db := r.transactionManager.GetDBWithContext(ctx)
 
 	if err := db.Model(&Book{}).Where("id = ?", id).Updates(book).Error; err != nil {
 		logger.Errorf("%s Failed to update book: %v", logPrefix, err)
@@ -145,11 +145,11 @@ func (r *repository) Update(ctx context.Context, id uuid.UUID, book *Book, tx ..
 	return nil
 }
 
-func (r *repository) Delete(ctx context.Context, id uuid.UUID, tx ...*gorm.DB) error {
+func (r *repository) Delete(ctx context.Context, id uuid.UUID) error {
 	logPrefix := "[BookRepository#Delete]"
 	logger := logger.InjectRequestIDWithLogger(ctx, r.logger)
 
-	db := r.transactionManager.GetDB(tx...)
+	db := r.transactionManager.GetDBWithContext(ctx)
 
 	if err := db.Delete(&Book{}, "id = ?", id).Error; err != nil {
 		logger.Errorf("%s Failed to delete book: %v", logPrefix, err)
@@ -8,6 +8,7 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
 	"github.com/sirawatc/simple-gin-crud/pkg/dto"
+	repoPkg "github.com/sirawatc/simple-gin-crud/pkg/repository"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -19,12 +20,20 @@ type MockTransactionManager struct {
 	mock.Mock
 }
 
-func (m *MockTransactionManager) Transaction(fn func(tx *gorm.DB) error) error {
-	args := m.Called(fn)
+func (m *MockTransactionManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error, opts ...repoPkg.TxOption) error {
+	args := m.Called(ctx, fn)
 	return args.Error(0)
 }
 
-func (m *MockTransactionManager) GetDB(tx ...*gorm.DB) *gorm.DB {
+func (m *MockTransactionManager) GetDB() *gorm.DB {
+	args := m.Called()
+	if db, ok := args.Get(0).(*gorm.DB); ok {
+		return db
+	}
+	return nil
+}
+
+func (m *MockTransactionManager) GetDBWithContext(ctx context.Context) *gorm.DB {
 	args := m.Called()
 	if db, ok := args.Get(0).(*gorm.DB); ok {
 		return db
@@ -82,12 +91,11 @@ func (suite *RepositoryTestSuite) TestCreate_Success() {
 		Name:     "Test Book",
 		ISBN:     "978-0-7475-3269-9",
 	}
-	addRow := sqlmock.NewRows([]string{"id"}).AddRow(uuid.New())
 
-	suite.mockTM.On("GetDB").Return(suite.db)
+	suite.mockTM.On("GetDBWithContext").Return(suite.db)
 
 	suite.mock.ExpectBegin()
-	suite.mock.ExpectQuery("INSERT INTO \"books\" (.+)").WillReturnRows(addRow)
+	suite.mock.ExpectExec("INSERT INTO \"books\" (.+)").WillReturnResult(sqlmock.NewResult(1, 1))
 	suite.mock.ExpectCommit()
 
 	err := suite.repo.Create(context.Background(), book)
@@ -104,10 +112,10 @@ func (suite *RepositoryTestSuite) TestCreate_Error_DuplicateKey() {
 		ISBN:     "978-0-7475-3269-9",
 	}
 
-	suite.mockTM.On("GetDB").Return(suite.db)
+	suite.mockTM.On("GetDBWithContext").Return(suite.db)
 
 	suite.mock.ExpectBegin()
-	suite.mock.ExpectQuery("INSERT INTO \"books\" (.+)").WillReturnError(errors.New(errMsg))
+	suite.mock.ExpectExec("INSERT INTO \"books\" (.+)").WillReturnError(errors.New(errMsg))
 	suite.mock.ExpectRollback()
 
 	err := suite.repo.Create(context.Background(), book)
@@ -126,10 +134,10 @@ func (suite *RepositoryTestSuite) TestCreate_Error_ConnectionFailed() {
 		ISBN:     "978-0-7475-3269-9",
 	}
 
-	suite.mockTM.On("GetDB").Return(suite.db)
+	suite.mockTM.On("GetDBWithContext").Return(suite.db)
 
 	suite.mock.ExpectBegin()
-	suite.mock.ExpectQuery("INSERT INTO \"books\" (.+)").WillReturnError(errors.New(errMsg))
+	suite.mock.ExpectExec("INSERT INTO \"books\" (.+)").WillReturnError(errors.New(errMsg))
 	suite.mock.ExpectRollback()
 
 	err := suite.repo.Create(context.Background(), book)
@@ -147,7 +155,7 @@ func (suite *RepositoryTestSuite) TestGetByID_Success() {
 	authorDataRows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "deleted_at", "pen_name", "birth_year"}).
 		AddRow(authorID, nil, nil, nil, "Author 1", 1990)
 
-	suite.mockTM.On("GetDB").Return(suite.db)
+	suite.mockTM.On("GetDBWithContext").Return(suite.db)
 
 	suite.mock.ExpectQuery("SELECT \\* FROM \"books\" WHERE id = (.+)").WillReturnRows(bookDataRows)
 	suite.mock.ExpectQuery("SELECT \\* FROM \"authors\" WHERE \"authors\".\"id\" = (.+)").WillReturnRows(authorDataRows)
@@ -166,7 +174,7 @@ func (suite *RepositoryTestSuite) TestGetByID_Success_WithAuthor() {
 		AddRow(bookID, nil, nil, nil, uuid.New(), "Test Book", "978-0-7475-3269-9")
 	authorDataRows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "deleted_at", "pen_name", "birth_year"})
 
-	suite.mockTM.On("GetDB").Return(suite.db)
+	suite.mockTM.On("GetDBWithContext").Return(suite.db)
 
 	suite.mock.ExpectQuery("SELECT \\* FROM \"books\" WHERE id = (.+)").WillReturnRows(bookDataRows)
 	suite.mock.ExpectQuery("SELECT \\* FROM \"authors\" WHERE \"authors\".\"id\" = (.+)").WillReturnRows(authorDataRows)
@@ -182,7 +190,7 @@ func (suite *RepositoryTestSuite) TestGetByID_Success_WithAuthor() {
 func (suite *RepositoryTestSuite) TestGetByID_NotFound() {
 	bookID := uuid.New()
 
-	suite.mockTM.On("GetDB").Return(suite.db)
+	suite.mockTM.On("GetDBWithContext").Return(suite.db)
 
 	suite.mock.ExpectQuery("SELECT \\* FROM \"books\" WHERE id = (.+)").WillReturnError(gorm.ErrRecordNotFound)
 
@@ -197,7 +205,7 @@ func (suite *RepositoryTestSuite) TestGetByID_DatabaseError() {
 	bookID := uuid.New()
 	errMsg := "connection failed"
 
-	suite.mockTM.On("GetDB").Return(suite.db)
+	suite.mockTM.On("GetDBWithContext").Return(suite.db)
 
 	suite.mock.ExpectQuery("SELECT \\* FROM \"books\" WHERE id = (.+)").WillReturnError(errors.New(errMsg))
 
@@ -218,7 +226,7 @@ func (suite *RepositoryTestSuite) TestGetByISBN_Success() {
 	authorDataRows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "deleted_at", "pen_name", "birth_year"}).
 		AddRow(authorID, nil, nil, nil, "Author 1", 1990)
 
-	suite.mockTM.On("GetDB").Return(suite.db)
+	suite.mockTM.On("GetDBWithContext").Return(suite.db)
 
 	suite.mock.ExpectQuery("SELECT \\* FROM \"books\" WHERE isbn = (.+)").WillReturnRows(bookDataRows)
 	suite.mock.ExpectQuery("SELECT \\* FROM \"authors\" WHERE \"authors\".\"id\" = (.+)").WillReturnRows(authorDataRows)
@@ -238,7 +246,7 @@ func (suite *RepositoryTestSuite) TestGetByISBN_Success_WithAuthor() {
 		AddRow(bookID, nil, nil, nil, uuid.New(), "Test Book", isbn)
 	authorDataRows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "deleted_at", "pen_name", "birth_year"})
 
-	suite.mockTM.On("GetDB").Return(suite.db)
+	suite.mockTM.On("GetDBWithContext").Return(suite.db)
 
 	suite.mock.ExpectQuery("SELECT \\* FROM \"books\" WHERE isbn = (.+)").WillReturnRows(bookDataRows)
 	suite.mock.ExpectQuery("SELECT \\* FROM \"authors\" WHERE \"authors\".\"id\" = (.+)").WillReturnRows(authorDataRows)
@@ -254,7 +262,7 @@ func (suite *RepositoryTestSuite) TestGetByISBN_Success_WithAuthor() {
 func (suite *RepositoryTestSuite) TestGetByISBN_NotFound() {
 	isbn := "978-0-7475-3269-9"
 
-	suite.mockTM.On("GetDB").Return(suite.db)
+	suite.mockTM.On("GetDBWithContext").Return(suite.db)
 
 	suite.mock.ExpectQuery("SELECT \\* FROM \"books\" WHERE isbn = (.+)").WillReturnError(gorm.ErrRecordNotFound)
 
@@ -269,7 +277,7 @@ func (suite *RepositoryTestSuite) TestGetByISBN_DatabaseError() {
 	isbn := "978-0-7475-3269-9"
 	errMsg := "connection failed"
 
-	suite.mockTM.On("GetDB").Return(suite.db)
+	suite.mockTM.On("GetDBWithContext").Return(suite.db)
 
 	suite.mock.ExpectQuery("SELECT \\* FROM \"books\" WHERE isbn = (.+)").WillReturnError(errors.New(errMsg))
 
@@ -297,7 +305,7 @@ func (suite *RepositoryTestSuite) TestGetAll_Success() {
 		AddRow(authorID, nil, nil, nil, "Author 1", 1990).
 		AddRow(authorID2, nil, nil, nil, "Author 2", 1991)
 
-	suite.mockTM.On("GetDB").Return(suite.db)
+	suite.mockTM.On("GetDBWithContext").Return(suite.db)
 
 	suite.mock.ExpectQuery("SELECT count\\(\\*\\) FROM \"books\" (.+)").WillReturnRows(countRows)
 	suite.mock.ExpectQuery("SELECT \\* FROM \"books\" (.+)").WillReturnRows(bookDataRows)
@@ -326,7 +334,7 @@ func (suite *RepositoryTestSuite) TestGetAll_Success_WithAuthor() {
 		AddRow(uuid.New(), nil, nil, nil, uuid.New(), "Book 1", "978-0-7475-3269-9")
 	authorDataRows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "deleted_at", "pen_name", "birth_year"})
 
-	suite.mockTM.On("GetDB").Return(suite.db)
+	suite.mockTM.On("GetDBWithContext").Return(suite.db)
 
 	suite.mock.ExpectQuery("SELECT count\\(\\*\\) FROM \"books\" (.+)").WillReturnRows(countRows)
 	suite.mock.ExpectQuery("SELECT \\* FROM \"books\" (.+)").WillReturnRows(bookDataRows)
@@ -352,7 +360,7 @@ func (suite *RepositoryTestSuite) TestGetAll_EmptyResult() {
 	countRows := sqlmock.NewRows([]string{"count"}).AddRow(0)
 	dataRows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "deleted_at", "author_id", "name", "isbn"})
 
-	suite.mockTM.On("GetDB").Return(suite.db)
+	suite.mockTM.On("GetDBWithContext").Return(suite.db)
 
 	suite.mock.ExpectQuery("SELECT count\\(\\*\\) FROM \"books\" (.+)").WillReturnRows(countRows)
 	suite.mock.ExpectQuery("SELECT \\* FROM \"books\" (.+)").WillReturnRows(dataRows)
@@ -374,7 +382,7 @@ func (suite *RepositoryTestSuite) TestGetAll_DatabaseError() {
 	}
 	errMsg := "connection failed"
 
-	suite.mockTM.On("GetDB").Return(suite.db)
+	suite.mockTM.On("GetDBWithContext").Return(suite.db)
 
 	suite.mock.ExpectQuery("SELECT count\\(\\*\\) FROM \"books\" (.+)").WillReturnError(errors.New(errMsg))
 
@@ -398,7 +406,7 @@ func (suite *RepositoryTestSuite) TestGetByAuthorID_Success() {
 		AddRow(uuid.New(), nil, nil, nil, authorID, "Book 1", "978-0-7475-3269-9").
 		AddRow(uuid.New(), nil, nil, nil, authorID, "Book 2", "978-0-7475-3269-8")
 
-	suite.mockTM.On("GetDB").Return(suite.db)
+	suite.mockTM.On("GetDBWithContext").Return(suite.db)
 
 	suite.mock.ExpectQuery("SELECT count\\(\\*\\) FROM \"books\" WHERE author_id = (.+)").WillReturnRows(countRows)
 	suite.mock.ExpectQuery("SELECT \\* FROM \"books\" WHERE author_id = (.+)").WillReturnRows(dataRows)
@@ -423,7 +431,7 @@ func (suite *RepositoryTestSuite) TestGetByAuthorID_EmptyResult() {
 	countRows := sqlmock.NewRows([]string{"count"}).AddRow(0)
 	dataRows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "deleted_at", "author_id", "name", "isbn"})
 
-	suite.mockTM.On("GetDB").Return(suite.db)
+	suite.mockTM.On("GetDBWithContext").Return(suite.db)
 
 	suite.mock.ExpectQuery("SELECT count\\(\\*\\) FROM \"books\" WHERE author_id = (.+)").WillReturnRows(countRows)
 	suite.mock.ExpectQuery("SELECT \\* FROM \"books\" WHERE author_id = (.+)").WillReturnRows(dataRows)
@@ -446,7 +454,7 @@ func (suite *RepositoryTestSuite) TestGetByAuthorID_DatabaseError() {
 	}
 	errMsg := "connection failed"
 
-	suite.mockTM.On("GetDB").Return(suite.db)
+	suite.mockTM.On("GetDBWithContext").Return(suite.db)
 
 	suite.mock.ExpectQuery("SELECT count\\(\\*\\) FROM \"books\" WHERE author_id = (.+)").WillReturnError(errors.New(errMsg))
 
@@ -467,7 +475,7 @@ func (suite *RepositoryTestSuite) TestUpdate_Success() {
 		ISBN:     "978-0-7475-3269-9",
 	}
 
-	suite.mockTM.On("GetDB").Return(suite.db)
+	suite.mockTM.On("GetDBWithContext").Return(suite.db)
 
 	suite.mock.ExpectBegin()
 	suite.mock.ExpectExec("UPDATE \"books\" SET (.+) WHERE id = (.+)").WillReturnResult(sqlmock.NewResult(1, 1))
@@ -488,7 +496,7 @@ func (suite *RepositoryTestSuite) TestUpdate_NotFound() {
 		ISBN:     "978-0-7475-3269-9",
 	}
 
-	suite.mockTM.On("GetDB").Return(suite.db)
+	suite.mockTM.On("GetDBWithContext").Return(suite.db)
 
 	suite.mock.ExpectBegin()
 	suite.mock.ExpectExec("UPDATE \"books\" SET (.+) WHERE id = (.+)").WillReturnResult(sqlmock.NewResult(0, 0))
@@ -510,7 +518,7 @@ func (suite *RepositoryTestSuite) TestUpdate_DatabaseError() {
 	}
 	errMsg := "connection failed"
 
-	suite.mockTM.On("GetDB").Return(suite.db)
+	suite.mockTM.On("GetDBWithContext").Return(suite.db)
 
 	suite.mock.ExpectBegin()
 	suite.mock.ExpectExec("UPDATE \"books\" SET (.+) WHERE id = (.+)").WillReturnError(errors.New(errMsg))
@@ -526,7 +534,7 @@ func (suite *RepositoryTestSuite) TestUpdate_DatabaseError() {
 func (suite *RepositoryTestSuite) TestDelete_Success() {
 	bookID := uuid.New()
 
-	suite.mockTM.On("GetDB").Return(suite.db)
+	suite.mockTM.On("GetDBWithContext").Return(suite.db)
 
 	suite.mock.ExpectBegin()
 	suite.mock.ExpectExec("UPDATE \"books\" SET \"deleted_at\"=(.+) WHERE id = (.+)").WillReturnResult(sqlmock.NewResult(1, 1))
@@ -541,7 +549,7 @@ func (suite *RepositoryTestSuite) TestDelete_Success() {
 func (suite *RepositoryTestSuite) TestDelete_NotFound() {
 	bookID := uuid.New()
 
-	suite.mockTM.On("GetDB").Return(suite.db)
+	suite.mockTM.On("GetDBWithContext").Return(suite.db)
 
 	suite.mock.ExpectBegin()
 	suite.mock.ExpectExec("UPDATE \"books\" SET \"deleted_at\"=(.+) WHERE id = (.+)").WillReturnResult(sqlmock.NewResult(0, 0))
@@ -557,7 +565,7 @@ func (suite *RepositoryTestSuite) TestDelete_DatabaseError() {
 	bookID := uuid.New()
 	errMsg := "connection failed"
 
-	suite.mockTM.On("GetDB").Return(suite.db)
+	suite.mockTM.On("GetDBWithContext").Return(suite.db)
 
 	suite.mock.ExpectBegin()
 	suite.mock.ExpectExec("UPDATE \"books\" SET \"deleted_at\"=(.+) WHERE id = (.+)").WillReturnError(errors.New(errMsg))
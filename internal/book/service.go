@@ -2,86 +2,149 @@ package book
 
 import (
 	"context"
+	"errors"
 
 	"github.com/google/uuid"
 	"github.com/sirawatc/simple-gin-crud/internal/shared/dto"
+	"github.com/sirawatc/simple-gin-crud/internal/shared/transaction"
 	pkgDto "github.com/sirawatc/simple-gin-crud/pkg/dto"
 	"github.com/sirawatc/simple-gin-crud/pkg/logger"
+	"github.com/sirawatc/simple-gin-crud/pkg/metrics"
+	repoPkg "github.com/sirawatc/simple-gin-crud/pkg/repository"
+	"github.com/sirawatc/simple-gin-crud/pkg/tracing"
 	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
 )
 
 type service struct {
-	repo          IRepository
-	authorService IAuthorService
-	logger        *logrus.Logger
+	repo               IRepository
+	authorService      IAuthorService
+	transactionManager repoPkg.ITransactionManager
+	logger             *logrus.Logger
 }
 
-func NewService(repo IRepository, authorService IAuthorService, logger *logrus.Logger) *service {
+func NewService(repo IRepository, authorService IAuthorService, transactionManager repoPkg.ITransactionManager, logger *logrus.Logger) *service {
 	return &service{
-		repo:          repo,
-		authorService: authorService,
-		logger:        logger,
+		repo:               repo,
+		authorService:      authorService,
+		transactionManager: transactionManager,
+		logger:             logger,
 	}
 }
 
 func (s *service) CreateBook(ctx context.Context, req *CreateBookRequest) (*Book, dto.Code) {
+	ctx, span := tracing.StartSpan(ctx, "BookService.CreateBook")
+	defer span.End()
+
 	logPrefix := "[BookService#CreateBook]"
 	logger := logger.InjectRequestIDWithLogger(ctx, s.logger)
-
-	author, code := s.authorService.GetAuthorByID(ctx, req.AuthorID)
+	isbn := NormalizeISBN(req.ISBN)
+
+	var book *Book
+	code, err := transaction.Run(ctx, s.transactionManager, func(ctx context.Context) dto.Code {
+		author, code := s.authorService.GetAuthorByID(ctx, req.AuthorID)
+		if code != dto.Success {
+			logger.Errorf("%s Failed to get author by ID: %v", logPrefix, code)
+			return code
+		}
+
+		if author == nil {
+			logger.Infof("%s Author not found: %v", logPrefix, req.AuthorID)
+			return dto.AuthorNotFound
+		}
+
+		existing, err := s.repo.GetByISBN(ctx, isbn)
+		if err != nil {
+			logger.Errorf("%s Failed to get book by ISBN: %v", logPrefix, err)
+			return dto.InternalError
+		}
+
+		if existing != nil {
+			logger.Infof("%s Book already exists: %v", logPrefix, isbn)
+			metrics.RecordDomainEvent(metrics.DomainBook, metrics.EventISBNConflict)
+			return dto.BookAlreadyExists
+		}
+
+		logger.Infof("%s Creating book: %+v", logPrefix, req)
+
+		book = &Book{
+			AuthorID: req.AuthorID,
+			Name:     req.Name,
+			ISBN:     isbn,
+		}
+
+		if err := s.repo.Create(ctx, book); err != nil {
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				// A concurrent request created the same ISBN after our check.
+				logger.Infof("%s Book already exists: %v", logPrefix, isbn)
+				metrics.RecordDomainEvent(metrics.DomainBook, metrics.EventISBNConflict)
+				return dto.BookAlreadyExists
+			}
+			if errors.Is(err, gorm.ErrForeignKeyViolated) {
+				logger.Infof("%s Author not found: %v", logPrefix, req.AuthorID)
+				return dto.AuthorNotFound
+			}
+			logger.Errorf("%s Failed to create book: %v", logPrefix, err)
+			return dto.InternalError
+		}
+		return dto.Success
+	})
+	if err != nil {
+		logger.Errorf("%s Transaction failed: %v", logPrefix, err)
+	}
 	if code != dto.Success {
-		logger.Errorf("%s Failed to get author by ID: %v", logPrefix, code)
 		return nil, code
 	}
 
-	if author == nil {
-		logger.Infof("%s Author not found: %v", logPrefix, req.AuthorID)
-		return nil, dto.AuthorNotFound
-	}
-
-	book, err := s.repo.GetByISBN(ctx, req.ISBN)
-	if err != nil {
-		logger.Errorf("%s Failed to get book by ISBN: %v", logPrefix, err)
-		return nil, dto.InternalError
-	}
+	logger.Infof("%s Book created successfully: %v", logPrefix, book.ID)
+	metrics.RecordDomainEvent(metrics.DomainBook, metrics.EventCreated)
+	return book, dto.Success
+}
 
-	if book != nil {
-		logger.Infof("%s Book already exists: %v", logPrefix, req.ISBN)
-		return nil, dto.BookAlreadyExists
-	}
+func (s *service) GetBookByID(ctx context.Context, id uuid.UUID) (*Book, dto.Code) {
+	ctx, span := tracing.StartSpan(ctx, "BookService.GetBookByID")
+	defer span.End()
 
-	logger.Infof("%s Creating book: %+v", logPrefix, req)
+	logPrefix := "[BookService#GetBookByID]"
+	logger := logger.InjectRequestIDWithLogger(ctx, s.logger)
 
-	book = &Book{
-		AuthorID: req.AuthorID,
-		Name:     req.Name,
-		ISBN:     req.ISBN,
-	}
+	logger.Infof("%s Getting book by ID: %v", logPrefix, id)
 
-	err = s.repo.Create(ctx, book)
+	book, err := s.repo.GetByID(ctx, id)
 	if err != nil {
-		logger.Errorf("%s Failed to create book: %v", logPrefix, err)
+		logger.Errorf("%s Failed to get book by ID: %v", logPrefix, err)
 		return nil, dto.InternalError
 	}
 
-	logger.Infof("%s Book created successfully: %v", logPrefix, book.ID)
+	if book == nil {
+		logger.Infof("%s Book not found: %v", logPrefix, id)
+		metrics.RecordDomainEvent(metrics.DomainBook, metrics.EventNotFound)
+		return nil, dto.BookNotFound
+	}
+
+	logger.Infof("%s Book retrieved successfully: %v", logPrefix, book.ID)
 	return book, dto.Success
 }
 
-func (s *service) GetBookByID(ctx context.Context, id uuid.UUID) (*Book, dto.Code) {
-	logPrefix := "[BookService#GetBookByID]"
+func (s *service) GetBookByISBN(ctx context.Context, isbn string) (*Book, dto.Code) {
+	ctx, span := tracing.StartSpan(ctx, "BookService.GetBookByISBN")
+	defer span.End()
+
+	logPrefix := "[BookService#GetBookByISBN]"
 	logger := logger.InjectRequestIDWithLogger(ctx, s.logger)
 
-	logger.Infof("%s Getting book by ID: %v", logPrefix, id)
+	isbn = NormalizeISBN(isbn)
+	logger.Infof("%s Getting book by ISBN: %v", logPrefix, isbn)
 
-	book, err := s.repo.GetByID(ctx, id)
+	book, err := s.repo.GetByISBN(ctx, isbn)
 	if err != nil {
-		logger.Errorf("%s Failed to get book by ID: %v", logPrefix, err)
+		logger.Errorf("%s Failed to get book by ISBN: %v", logPrefix, err)
 		return nil, dto.InternalError
 	}
 
 	if book == nil {
-		logger.Infof("%s Book not found: %v", logPrefix, id)
+		logger.Infof("%s Book not found: %v", logPrefix, isbn)
+		metrics.RecordDomainEvent(metrics.DomainBook, metrics.EventNotFound)
 		return nil, dto.BookNotFound
 	}
 
@@ -90,6 +153,9 @@ func (s *service) GetBookByID(ctx context.Context, id uuid.UUID) (*Book, dto.Cod
 }
 
 func (s *service) GetAllBooks(ctx context.Context, pagination *pkgDto.PaginationRequest) (*pkgDto.PaginationDataResponse[Book], dto.Code) {
+	ctx, span := tracing.StartSpan(ctx, "BookService.GetAllBooks")
+	defer span.End()
+
 	logPrefix := "[BookService#GetAllBooks]"
 	logger := logger.InjectRequestIDWithLogger(ctx, s.logger)
 
@@ -111,6 +177,9 @@ func (s *service) GetAllBooks(ctx context.Context, pagination *pkgDto.Pagination
 }
 
 func (s *service) GetBooksByAuthorID(ctx context.Context, authorID uuid.UUID, pagination *pkgDto.PaginationRequest) (*pkgDto.PaginationDataResponse[Book], dto.Code) {
+	ctx, span := tracing.StartSpan(ctx, "BookService.GetBooksByAuthorID")
+	defer span.End()
+
 	logPrefix := "[BookService#GetBooksByAuthorID]"
 	logger := logger.InjectRequestIDWithLogger(ctx, s.logger)
 
@@ -132,50 +201,87 @@ func (s *service) GetBooksByAuthorID(ctx context.Context, authorID uuid.UUID, pa
 }
 
 func (s *service) UpdateBook(ctx context.Context, id uuid.UUID, req *UpdateBookRequest) dto.Code {
+	ctx, span := tracing.StartSpan(ctx, "BookService.UpdateBook")
+	defer span.End()
+
 	logPrefix := "[BookService#UpdateBook]"
 	logger := logger.InjectRequestIDWithLogger(ctx, s.logger)
-
-	book, err := s.repo.GetByID(ctx, id)
+	isbn := NormalizeISBN(req.ISBN)
+
+	code, err := transaction.Run(ctx, s.transactionManager, func(ctx context.Context) dto.Code {
+		book, err := s.repo.GetByID(ctx, id)
+		if err != nil {
+			logger.Errorf("%s Failed to get book by ID: %v", logPrefix, err)
+			return dto.InternalError
+		}
+
+		if book == nil {
+			logger.Infof("%s Book not found: %v", logPrefix, id)
+			metrics.RecordDomainEvent(metrics.DomainBook, metrics.EventNotFound)
+			return dto.BookNotFound
+		}
+
+		author, code := s.authorService.GetAuthorByID(ctx, req.AuthorID)
+		if code != dto.Success {
+			logger.Errorf("%s Failed to get author by ID: %v", logPrefix, code)
+			return code
+		}
+
+		if author == nil {
+			logger.Infof("%s Author not found: %v", logPrefix, req.AuthorID)
+			return dto.AuthorNotFound
+		}
+
+		existing, err := s.repo.GetByISBN(ctx, isbn)
+		if err != nil {
+			logger.Errorf("%s Failed to get book by ISBN: %v", logPrefix, err)
+			return dto.InternalError
+		}
+
+		if existing != nil && existing.ID != id {
+			logger.Infof("%s Book already exists: %v", logPrefix, isbn)
+			metrics.RecordDomainEvent(metrics.DomainBook, metrics.EventISBNConflict)
+			return dto.BookAlreadyExists
+		}
+
+		logger.Infof("%s Updating book %v: %+v", logPrefix, id, req)
+
+		book = &Book{
+			AuthorID: req.AuthorID,
+			Name:     req.Name,
+			ISBN:     isbn,
+		}
+
+		if err := s.repo.Update(ctx, id, book); err != nil {
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				logger.Infof("%s Book already exists: %v", logPrefix, isbn)
+				metrics.RecordDomainEvent(metrics.DomainBook, metrics.EventISBNConflict)
+				return dto.BookAlreadyExists
+			}
+			if errors.Is(err, gorm.ErrForeignKeyViolated) {
+				logger.Infof("%s Author not found: %v", logPrefix, req.AuthorID)
+				return dto.AuthorNotFound
+			}
+			logger.Errorf("%s Failed to update book: %v", logPrefix, err)
+			return dto.InternalError
+		}
+		return dto.Success
+	})
 	if err != nil {
-		logger.Errorf("%s Failed to get book by ID: %v", logPrefix, err)
-		return dto.InternalError
+		logger.Errorf("%s Transaction failed: %v", logPrefix, err)
 	}
-
-	if book == nil {
-		logger.Infof("%s Book not found: %v", logPrefix, id)
-		return dto.BookNotFound
-	}
-
-	author, code := s.authorService.GetAuthorByID(ctx, req.AuthorID)
 	if code != dto.Success {
-		logger.Errorf("%s Failed to get author by ID: %v", logPrefix, code)
 		return code
 	}
 
-	if author == nil {
-		logger.Infof("%s Author not found: %v", logPrefix, req.AuthorID)
-		return dto.AuthorNotFound
-	}
-
-	logger.Infof("%s Updating book %v: %+v", logPrefix, id, req)
-
-	book = &Book{
-		AuthorID: req.AuthorID,
-		Name:     req.Name,
-		ISBN:     req.ISBN,
-	}
-
-	err = s.repo.Update(ctx, id, book)
-	if err != nil {
-		logger.Errorf("%s Failed to update book: %v", logPrefix, err)
-		return dto.InternalError
-	}
-
 	logger.Infof("%s Book %v updated successfully", logPrefix, id)
 	return dto.Success
 }
 
 func (s *service) DeleteBook(ctx context.Context, id uuid.UUID) dto.Code {
+	ctx, span := tracing.StartSpan(ctx, "BookService.DeleteBook")
+	defer span.End()
+
 	logPrefix := "[BookService#DeleteBook]"
 	logger := logger.InjectRequestIDWithLogger(ctx, s.logger)
 
@@ -10,6 +10,7 @@ import (
 	"github.com/sirawatc/simple-gin-crud/internal/shared/dto"
 	"github.com/sirawatc/simple-gin-crud/internal/shared/models"
 	pkgDto "github.com/sirawatc/simple-gin-crud/pkg/dto"
+	repoPkg "github.com/sirawatc/simple-gin-crud/pkg/repository"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -20,85 +21,50 @@ type MockRepository struct {
 	mock.Mock
 }
 
-func (m *MockRepository) Create(ctx context.Context, book *Book, tx ...*gorm.DB) error {
-	var args mock.Arguments
-	if len(tx) > 0 {
-		args = m.Called(ctx, book, tx)
-	} else {
-		args = m.Called(ctx, book)
-	}
+func (m *MockRepository) Create(ctx context.Context, book *Book) error {
+	args := m.Called(ctx, book)
 	return args.Error(0)
 }
 
-func (m *MockRepository) GetByID(ctx context.Context, id uuid.UUID, tx ...*gorm.DB) (*Book, error) {
-	var args mock.Arguments
-	if len(tx) > 0 {
-		args = m.Called(ctx, id, tx)
-	} else {
-		args = m.Called(ctx, id)
-	}
+func (m *MockRepository) GetByID(ctx context.Context, id uuid.UUID) (*Book, error) {
+	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*Book), args.Error(1)
 }
 
-func (m *MockRepository) GetByISBN(ctx context.Context, isbn string, tx ...*gorm.DB) (*Book, error) {
-	var args mock.Arguments
-	if len(tx) > 0 {
-		args = m.Called(ctx, isbn, tx)
-	} else {
-		args = m.Called(ctx, isbn)
-	}
+func (m *MockRepository) GetByISBN(ctx context.Context, isbn string) (*Book, error) {
+	args := m.Called(ctx, isbn)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*Book), args.Error(1)
 }
 
-func (m *MockRepository) GetAll(ctx context.Context, pagination *pkgDto.PaginationRequest, tx ...*gorm.DB) (*pkgDto.PaginationDataResponse[Book], error) {
-	var args mock.Arguments
-	if len(tx) > 0 {
-		args = m.Called(ctx, pagination, tx)
-	} else {
-		args = m.Called(ctx, pagination)
-	}
+func (m *MockRepository) GetAll(ctx context.Context, pagination *pkgDto.PaginationRequest) (*pkgDto.PaginationDataResponse[Book], error) {
+	args := m.Called(ctx, pagination)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*pkgDto.PaginationDataResponse[Book]), args.Error(1)
 }
 
-func (m *MockRepository) GetByAuthorID(ctx context.Context, authorID uuid.UUID, pagination *pkgDto.PaginationRequest, tx ...*gorm.DB) (*pkgDto.PaginationDataResponse[Book], error) {
-	var args mock.Arguments
-	if len(tx) > 0 {
-		args = m.Called(ctx, authorID, pagination, tx)
-	} else {
-		args = m.Called(ctx, authorID, pagination)
-	}
+func (m *MockRepository) GetByAuthorID(ctx context.Context, authorID uuid.UUID, pagination *pkgDto.PaginationRequest) (*pkgDto.PaginationDataResponse[Book], error) {
+	args := m.Called(ctx, authorID, pagination)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*pkgDto.PaginationDataResponse[Book]), args.Error(1)
 }
 
-func (m *MockRepository) Update(ctx context.Context, id uuid.UUID, book *Book, tx ...*gorm.DB) error {
-	var args mock.Arguments
-	if len(tx) > 0 {
-		args = m.Called(ctx, id, book, tx)
-	} else {
-		args = m.Called(ctx, id, book)
-	}
+func (m *MockRepository) Update(ctx context.Context, id uuid.UUID, book *Book) error {
+	args := m.Called(ctx, id, book)
 	return args.Error(0)
 }
 
-func (m *MockRepository) Delete(ctx context.Context, id uuid.UUID, tx ...*gorm.DB) error {
-	var args mock.Arguments
-	if len(tx) > 0 {
-		args = m.Called(ctx, id, tx)
-	} else {
-		args = m.Called(ctx, id)
-	}
+func (m *MockRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
 	return args.Error(0)
 }
 
@@ -127,7 +93,7 @@ func (suite *ServiceTestSuite) SetupTest() {
 	mockAuthorService := new(MockAuthorService)
 	logger := logrus.New()
 	logger.SetLevel(logrus.ErrorLevel)
-	service := NewService(mockRepo, mockAuthorService, logger)
+	service := NewService(mockRepo, mockAuthorService, repoPkg.NewMemoryTransactionManager(), logger)
 
 	suite.service = service
 	suite.mockRepo = mockRepo
@@ -139,7 +105,7 @@ func (suite *ServiceTestSuite) TestNewService() {
 	mockRepo := new(MockRepository)
 	mockAuthorService := new(MockAuthorService)
 	logger := logrus.New()
-	service := NewService(mockRepo, mockAuthorService, logger)
+	service := NewService(mockRepo, mockAuthorService, repoPkg.NewMemoryTransactionManager(), logger)
 
 	suite.NotNil(service)
 
@@ -162,16 +128,18 @@ func (suite *ServiceTestSuite) TestCreateBook_Success() {
 		BirthYear: 1990,
 	}
 
-	suite.mockAuthorService.On("GetAuthorByID", suite.ctx, authorID).Return(expectedAuthor, dto.Success)
-	suite.mockRepo.On("GetByISBN", suite.ctx, req.ISBN).Return((*Book)(nil), nil)
-	suite.mockRepo.On("Create", suite.ctx, mock.AnythingOfType("*book.Book")).Return(nil)
+	// The author is read in the transaction so the check is atomic with the insert.
+	inTransaction := mock.MatchedBy(func(ctx context.Context) bool { return repoPkg.InTransaction(ctx) })
+	suite.mockAuthorService.On("GetAuthorByID", inTransaction, authorID).Return(expectedAuthor, dto.Success)
+	suite.mockRepo.On("GetByISBN", mock.Anything, NormalizeISBN(req.ISBN)).Return((*Book)(nil), nil)
+	suite.mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*book.Book")).Return(nil)
 
 	book, code := suite.service.CreateBook(suite.ctx, req)
 
 	suite.Equal(dto.Success, code)
 	suite.NotNil(book)
 	suite.Equal(req.Name, book.Name)
-	suite.Equal(req.ISBN, book.ISBN)
+	suite.Equal("9780747532699", book.ISBN, "the ISBN is stored without hyphens")
 	suite.Equal(req.AuthorID, book.AuthorID)
 	suite.mockAuthorService.AssertExpectations(suite.T())
 	suite.mockRepo.AssertExpectations(suite.T())
@@ -185,7 +153,7 @@ func (suite *ServiceTestSuite) TestCreateBook_AuthorNotFound() {
 		ISBN:     "978-0-7475-3269-9",
 	}
 
-	suite.mockAuthorService.On("GetAuthorByID", suite.ctx, authorID).Return((*author.Author)(nil), dto.AuthorNotFound)
+	suite.mockAuthorService.On("GetAuthorByID", mock.Anything, authorID).Return((*author.Author)(nil), dto.AuthorNotFound)
 
 	book, code := suite.service.CreateBook(suite.ctx, req)
 
@@ -202,7 +170,7 @@ func (suite *ServiceTestSuite) TestCreateBook_GetAuthorByIDError() {
 		ISBN:     "978-0-7475-3269-9",
 	}
 
-	suite.mockAuthorService.On("GetAuthorByID", suite.ctx, authorID).Return((*author.Author)(nil), dto.InternalError)
+	suite.mockAuthorService.On("GetAuthorByID", mock.Anything, authorID).Return((*author.Author)(nil), dto.InternalError)
 
 	book, code := suite.service.CreateBook(suite.ctx, req)
 
@@ -233,8 +201,8 @@ func (suite *ServiceTestSuite) TestCreateBook_BookAlreadyExists() {
 		ISBN:      "978-0-7475-3269-9",
 	}
 
-	suite.mockAuthorService.On("GetAuthorByID", suite.ctx, authorID).Return(expectedAuthor, dto.Success)
-	suite.mockRepo.On("GetByISBN", suite.ctx, req.ISBN).Return(existingBook, nil)
+	suite.mockAuthorService.On("GetAuthorByID", mock.Anything, authorID).Return(expectedAuthor, dto.Success)
+	suite.mockRepo.On("GetByISBN", mock.Anything, NormalizeISBN(req.ISBN)).Return(existingBook, nil)
 
 	book, code := suite.service.CreateBook(suite.ctx, req)
 
@@ -258,8 +226,8 @@ func (suite *ServiceTestSuite) TestCreateBook_GetByISBNError() {
 		BirthYear: 1990,
 	}
 
-	suite.mockAuthorService.On("GetAuthorByID", suite.ctx, authorID).Return(expectedAuthor, dto.Success)
-	suite.mockRepo.On("GetByISBN", suite.ctx, req.ISBN).Return((*Book)(nil), errors.New("database error"))
+	suite.mockAuthorService.On("GetAuthorByID", mock.Anything, authorID).Return(expectedAuthor, dto.Success)
+	suite.mockRepo.On("GetByISBN", mock.Anything, NormalizeISBN(req.ISBN)).Return((*Book)(nil), errors.New("database error"))
 
 	book, code := suite.service.CreateBook(suite.ctx, req)
 
@@ -283,9 +251,9 @@ func (suite *ServiceTestSuite) TestCreateBook_CreateError() {
 		BirthYear: 1990,
 	}
 
-	suite.mockAuthorService.On("GetAuthorByID", suite.ctx, authorID).Return(expectedAuthor, dto.Success)
-	suite.mockRepo.On("GetByISBN", suite.ctx, req.ISBN).Return((*Book)(nil), nil)
-	suite.mockRepo.On("Create", suite.ctx, mock.AnythingOfType("*book.Book")).Return(errors.New("database error"))
+	suite.mockAuthorService.On("GetAuthorByID", mock.Anything, authorID).Return(expectedAuthor, dto.Success)
+	suite.mockRepo.On("GetByISBN", mock.Anything, NormalizeISBN(req.ISBN)).Return((*Book)(nil), nil)
+	suite.mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*book.Book")).Return(errors.New("database error"))
 
 	book, code := suite.service.CreateBook(suite.ctx, req)
 
@@ -295,6 +263,58 @@ func (suite *ServiceTestSuite) TestCreateBook_CreateError() {
 	suite.mockRepo.AssertExpectations(suite.T())
 }
 
+func (suite *ServiceTestSuite) TestCreateBook_DuplicateOnInsert() {
+	authorID := uuid.New()
+	req := &CreateBookRequest{
+		AuthorID: authorID,
+		Name:     "Test Book",
+		ISBN:     "978-0-7475-3269-9",
+	}
+
+	expectedAuthor := &author.Author{
+		BaseModel: models.BaseModel{ID: authorID},
+		PenName:   "Test Author",
+		BirthYear: 1990,
+	}
+
+	// Another request inserts the same ISBN between the check and the insert.
+	suite.mockAuthorService.On("GetAuthorByID", mock.Anything, authorID).Return(expectedAuthor, dto.Success)
+	suite.mockRepo.On("GetByISBN", mock.Anything, NormalizeISBN(req.ISBN)).Return((*Book)(nil), nil)
+	suite.mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*book.Book")).Return(gorm.ErrDuplicatedKey)
+
+	book, code := suite.service.CreateBook(suite.ctx, req)
+
+	suite.Equal(dto.BookAlreadyExists, code)
+	suite.Nil(book)
+	suite.mockAuthorService.AssertExpectations(suite.T())
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *ServiceTestSuite) TestCreateBook_AuthorDeletedOnInsert() {
+	authorID := uuid.New()
+	req := &CreateBookRequest{
+		AuthorID: authorID,
+		Name:     "Test Book",
+		ISBN:     "978-0-7475-3269-9",
+	}
+
+	expectedAuthor := &author.Author{
+		BaseModel: models.BaseModel{ID: authorID},
+		PenName:   "Test Author",
+		BirthYear: 1990,
+	}
+
+	suite.mockAuthorService.On("GetAuthorByID", mock.Anything, authorID).Return(expectedAuthor, dto.Success)
+	suite.mockRepo.On("GetByISBN", mock.Anything, NormalizeISBN(req.ISBN)).Return((*Book)(nil), nil)
+	suite.mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*book.Book")).Return(gorm.ErrForeignKeyViolated)
+
+	book, code := suite.service.CreateBook(suite.ctx, req)
+
+	suite.Equal(dto.AuthorNotFound, code)
+	suite.Nil(book)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
 func (suite *ServiceTestSuite) TestGetBookByID_Success() {
 	bookID := uuid.New()
 	authorID := uuid.New()
@@ -310,7 +330,7 @@ func (suite *ServiceTestSuite) TestGetBookByID_Success() {
 		},
 	}
 
-	suite.mockRepo.On("GetByID", suite.ctx, bookID).Return(expectedBook, nil)
+	suite.mockRepo.On("GetByID", mock.Anything, bookID).Return(expectedBook, nil)
 
 	book, code := suite.service.GetBookByID(suite.ctx, bookID)
 
@@ -334,7 +354,7 @@ func (suite *ServiceTestSuite) TestGetBookByID_Success_WithoutAuthor() {
 		Author:    nil,
 	}
 
-	suite.mockRepo.On("GetByID", suite.ctx, bookID).Return(expectedBook, nil)
+	suite.mockRepo.On("GetByID", mock.Anything, bookID).Return(expectedBook, nil)
 
 	book, code := suite.service.GetBookByID(suite.ctx, bookID)
 
@@ -349,7 +369,7 @@ func (suite *ServiceTestSuite) TestGetBookByID_Success_WithoutAuthor() {
 func (suite *ServiceTestSuite) TestGetBookByID_NotFound() {
 	bookID := uuid.New()
 
-	suite.mockRepo.On("GetByID", suite.ctx, bookID).Return((*Book)(nil), nil)
+	suite.mockRepo.On("GetByID", mock.Anything, bookID).Return((*Book)(nil), nil)
 
 	book, code := suite.service.GetBookByID(suite.ctx, bookID)
 
@@ -361,7 +381,7 @@ func (suite *ServiceTestSuite) TestGetBookByID_NotFound() {
 func (suite *ServiceTestSuite) TestGetBookByID_GetByIDError() {
 	bookID := uuid.New()
 
-	suite.mockRepo.On("GetByID", suite.ctx, bookID).Return((*Book)(nil), errors.New("database error"))
+	suite.mockRepo.On("GetByID", mock.Anything, bookID).Return((*Book)(nil), errors.New("database error"))
 
 	book, code := suite.service.GetBookByID(suite.ctx, bookID)
 
@@ -370,6 +390,43 @@ func (suite *ServiceTestSuite) TestGetBookByID_GetByIDError() {
 	suite.mockRepo.AssertExpectations(suite.T())
 }
 
+func (suite *ServiceTestSuite) TestGetBookByISBN_Success() {
+	expectedBook := &Book{
+		BaseModel: models.BaseModel{ID: uuid.New()},
+		AuthorID:  uuid.New(),
+		Name:      "Test Book",
+		ISBN:      "9780134190440",
+	}
+
+	suite.mockRepo.On("GetByISBN", mock.Anything, "9780134190440").Return(expectedBook, nil)
+
+	book, code := suite.service.GetBookByISBN(suite.ctx, "9780134190440")
+
+	suite.Equal(dto.Success, code)
+	suite.Equal(expectedBook, book)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *ServiceTestSuite) TestGetBookByISBN_NotFound() {
+	suite.mockRepo.On("GetByISBN", mock.Anything, "9780134190440").Return((*Book)(nil), nil)
+
+	book, code := suite.service.GetBookByISBN(suite.ctx, "9780134190440")
+
+	suite.Equal(dto.BookNotFound, code)
+	suite.Nil(book)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *ServiceTestSuite) TestGetBookByISBN_GetByISBNError() {
+	suite.mockRepo.On("GetByISBN", mock.Anything, "9780134190440").Return((*Book)(nil), errors.New("database error"))
+
+	book, code := suite.service.GetBookByISBN(suite.ctx, "9780134190440")
+
+	suite.Equal(dto.InternalError, code)
+	suite.Nil(book)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
 func (suite *ServiceTestSuite) TestGetAllBooks_Success() {
 	pagination := &pkgDto.PaginationRequest{Page: 1, PageSize: 5}
 	expectedBooks := &pkgDto.PaginationDataResponse[Book]{
@@ -403,7 +460,7 @@ func (suite *ServiceTestSuite) TestGetAllBooks_Success() {
 		},
 	}
 
-	suite.mockRepo.On("GetAll", suite.ctx, pagination).Return(expectedBooks, nil)
+	suite.mockRepo.On("GetAll", mock.Anything, pagination).Return(expectedBooks, nil)
 
 	books, code := suite.service.GetAllBooks(suite.ctx, pagination)
 
@@ -428,7 +485,7 @@ func (suite *ServiceTestSuite) TestGetAllBooks_EmptyResult() {
 		},
 	}
 
-	suite.mockRepo.On("GetAll", suite.ctx, pagination).Return(expectedBooks, nil)
+	suite.mockRepo.On("GetAll", mock.Anything, pagination).Return(expectedBooks, nil)
 
 	books, code := suite.service.GetAllBooks(suite.ctx, pagination)
 
@@ -442,7 +499,7 @@ func (suite *ServiceTestSuite) TestGetAllBooks_EmptyResult() {
 func (suite *ServiceTestSuite) TestGetAllBooks_GetAllError() {
 	pagination := &pkgDto.PaginationRequest{Page: 1, PageSize: 10}
 
-	suite.mockRepo.On("GetAll", suite.ctx, pagination).Return((*pkgDto.PaginationDataResponse[Book])(nil), errors.New("database error"))
+	suite.mockRepo.On("GetAll", mock.Anything, pagination).Return((*pkgDto.PaginationDataResponse[Book])(nil), errors.New("database error"))
 
 	books, code := suite.service.GetAllBooks(suite.ctx, pagination)
 
@@ -477,7 +534,7 @@ func (suite *ServiceTestSuite) TestGetBooksByAuthorID_Success() {
 		},
 	}
 
-	suite.mockRepo.On("GetByAuthorID", suite.ctx, authorID, pagination).Return(expectedBooks, nil)
+	suite.mockRepo.On("GetByAuthorID", mock.Anything, authorID, pagination).Return(expectedBooks, nil)
 
 	books, code := suite.service.GetBooksByAuthorID(suite.ctx, authorID, pagination)
 
@@ -501,7 +558,7 @@ func (suite *ServiceTestSuite) TestGetBooksByAuthorID_EmptyResult() {
 		},
 	}
 
-	suite.mockRepo.On("GetByAuthorID", suite.ctx, authorID, pagination).Return(expectedBooks, nil)
+	suite.mockRepo.On("GetByAuthorID", mock.Anything, authorID, pagination).Return(expectedBooks, nil)
 
 	books, code := suite.service.GetBooksByAuthorID(suite.ctx, authorID, pagination)
 
@@ -516,7 +573,7 @@ func (suite *ServiceTestSuite) TestGetBooksByAuthorID_GetByAuthorIDError() {
 	authorID := uuid.New()
 	pagination := &pkgDto.PaginationRequest{Page: 1, PageSize: 10}
 
-	suite.mockRepo.On("GetByAuthorID", suite.ctx, authorID, pagination).Return((*pkgDto.PaginationDataResponse[Book])(nil), errors.New("database error"))
+	suite.mockRepo.On("GetByAuthorID", mock.Anything, authorID, pagination).Return((*pkgDto.PaginationDataResponse[Book])(nil), errors.New("database error"))
 
 	books, code := suite.service.GetBooksByAuthorID(suite.ctx, authorID, pagination)
 
@@ -547,9 +604,10 @@ func (suite *ServiceTestSuite) TestUpdateBook_Success() {
 		BirthYear: 1990,
 	}
 
-	suite.mockRepo.On("GetByID", suite.ctx, bookID).Return(existingBook, nil)
-	suite.mockAuthorService.On("GetAuthorByID", suite.ctx, authorID).Return(expectedAuthor, dto.Success)
-	suite.mockRepo.On("Update", suite.ctx, bookID, mock.AnythingOfType("*book.Book")).Return(nil)
+	suite.mockRepo.On("GetByID", mock.Anything, bookID).Return(existingBook, nil)
+	suite.mockAuthorService.On("GetAuthorByID", mock.Anything, authorID).Return(expectedAuthor, dto.Success)
+	suite.mockRepo.On("GetByISBN", mock.Anything, NormalizeISBN(req.ISBN)).Return((*Book)(nil), nil)
+	suite.mockRepo.On("Update", mock.Anything, bookID, mock.AnythingOfType("*book.Book")).Return(nil)
 
 	code := suite.service.UpdateBook(suite.ctx, bookID, req)
 
@@ -567,12 +625,13 @@ func (suite *ServiceTestSuite) TestUpdateBook_BookNotFound() {
 		ISBN:     "978-0-7475-3269-9",
 	}
 
-	suite.mockRepo.On("GetByID", suite.ctx, bookID).Return((*Book)(nil), nil)
+	suite.mockRepo.On("GetByID", mock.Anything, bookID).Return((*Book)(nil), nil)
 
 	code := suite.service.UpdateBook(suite.ctx, bookID, req)
 
 	suite.Equal(dto.BookNotFound, code)
 	suite.mockRepo.AssertExpectations(suite.T())
+	suite.mockAuthorService.AssertNotCalled(suite.T(), "GetAuthorByID", mock.Anything, mock.Anything)
 }
 
 func (suite *ServiceTestSuite) TestUpdateBook_GetByIDError() {
@@ -584,12 +643,13 @@ func (suite *ServiceTestSuite) TestUpdateBook_GetByIDError() {
 		ISBN:     "978-0-7475-3269-9",
 	}
 
-	suite.mockRepo.On("GetByID", suite.ctx, bookID).Return((*Book)(nil), errors.New("database error"))
+	suite.mockRepo.On("GetByID", mock.Anything, bookID).Return((*Book)(nil), errors.New("database error"))
 
 	code := suite.service.UpdateBook(suite.ctx, bookID, req)
 
 	suite.Equal(dto.InternalError, code)
 	suite.mockRepo.AssertExpectations(suite.T())
+	suite.mockAuthorService.AssertNotCalled(suite.T(), "GetAuthorByID", mock.Anything, mock.Anything)
 }
 
 func (suite *ServiceTestSuite) TestUpdateBook_AuthorNotFound() {
@@ -608,13 +668,14 @@ func (suite *ServiceTestSuite) TestUpdateBook_AuthorNotFound() {
 		ISBN:      "1234567890123",
 	}
 
-	suite.mockRepo.On("GetByID", suite.ctx, bookID).Return(existingBook, nil)
-	suite.mockAuthorService.On("GetAuthorByID", suite.ctx, authorID).Return((*author.Author)(nil), dto.AuthorNotFound)
+	suite.mockRepo.On("GetByID", mock.Anything, bookID).Return(existingBook, nil)
+	suite.mockAuthorService.On("GetAuthorByID", mock.Anything, authorID).Return((*author.Author)(nil), dto.AuthorNotFound)
 
 	code := suite.service.UpdateBook(suite.ctx, bookID, req)
 
 	suite.Equal(dto.AuthorNotFound, code)
 	suite.mockRepo.AssertExpectations(suite.T())
+	suite.mockRepo.AssertNotCalled(suite.T(), "Update", mock.Anything, mock.Anything, mock.Anything)
 	suite.mockAuthorService.AssertExpectations(suite.T())
 }
 
@@ -634,13 +695,14 @@ func (suite *ServiceTestSuite) TestUpdateBook_GetAuthorByIDError() {
 		ISBN:      "1234567890123",
 	}
 
-	suite.mockRepo.On("GetByID", suite.ctx, bookID).Return(existingBook, nil)
-	suite.mockAuthorService.On("GetAuthorByID", suite.ctx, authorID).Return((*author.Author)(nil), dto.InternalError)
+	suite.mockRepo.On("GetByID", mock.Anything, bookID).Return(existingBook, nil)
+	suite.mockAuthorService.On("GetAuthorByID", mock.Anything, authorID).Return((*author.Author)(nil), dto.InternalError)
 
 	code := suite.service.UpdateBook(suite.ctx, bookID, req)
 
 	suite.Equal(dto.InternalError, code)
 	suite.mockRepo.AssertExpectations(suite.T())
+	suite.mockRepo.AssertNotCalled(suite.T(), "Update", mock.Anything, mock.Anything, mock.Anything)
 	suite.mockAuthorService.AssertExpectations(suite.T())
 }
 
@@ -666,9 +728,10 @@ func (suite *ServiceTestSuite) TestUpdateBook_UpdateError() {
 		BirthYear: 1990,
 	}
 
-	suite.mockRepo.On("GetByID", suite.ctx, bookID).Return(existingBook, nil)
-	suite.mockAuthorService.On("GetAuthorByID", suite.ctx, authorID).Return(expectedAuthor, dto.Success)
-	suite.mockRepo.On("Update", suite.ctx, bookID, mock.AnythingOfType("*book.Book")).Return(errors.New("database error"))
+	suite.mockRepo.On("GetByID", mock.Anything, bookID).Return(existingBook, nil)
+	suite.mockAuthorService.On("GetAuthorByID", mock.Anything, authorID).Return(expectedAuthor, dto.Success)
+	suite.mockRepo.On("GetByISBN", mock.Anything, NormalizeISBN(req.ISBN)).Return((*Book)(nil), nil)
+	suite.mockRepo.On("Update", mock.Anything, bookID, mock.AnythingOfType("*book.Book")).Return(errors.New("database error"))
 
 	code := suite.service.UpdateBook(suite.ctx, bookID, req)
 
@@ -677,10 +740,116 @@ func (suite *ServiceTestSuite) TestUpdateBook_UpdateError() {
 	suite.mockAuthorService.AssertExpectations(suite.T())
 }
 
+func (suite *ServiceTestSuite) TestUpdateBook_BookAlreadyExists() {
+	bookID := uuid.New()
+	authorID := uuid.New()
+	req := &UpdateBookRequest{
+		AuthorID: authorID,
+		Name:     "Updated Book",
+		ISBN:     "978-0-7475-3269-9",
+	}
+
+	existingBook := &Book{
+		BaseModel: models.BaseModel{ID: bookID},
+		AuthorID:  authorID,
+		Name:      "Original Book",
+		ISBN:      "1234567890123",
+	}
+
+	otherBook := &Book{
+		BaseModel: models.BaseModel{ID: uuid.New()},
+		AuthorID:  authorID,
+		Name:      "Other Book",
+		ISBN:      req.ISBN,
+	}
+
+	expectedAuthor := &author.Author{
+		BaseModel: models.BaseModel{ID: authorID},
+		PenName:   "Test Author",
+		BirthYear: 1990,
+	}
+
+	suite.mockRepo.On("GetByID", mock.Anything, bookID).Return(existingBook, nil)
+	suite.mockAuthorService.On("GetAuthorByID", mock.Anything, authorID).Return(expectedAuthor, dto.Success)
+	suite.mockRepo.On("GetByISBN", mock.Anything, NormalizeISBN(req.ISBN)).Return(otherBook, nil)
+
+	code := suite.service.UpdateBook(suite.ctx, bookID, req)
+
+	suite.Equal(dto.BookAlreadyExists, code)
+	suite.mockRepo.AssertExpectations(suite.T())
+	suite.mockRepo.AssertNotCalled(suite.T(), "Update", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *ServiceTestSuite) TestUpdateBook_KeepsOwnISBN() {
+	bookID := uuid.New()
+	authorID := uuid.New()
+	req := &UpdateBookRequest{
+		AuthorID: authorID,
+		Name:     "Updated Book",
+		ISBN:     "978-0-7475-3269-9",
+	}
+
+	existingBook := &Book{
+		BaseModel: models.BaseModel{ID: bookID},
+		AuthorID:  authorID,
+		Name:      "Original Book",
+		ISBN:      req.ISBN,
+	}
+
+	expectedAuthor := &author.Author{
+		BaseModel: models.BaseModel{ID: authorID},
+		PenName:   "Test Author",
+		BirthYear: 1990,
+	}
+
+	suite.mockRepo.On("GetByID", mock.Anything, bookID).Return(existingBook, nil)
+	suite.mockAuthorService.On("GetAuthorByID", mock.Anything, authorID).Return(expectedAuthor, dto.Success)
+	suite.mockRepo.On("GetByISBN", mock.Anything, NormalizeISBN(req.ISBN)).Return(existingBook, nil)
+	suite.mockRepo.On("Update", mock.Anything, bookID, mock.AnythingOfType("*book.Book")).Return(nil)
+
+	code := suite.service.UpdateBook(suite.ctx, bookID, req)
+
+	suite.Equal(dto.Success, code)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *ServiceTestSuite) TestUpdateBook_DuplicateOnUpdate() {
+	bookID := uuid.New()
+	authorID := uuid.New()
+	req := &UpdateBookRequest{
+		AuthorID: authorID,
+		Name:     "Updated Book",
+		ISBN:     "978-0-7475-3269-9",
+	}
+
+	existingBook := &Book{
+		BaseModel: models.BaseModel{ID: bookID},
+		AuthorID:  authorID,
+		Name:      "Original Book",
+		ISBN:      "1234567890123",
+	}
+
+	expectedAuthor := &author.Author{
+		BaseModel: models.BaseModel{ID: authorID},
+		PenName:   "Test Author",
+		BirthYear: 1990,
+	}
+
+	suite.mockRepo.On("GetByID", mock.Anything, bookID).Return(existingBook, nil)
+	suite.mockAuthorService.On("GetAuthorByID", mock.Anything, authorID).Return(expectedAuthor, dto.Success)
+	suite.mockRepo.On("GetByISBN", mock.Anything, NormalizeISBN(req.ISBN)).Return((*Book)(nil), nil)
+	suite.mockRepo.On("Update", mock.Anything, bookID, mock.AnythingOfType("*book.Book")).Return(gorm.ErrDuplicatedKey)
+
+	code := suite.service.UpdateBook(suite.ctx, bookID, req)
+
+	suite.Equal(dto.BookAlreadyExists, code)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
 func (suite *ServiceTestSuite) TestDeleteBook_Success() {
 	bookID := uuid.New()
 
-	suite.mockRepo.On("Delete", suite.ctx, bookID).Return(nil)
+	suite.mockRepo.On("Delete", mock.Anything, bookID).Return(nil)
 
 	code := suite.service.DeleteBook(suite.ctx, bookID)
 
@@ -691,7 +860,7 @@ func (suite *ServiceTestSuite) TestDeleteBook_Success() {
 func (suite *ServiceTestSuite) TestDeleteBook_DeleteError() {
 	bookID := uuid.New()
 
-	suite.mockRepo.On("Delete", suite.ctx, bookID).Return(errors.New("database error"))
+	suite.mockRepo.On("Delete", mock.Anything, bookID).Return(errors.New("database error"))
 
 	code := suite.service.DeleteBook(suite.ctx, bookID)
 
@@ -702,3 +871,114 @@ func (suite *ServiceTestSuite) TestDeleteBook_DeleteError() {
 func TestServiceTestSuite(t *testing.T) {
 	suite.Run(t, new(ServiceTestSuite))
 }
+
+// MemoryServiceTestSuite runs the book and author services against the
+// in-memory repositories, so rollbacks, unique ISBNs and the author preload
+// behave as they do in SQL.
+type MemoryServiceTestSuite struct {
+	suite.Suite
+	service       IService
+	authorService author.IService
+	ctx           context.Context
+}
+
+func (suite *MemoryServiceTestSuite) SetupTest() {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	transactionManager := repoPkg.NewMemoryTransactionManager()
+	authorRepo := author.NewMemoryRepository(transactionManager)
+	bookRepo := NewMemoryRepository(transactionManager, authorRepo)
+
+	suite.authorService = author.NewService(authorRepo, transactionManager, logger)
+	suite.service = NewService(bookRepo, suite.authorService, transactionManager, logger)
+	suite.ctx = context.Background()
+}
+
+func (suite *MemoryServiceTestSuite) createAuthor(penName string) *author.Author {
+	created, code := suite.authorService.CreateAuthor(suite.ctx, &author.CreateAuthorRequest{PenName: penName, BirthYear: 1949})
+	suite.Require().Equal(dto.Success, code)
+	return created
+}
+
+func (suite *MemoryServiceTestSuite) TestCreateAndGetBook() {
+	writer := suite.createAuthor("Haruki Murakami")
+
+	created, code := suite.service.CreateBook(suite.ctx, &CreateBookRequest{AuthorID: writer.ID, Name: "Norwegian Wood", ISBN: "9780375704024"})
+	suite.Equal(dto.Success, code)
+
+	found, code := suite.service.GetBookByID(suite.ctx, created.ID)
+	suite.Equal(dto.Success, code)
+	suite.Equal("Norwegian Wood", found.Name)
+	suite.Require().NotNil(found.Author)
+	suite.Equal("Haruki Murakami", found.Author.PenName)
+
+	books, code := suite.service.GetBooksByAuthorID(suite.ctx, writer.ID, &pkgDto.PaginationRequest{Page: 1, PageSize: 10})
+	suite.Equal(dto.Success, code)
+	suite.Equal(int64(1), books.Pagination.TotalItems)
+}
+
+func (suite *MemoryServiceTestSuite) TestCreateBook_AuthorNotFound() {
+	book, code := suite.service.CreateBook(suite.ctx, &CreateBookRequest{AuthorID: uuid.New(), Name: "Orphan", ISBN: "9780375704024"})
+
+	suite.Equal(dto.AuthorNotFound, code)
+	suite.Nil(book)
+}
+
+func (suite *MemoryServiceTestSuite) TestCreateBook_ISBNHeldByDeletedBook() {
+	writer := suite.createAuthor("Haruki Murakami")
+	created, _ := suite.service.CreateBook(suite.ctx, &CreateBookRequest{AuthorID: writer.ID, Name: "Norwegian Wood", ISBN: "9780375704024"})
+	suite.Equal(dto.Success, suite.service.DeleteBook(suite.ctx, created.ID))
+
+	// The soft-deleted row is hidden from the ISBN check but still holds the
+	// unique ISBN, so the insert itself is rejected.
+	book, code := suite.service.CreateBook(suite.ctx, &CreateBookRequest{AuthorID: writer.ID, Name: "Again", ISBN: "9780375704024"})
+
+	suite.Equal(dto.BookAlreadyExists, code)
+	suite.Nil(book)
+}
+
+func (suite *MemoryServiceTestSuite) TestUpdateBook_BookAlreadyExists() {
+	writer := suite.createAuthor("Haruki Murakami")
+	first, _ := suite.service.CreateBook(suite.ctx, &CreateBookRequest{AuthorID: writer.ID, Name: "Norwegian Wood", ISBN: "9780375704024"})
+	second, _ := suite.service.CreateBook(suite.ctx, &CreateBookRequest{AuthorID: writer.ID, Name: "Kafka on the Shore", ISBN: "9781400079278"})
+
+	code := suite.service.UpdateBook(suite.ctx, second.ID, &UpdateBookRequest{AuthorID: writer.ID, Name: "Renamed", ISBN: first.ISBN})
+	suite.Equal(dto.BookAlreadyExists, code)
+
+	found, _ := suite.service.GetBookByID(suite.ctx, second.ID)
+	suite.Equal("Kafka on the Shore", found.Name)
+	suite.Equal("9781400079278", found.ISBN)
+
+	code = suite.service.UpdateBook(suite.ctx, second.ID, &UpdateBookRequest{AuthorID: writer.ID, Name: "Kafka on the Shore", ISBN: second.ISBN})
+	suite.Equal(dto.Success, code)
+}
+
+func (suite *MemoryServiceTestSuite) TestCreateBook_SameISBNWithAndWithoutHyphens() {
+	writer := suite.createAuthor("Haruki Murakami")
+	created, code := suite.service.CreateBook(suite.ctx, &CreateBookRequest{AuthorID: writer.ID, Name: "Norwegian Wood", ISBN: "978-0-375-70402-4"})
+	suite.Require().Equal(dto.Success, code)
+	suite.Equal("9780375704024", created.ISBN)
+
+	book, code := suite.service.CreateBook(suite.ctx, &CreateBookRequest{AuthorID: writer.ID, Name: "Copy", ISBN: "9780375704024"})
+	suite.Equal(dto.BookAlreadyExists, code, "hyphenated and bare forms are the same book")
+	suite.Nil(book)
+
+	found, code := suite.service.GetBookByISBN(suite.ctx, "978-0-375-70402-4")
+	suite.Equal(dto.Success, code)
+	suite.Equal(created.ID, found.ID)
+}
+
+func (suite *MemoryServiceTestSuite) TestDeleteBook() {
+	writer := suite.createAuthor("Haruki Murakami")
+	created, _ := suite.service.CreateBook(suite.ctx, &CreateBookRequest{AuthorID: writer.ID, Name: "Norwegian Wood", ISBN: "9780375704024"})
+
+	suite.Equal(dto.Success, suite.service.DeleteBook(suite.ctx, created.ID))
+
+	found, code := suite.service.GetBookByID(suite.ctx, created.ID)
+	suite.Equal(dto.BookNotFound, code)
+	suite.Nil(found)
+}
+
+func TestMemoryServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(MemoryServiceTestSuite))
+}
@@ -0,0 +1,56 @@
+package book
+
+import (
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	pkgValidator "github.com/sirawatc/simple-gin-crud/pkg/validator"
+)
+
+// ISBN13StrictRule accepts ISBN-13s with a 978 or 979 prefix and a valid
+// check digit. Hyphens between groups are allowed.
+var ISBN13StrictRule = pkgValidator.Rule{
+	Tag: "isbn13strict",
+	Func: func(fl validator.FieldLevel) bool {
+		return isISBN13Strict(fl.Field().String())
+	},
+	Messages: map[string]string{
+		"en": "{0} must be a valid ISBN-13 starting with 978 or 979",
+		"th": "{0} ต้องเป็น ISBN-13 ที่ถูกต้องและขึ้นต้นด้วย 978 หรือ 979",
+	},
+}
+
+// ValidationRules returns the book rules to register on the shared
+// validator so struct tags can refer to them.
+func ValidationRules() []pkgValidator.Rule {
+	return []pkgValidator.Rule{ISBN13StrictRule}
+}
+
+// NormalizeISBN removes the hyphens and spaces an ISBN may be written with,
+// so each book is stored and looked up under a single form.
+func NormalizeISBN(isbn string) string {
+	return strings.NewReplacer("-", "", " ", "").Replace(isbn)
+}
+
+func isISBN13Strict(isbn string) bool {
+	if strings.HasPrefix(isbn, "-") || strings.HasSuffix(isbn, "-") || strings.Contains(isbn, "--") {
+		return false
+	}
+	digits := strings.ReplaceAll(isbn, "-", "")
+	if len(digits) != 13 || (!strings.HasPrefix(digits, "978") && !strings.HasPrefix(digits, "979")) {
+		return false
+	}
+
+	sum := 0
+	for i, r := range digits {
+		if r < '0' || r > '9' {
+			return false
+		}
+		digit := int(r - '0')
+		if i%2 == 1 {
+			digit *= 3
+		}
+		sum += digit
+	}
+	return sum%10 == 0
+}
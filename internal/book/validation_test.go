@@ -0,0 +1,39 @@
+package book
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsISBN13Strict(t *testing.T) {
+	tests := []struct {
+		isbn     string
+		expected bool
+	}{
+		{isbn: "9780747532699", expected: true},
+		{isbn: "978-0-7475-3269-9", expected: true},
+		{isbn: "9791032305690", expected: true},
+		{isbn: "9780747532698", expected: false},
+		{isbn: "1234567890128", expected: false},
+		{isbn: "074753269X", expected: false},
+		{isbn: "978074753269", expected: false},
+		{isbn: "978-0-7475-3269-", expected: false},
+		{isbn: "-9780747532699", expected: false},
+		{isbn: "978--0747532699", expected: false},
+		{isbn: "978 0747532699", expected: false},
+		{isbn: "", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.isbn, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isISBN13Strict(tt.isbn))
+		})
+	}
+}
+
+func TestNormalizeISBN(t *testing.T) {
+	assert.Equal(t, "9780747532699", NormalizeISBN("978-0-7475-3269-9"))
+	assert.Equal(t, "9780747532699", NormalizeISBN("978 0 7475 3269 9"))
+	assert.Equal(t, "9780747532699", NormalizeISBN("9780747532699"))
+}
@@ -0,0 +1,94 @@
+package seed
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+)
+
+var (
+	firstNames = []string{
+		"Haruki", "Chimamanda", "Kazuo", "Ursula", "Gabriel", "Margaret", "Salman", "Toni",
+		"Jhumpa", "Orhan", "Isabel", "Neil", "Zadie", "Italo", "Octavia", "Banana",
+		"Prabda", "Chart", "Duanwad", "Sidaoruang", "Arundhati", "Yoko", "Ken", "Elena",
+	}
+	lastNames = []string{
+		"Murakami", "Adichie", "Ishiguro", "Le Guin", "Marquez", "Atwood", "Rushdie", "Morrison",
+		"Lahiri", "Pamuk", "Allende", "Gaiman", "Smith", "Calvino", "Butler", "Yoshimoto",
+		"Yoon", "Korbjitti", "Pimwana", "Kaewsai", "Roy", "Ogawa", "Liu", "Ferrante",
+	}
+	titleAdjectives = []string{
+		"Silent", "Hidden", "Last", "Forgotten", "Golden", "Broken", "Midnight", "Endless",
+		"Distant", "Burning", "Quiet", "Crimson", "Paper", "Wandering", "Glass", "Northern",
+	}
+	titleNouns = []string{
+		"River", "Garden", "Kingdom", "Orchard", "Letters", "Harbor", "Mirror", "Monsoon",
+		"Lantern", "Archive", "Island", "Season", "Temple", "Cartographer", "Tide", "Library",
+	}
+)
+
+// Fake builds a fixture of random but realistic authors and books. Pen names
+// and ISBNs are unique within the fixture and every ISBN carries a valid
+// ISBN-13 check digit.
+func Fake(r *rand.Rand, authors int, booksPerAuthor int) *Fixture {
+	fixture := &Fixture{}
+	penNames := map[string]bool{}
+	isbns := map[string]bool{}
+
+	for i := 0; i < authors; i++ {
+		penName := fmt.Sprintf("%s %s", pick(r, firstNames), pick(r, lastNames))
+		for suffix := 2; penNames[penName]; suffix++ {
+			penName = fmt.Sprintf("%s %s %d", pick(r, firstNames), pick(r, lastNames), suffix)
+		}
+		penNames[penName] = true
+
+		fixture.Authors = append(fixture.Authors, AuthorFixture{
+			PenName:   penName,
+			BirthYear: 1900 + r.IntN(105),
+		})
+
+		for j := 0; j < booksPerAuthor; j++ {
+			isbn := FakeISBN13(r)
+			for isbns[isbn] {
+				isbn = FakeISBN13(r)
+			}
+			isbns[isbn] = true
+
+			fixture.Books = append(fixture.Books, BookFixture{
+				AuthorPenName: penName,
+				Name:          fmt.Sprintf("The %s %s", pick(r, titleAdjectives), pick(r, titleNouns)),
+				ISBN:          isbn,
+			})
+		}
+	}
+
+	return fixture
+}
+
+func FakeISBN13(r *rand.Rand) string {
+	var digits strings.Builder
+	digits.WriteString("978")
+	for i := 0; i < 9; i++ {
+		digits.WriteByte(byte('0' + r.IntN(10)))
+	}
+	isbn := digits.String()
+	return isbn + string(ISBN13CheckDigit(isbn))
+}
+
+// ISBN13CheckDigit computes the check digit for the first 12 digits of an
+// ISBN-13, weighting digits alternately by 1 and 3.
+func ISBN13CheckDigit(digits string) byte {
+	sum := 0
+	for i := 0; i < 12; i++ {
+		digit := int(digits[i] - '0')
+		if i%2 == 1 {
+			digit *= 3
+		}
+		sum += digit
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+func pick(r *rand.Rand, items []string) string {
+	return items[r.IntN(len(items))]
+}
@@ -0,0 +1,63 @@
+package seed
+
+import (
+	"context"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/sirawatc/simple-gin-crud/internal/author"
+	"github.com/sirawatc/simple-gin-crud/internal/book"
+	"github.com/sirawatc/simple-gin-crud/pkg/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestISBN13CheckDigit(t *testing.T) {
+	tests := []struct {
+		isbn     string
+		expected byte
+	}{
+		{isbn: "9780375704024", expected: '4'},
+		{isbn: "9780547773742", expected: '2'},
+		{isbn: "9781400078776", expected: '6'},
+		{isbn: "9780306406157", expected: '7'},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.isbn, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ISBN13CheckDigit(tt.isbn))
+		})
+	}
+}
+
+func TestFake(t *testing.T) {
+	fixture := Fake(rand.New(rand.NewPCG(1, 1)), 50, 3)
+
+	assert.Len(t, fixture.Authors, 50)
+	assert.Len(t, fixture.Books, 150)
+
+	v := validator.NewValidator()
+	v.MustRegister(author.ValidationRules()...)
+	v.MustRegister(book.ValidationRules()...)
+	penNames := map[string]bool{}
+	for _, item := range fixture.Authors {
+		assert.False(t, penNames[item.PenName], "duplicate pen name %q", item.PenName)
+		penNames[item.PenName] = true
+		assert.Nil(t, v.Validate(context.Background(), &author.CreateAuthorRequest{PenName: item.PenName, BirthYear: item.BirthYear}))
+	}
+
+	isbns := map[string]bool{}
+	for _, item := range fixture.Books {
+		assert.False(t, isbns[item.ISBN], "duplicate ISBN %q", item.ISBN)
+		isbns[item.ISBN] = true
+		assert.True(t, penNames[item.AuthorPenName])
+		assert.Nil(t, v.Validate(context.Background(), &book.CreateBookRequest{AuthorID: uuid.New(), Name: item.Name, ISBN: item.ISBN}))
+	}
+}
+
+func TestFake_Deterministic(t *testing.T) {
+	first := Fake(rand.New(rand.NewPCG(42, 42)), 5, 2)
+	second := Fake(rand.New(rand.NewPCG(42, 42)), 5, 2)
+
+	assert.Equal(t, first, second)
+}
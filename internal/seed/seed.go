@@ -0,0 +1,185 @@
+package seed
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/sirawatc/simple-gin-crud/internal/author"
+	"github.com/sirawatc/simple-gin-crud/internal/book"
+	"github.com/sirawatc/simple-gin-crud/internal/shared/dto"
+	"github.com/sirawatc/simple-gin-crud/pkg/validator"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+)
+
+type Fixture struct {
+	Authors []AuthorFixture `json:"authors" yaml:"authors"`
+	Books   []BookFixture   `json:"books" yaml:"books"`
+}
+
+type AuthorFixture struct {
+	PenName   string `json:"penName" yaml:"penName"`
+	BirthYear int    `json:"birthYear" yaml:"birthYear"`
+}
+
+// BookFixture references its author by pen name so fixtures stay readable
+// and do not depend on generated IDs.
+type BookFixture struct {
+	AuthorPenName string `json:"authorPenName" yaml:"authorPenName"`
+	Name          string `json:"name" yaml:"name"`
+	ISBN          string `json:"isbn" yaml:"isbn"`
+}
+
+type Result struct {
+	AuthorsCreated int
+	AuthorsUpdated int
+	BooksCreated   int
+	BooksUpdated   int
+}
+
+type seeder struct {
+	authorService author.IService
+	bookService   book.IService
+	validator     *validator.Validator
+	logger        *logrus.Logger
+	upsert        bool
+}
+
+func NewSeeder(authorService author.IService, bookService book.IService, logger *logrus.Logger, upsert bool) *seeder {
+	requestValidator := validator.NewValidator()
+	requestValidator.MustRegister(author.ValidationRules()...)
+	requestValidator.MustRegister(book.ValidationRules()...)
+
+	return &seeder{
+		authorService: authorService,
+		bookService:   bookService,
+		validator:     requestValidator,
+		logger:        logger,
+		upsert:        upsert,
+	}
+}
+
+func LoadFixture(path string) (*Fixture, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	fixture := &Fixture{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, fixture)
+	case ".json":
+		err = json.Unmarshal(content, fixture)
+	default:
+		return nil, fmt.Errorf("unsupported fixture format %q", filepath.Ext(path))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse fixture %s: %w", path, err)
+	}
+
+	return fixture, nil
+}
+
+func (s *seeder) Seed(ctx context.Context, fixture *Fixture) (*Result, error) {
+	result := &Result{}
+	authorIDs := map[string]uuid.UUID{}
+
+	for _, item := range fixture.Authors {
+		id, err := s.seedAuthor(ctx, item, result)
+		if err != nil {
+			return result, err
+		}
+		authorIDs[item.PenName] = id
+	}
+
+	for _, item := range fixture.Books {
+		authorID, ok := authorIDs[item.AuthorPenName]
+		if !ok {
+			existing, code := s.authorService.GetAuthorByPenName(ctx, item.AuthorPenName)
+			if code != dto.Success {
+				return result, fmt.Errorf("book %q references author %q: %s", item.ISBN, item.AuthorPenName, dto.CodeMessage[code])
+			}
+			authorID = existing.ID
+			authorIDs[item.AuthorPenName] = authorID
+		}
+
+		if err := s.seedBook(ctx, item, authorID, result); err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+func (s *seeder) seedAuthor(ctx context.Context, item AuthorFixture, result *Result) (uuid.UUID, error) {
+	logPrefix := "[Seeder#seedAuthor]"
+
+	req := &author.CreateAuthorRequest{PenName: item.PenName, BirthYear: item.BirthYear}
+	if errors := s.validator.Validate(ctx, req); errors != nil {
+		return uuid.Nil, fmt.Errorf("invalid author %q: %s", item.PenName, errors)
+	}
+
+	if s.upsert {
+		existing, code := s.authorService.GetAuthorByPenName(ctx, item.PenName)
+		switch code {
+		case dto.Success:
+			updateReq := &author.UpdateAuthorRequest{PenName: item.PenName, BirthYear: item.BirthYear}
+			if code := s.authorService.UpdateAuthor(ctx, existing.ID, updateReq); code != dto.Success {
+				return uuid.Nil, fmt.Errorf("failed to update author %q: %s", item.PenName, dto.CodeMessage[code])
+			}
+			s.logger.Debugf("%s Updated author %q", logPrefix, item.PenName)
+			result.AuthorsUpdated++
+			return existing.ID, nil
+		case dto.AuthorNotFound:
+		default:
+			return uuid.Nil, fmt.Errorf("failed to look up author %q: %s", item.PenName, dto.CodeMessage[code])
+		}
+	}
+
+	created, code := s.authorService.CreateAuthor(ctx, req)
+	if code != dto.Success {
+		return uuid.Nil, fmt.Errorf("failed to create author %q: %s", item.PenName, dto.CodeMessage[code])
+	}
+	s.logger.Debugf("%s Created author %q", logPrefix, item.PenName)
+	result.AuthorsCreated++
+	return created.ID, nil
+}
+
+func (s *seeder) seedBook(ctx context.Context, item BookFixture, authorID uuid.UUID, result *Result) error {
+	logPrefix := "[Seeder#seedBook]"
+
+	req := &book.CreateBookRequest{AuthorID: authorID, Name: item.Name, ISBN: item.ISBN}
+	if errors := s.validator.Validate(ctx, req); errors != nil {
+		return fmt.Errorf("invalid book %q: %s", item.ISBN, errors)
+	}
+
+	if s.upsert {
+		existing, code := s.bookService.GetBookByISBN(ctx, item.ISBN)
+		switch code {
+		case dto.Success:
+			updateReq := &book.UpdateBookRequest{AuthorID: authorID, Name: item.Name, ISBN: item.ISBN}
+			if code := s.bookService.UpdateBook(ctx, existing.ID, updateReq); code != dto.Success {
+				return fmt.Errorf("failed to update book %q: %s", item.ISBN, dto.CodeMessage[code])
+			}
+			s.logger.Debugf("%s Updated book %q", logPrefix, item.ISBN)
+			result.BooksUpdated++
+			return nil
+		case dto.BookNotFound:
+		default:
+			return fmt.Errorf("failed to look up book %q: %s", item.ISBN, dto.CodeMessage[code])
+		}
+	}
+
+	if _, code := s.bookService.CreateBook(ctx, req); code != dto.Success {
+		return fmt.Errorf("failed to create book %q: %s", item.ISBN, dto.CodeMessage[code])
+	}
+	s.logger.Debugf("%s Created book %q", logPrefix, item.ISBN)
+	result.BooksCreated++
+	return nil
+}
@@ -0,0 +1,275 @@
+package seed
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/sirawatc/simple-gin-crud/internal/author"
+	"github.com/sirawatc/simple-gin-crud/internal/book"
+	"github.com/sirawatc/simple-gin-crud/internal/shared/dto"
+	"github.com/sirawatc/simple-gin-crud/internal/shared/models"
+	pkgDto "github.com/sirawatc/simple-gin-crud/pkg/dto"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MockAuthorService struct {
+	mock.Mock
+}
+
+func (m *MockAuthorService) CreateAuthor(ctx context.Context, req *author.CreateAuthorRequest) (*author.Author, dto.Code) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(dto.Code)
+	}
+	return args.Get(0).(*author.Author), args.Get(1).(dto.Code)
+}
+
+func (m *MockAuthorService) GetAuthorByID(ctx context.Context, id uuid.UUID) (*author.Author, dto.Code) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(dto.Code)
+	}
+	return args.Get(0).(*author.Author), args.Get(1).(dto.Code)
+}
+
+func (m *MockAuthorService) GetAuthorByPenName(ctx context.Context, penName string) (*author.Author, dto.Code) {
+	args := m.Called(ctx, penName)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(dto.Code)
+	}
+	return args.Get(0).(*author.Author), args.Get(1).(dto.Code)
+}
+
+func (m *MockAuthorService) GetAllAuthors(ctx context.Context, pagination *pkgDto.PaginationRequest) (*pkgDto.PaginationDataResponse[author.Author], dto.Code) {
+	args := m.Called(ctx, pagination)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(dto.Code)
+	}
+	return args.Get(0).(*pkgDto.PaginationDataResponse[author.Author]), args.Get(1).(dto.Code)
+}
+
+func (m *MockAuthorService) UpdateAuthor(ctx context.Context, id uuid.UUID, req *author.UpdateAuthorRequest) dto.Code {
+	args := m.Called(ctx, id, req)
+	return args.Get(0).(dto.Code)
+}
+
+func (m *MockAuthorService) DeleteAuthor(ctx context.Context, id uuid.UUID) dto.Code {
+	args := m.Called(ctx, id)
+	return args.Get(0).(dto.Code)
+}
+
+type MockBookService struct {
+	mock.Mock
+}
+
+func (m *MockBookService) CreateBook(ctx context.Context, req *book.CreateBookRequest) (*book.Book, dto.Code) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(dto.Code)
+	}
+	return args.Get(0).(*book.Book), args.Get(1).(dto.Code)
+}
+
+func (m *MockBookService) GetBookByID(ctx context.Context, id uuid.UUID) (*book.Book, dto.Code) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(dto.Code)
+	}
+	return args.Get(0).(*book.Book), args.Get(1).(dto.Code)
+}
+
+func (m *MockBookService) GetBookByISBN(ctx context.Context, isbn string) (*book.Book, dto.Code) {
+	args := m.Called(ctx, isbn)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(dto.Code)
+	}
+	return args.Get(0).(*book.Book), args.Get(1).(dto.Code)
+}
+
+func (m *MockBookService) GetBooksByAuthorID(ctx context.Context, authorID uuid.UUID, pagination *pkgDto.PaginationRequest) (*pkgDto.PaginationDataResponse[book.Book], dto.Code) {
+	args := m.Called(ctx, authorID, pagination)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(dto.Code)
+	}
+	return args.Get(0).(*pkgDto.PaginationDataResponse[book.Book]), args.Get(1).(dto.Code)
+}
+
+func (m *MockBookService) GetAllBooks(ctx context.Context, pagination *pkgDto.PaginationRequest) (*pkgDto.PaginationDataResponse[book.Book], dto.Code) {
+	args := m.Called(ctx, pagination)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(dto.Code)
+	}
+	return args.Get(0).(*pkgDto.PaginationDataResponse[book.Book]), args.Get(1).(dto.Code)
+}
+
+func (m *MockBookService) UpdateBook(ctx context.Context, id uuid.UUID, req *book.UpdateBookRequest) dto.Code {
+	args := m.Called(ctx, id, req)
+	return args.Get(0).(dto.Code)
+}
+
+func (m *MockBookService) DeleteBook(ctx context.Context, id uuid.UUID) dto.Code {
+	args := m.Called(ctx, id)
+	return args.Get(0).(dto.Code)
+}
+
+type SeederTestSuite struct {
+	suite.Suite
+	mockAuthorService *MockAuthorService
+	mockBookService   *MockBookService
+	logger            *logrus.Logger
+	ctx               context.Context
+}
+
+func (suite *SeederTestSuite) SetupTest() {
+	suite.mockAuthorService = new(MockAuthorService)
+	suite.mockBookService = new(MockBookService)
+	suite.logger = logrus.New()
+	suite.logger.SetLevel(logrus.ErrorLevel)
+	suite.ctx = context.Background()
+}
+
+func (suite *SeederTestSuite) fixture() *Fixture {
+	return &Fixture{
+		Authors: []AuthorFixture{{PenName: "Haruki Murakami", BirthYear: 1949}},
+		Books:   []BookFixture{{AuthorPenName: "Haruki Murakami", Name: "Norwegian Wood", ISBN: "9780375704024"}},
+	}
+}
+
+func (suite *SeederTestSuite) TestSeed_Create() {
+	authorID := uuid.New()
+	suite.mockAuthorService.On("CreateAuthor", mock.Anything, &author.CreateAuthorRequest{PenName: "Haruki Murakami", BirthYear: 1949}).
+		Return(&author.Author{BaseModel: models.BaseModel{ID: authorID}}, dto.Success)
+	suite.mockBookService.On("CreateBook", mock.Anything, &book.CreateBookRequest{AuthorID: authorID, Name: "Norwegian Wood", ISBN: "9780375704024"}).
+		Return(&book.Book{}, dto.Success)
+
+	result, err := NewSeeder(suite.mockAuthorService, suite.mockBookService, suite.logger, false).Seed(suite.ctx, suite.fixture())
+
+	suite.NoError(err)
+	suite.Equal(&Result{AuthorsCreated: 1, BooksCreated: 1}, result)
+	suite.mockAuthorService.AssertExpectations(suite.T())
+	suite.mockBookService.AssertExpectations(suite.T())
+}
+
+func (suite *SeederTestSuite) TestSeed_CreateConflict() {
+	suite.mockAuthorService.On("CreateAuthor", mock.Anything, mock.Anything).Return(nil, dto.AuthorAlreadyExists)
+
+	result, err := NewSeeder(suite.mockAuthorService, suite.mockBookService, suite.logger, false).Seed(suite.ctx, suite.fixture())
+
+	suite.EqualError(err, `failed to create author "Haruki Murakami": `+dto.CodeMessage[dto.AuthorAlreadyExists])
+	suite.Equal(&Result{}, result)
+	suite.mockBookService.AssertNotCalled(suite.T(), "CreateBook", mock.Anything, mock.Anything)
+}
+
+func (suite *SeederTestSuite) TestSeed_UpsertUpdatesExisting() {
+	authorID := uuid.New()
+	bookID := uuid.New()
+	suite.mockAuthorService.On("GetAuthorByPenName", mock.Anything, "Haruki Murakami").
+		Return(&author.Author{BaseModel: models.BaseModel{ID: authorID}}, dto.Success)
+	suite.mockAuthorService.On("UpdateAuthor", mock.Anything, authorID, &author.UpdateAuthorRequest{PenName: "Haruki Murakami", BirthYear: 1949}).
+		Return(dto.Success)
+	suite.mockBookService.On("GetBookByISBN", mock.Anything, "9780375704024").
+		Return(&book.Book{BaseModel: models.BaseModel{ID: bookID}}, dto.Success)
+	suite.mockBookService.On("UpdateBook", mock.Anything, bookID, &book.UpdateBookRequest{AuthorID: authorID, Name: "Norwegian Wood", ISBN: "9780375704024"}).
+		Return(dto.Success)
+
+	result, err := NewSeeder(suite.mockAuthorService, suite.mockBookService, suite.logger, true).Seed(suite.ctx, suite.fixture())
+
+	suite.NoError(err)
+	suite.Equal(&Result{AuthorsUpdated: 1, BooksUpdated: 1}, result)
+	suite.mockAuthorService.AssertExpectations(suite.T())
+	suite.mockBookService.AssertExpectations(suite.T())
+}
+
+func (suite *SeederTestSuite) TestSeed_UpsertCreatesMissing() {
+	authorID := uuid.New()
+	suite.mockAuthorService.On("GetAuthorByPenName", mock.Anything, "Haruki Murakami").Return(nil, dto.AuthorNotFound)
+	suite.mockAuthorService.On("CreateAuthor", mock.Anything, mock.Anything).
+		Return(&author.Author{BaseModel: models.BaseModel{ID: authorID}}, dto.Success)
+	suite.mockBookService.On("GetBookByISBN", mock.Anything, "9780375704024").Return(nil, dto.BookNotFound)
+	suite.mockBookService.On("CreateBook", mock.Anything, mock.Anything).Return(&book.Book{}, dto.Success)
+
+	result, err := NewSeeder(suite.mockAuthorService, suite.mockBookService, suite.logger, true).Seed(suite.ctx, suite.fixture())
+
+	suite.NoError(err)
+	suite.Equal(&Result{AuthorsCreated: 1, BooksCreated: 1}, result)
+	suite.mockAuthorService.AssertExpectations(suite.T())
+	suite.mockBookService.AssertExpectations(suite.T())
+}
+
+func (suite *SeederTestSuite) TestSeed_BookReferencesExistingAuthor() {
+	authorID := uuid.New()
+	fixture := &Fixture{Books: suite.fixture().Books}
+	suite.mockAuthorService.On("GetAuthorByPenName", mock.Anything, "Haruki Murakami").
+		Return(&author.Author{BaseModel: models.BaseModel{ID: authorID}}, dto.Success)
+	suite.mockBookService.On("CreateBook", mock.Anything, &book.CreateBookRequest{AuthorID: authorID, Name: "Norwegian Wood", ISBN: "9780375704024"}).
+		Return(&book.Book{}, dto.Success)
+
+	result, err := NewSeeder(suite.mockAuthorService, suite.mockBookService, suite.logger, false).Seed(suite.ctx, fixture)
+
+	suite.NoError(err)
+	suite.Equal(1, result.BooksCreated)
+	suite.mockBookService.AssertExpectations(suite.T())
+}
+
+func (suite *SeederTestSuite) TestSeed_BookReferencesUnknownAuthor() {
+	fixture := &Fixture{Books: suite.fixture().Books}
+	suite.mockAuthorService.On("GetAuthorByPenName", mock.Anything, "Haruki Murakami").Return(nil, dto.AuthorNotFound)
+
+	_, err := NewSeeder(suite.mockAuthorService, suite.mockBookService, suite.logger, false).Seed(suite.ctx, fixture)
+
+	suite.EqualError(err, `book "9780375704024" references author "Haruki Murakami": `+dto.CodeMessage[dto.AuthorNotFound])
+}
+
+func (suite *SeederTestSuite) TestSeed_ValidationError() {
+	fixture := &Fixture{Authors: []AuthorFixture{{PenName: "Too Old", BirthYear: 1200}}}
+
+	_, err := NewSeeder(suite.mockAuthorService, suite.mockBookService, suite.logger, false).Seed(suite.ctx, fixture)
+
+	suite.ErrorContains(err, `invalid author "Too Old"`)
+	suite.mockAuthorService.AssertNotCalled(suite.T(), "CreateAuthor", mock.Anything, mock.Anything)
+}
+
+func TestSeederTestSuite(t *testing.T) {
+	suite.Run(t, new(SeederTestSuite))
+}
+
+func TestLoadFixture(t *testing.T) {
+	dir := t.TempDir()
+	yamlPath := filepath.Join(dir, "fixture.yaml")
+	jsonPath := filepath.Join(dir, "fixture.json")
+	textPath := filepath.Join(dir, "fixture.txt")
+
+	assert.NoError(t, os.WriteFile(yamlPath, []byte("authors:\n  - penName: A\n    birthYear: 1990\nbooks:\n  - authorPenName: A\n    name: B\n    isbn: \"9780375704024\"\n"), 0o600))
+	assert.NoError(t, os.WriteFile(jsonPath, []byte(`{"authors":[{"penName":"A","birthYear":1990}],"books":[{"authorPenName":"A","name":"B","isbn":"9780375704024"}]}`), 0o600))
+	assert.NoError(t, os.WriteFile(textPath, []byte("authors"), 0o600))
+
+	expected := &Fixture{
+		Authors: []AuthorFixture{{PenName: "A", BirthYear: 1990}},
+		Books:   []BookFixture{{AuthorPenName: "A", Name: "B", ISBN: "9780375704024"}},
+	}
+
+	fixture, err := LoadFixture(yamlPath)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, fixture)
+
+	fixture, err = LoadFixture(jsonPath)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, fixture)
+
+	_, err = LoadFixture(textPath)
+	assert.EqualError(t, err, `unsupported fixture format ".txt"`)
+}
+
+func TestLoadFixture_Sample(t *testing.T) {
+	fixture, err := LoadFixture("../../database/fixtures/sample.yaml")
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, fixture.Authors)
+	assert.NotEmpty(t, fixture.Books)
+}
@@ -1,64 +1,226 @@
 package config
 
 import (
-	"log"
-	"os"
-
-	"github.com/joho/godotenv"
+	"time"
 )
 
+// Config is populated by Load. Each leaf field is described by struct tags:
+// config is its key in the config file, env the environment variable (and,
+// lowercased with dashes, the command line flag), default its value when no
+// source sets it, validate the rules it must satisfy, secret whether Print
+// redacts it and reload whether a Watcher applies changes without a restart.
 type Config struct {
-	Mode        string
-	ServiceName string
-	Database    DatabaseConfig
-	Server      ServerConfig
+	Mode        string          `config:"mode" env:"GIN_MODE" default:"debug" validate:"oneof=debug release test"`
+	ServiceName string          `config:"serviceName" env:"SERVICE_NAME" default:"simple-gin-crud" validate:"required"`
+	Database    DatabaseConfig  `config:"database"`
+	Server      ServerConfig    `config:"server"`
+	TLS         TLSConfig       `config:"tls"`
+	Log         LogConfig       `config:"log"`
+	AccessLog   AccessLogConfig `config:"accessLog"`
+	Metrics     MetricsConfig   `config:"metrics"`
+	Tracing     TracingConfig   `config:"tracing"`
+	Health      HealthConfig    `config:"health"`
+	Cache       CacheConfig     `config:"cache"`
+	HTTPCache   HTTPCacheConfig `config:"httpCache"`
+	CORS        CORSConfig      `config:"cors"`
+	Security    SecurityConfig  `config:"security"`
+	Request     RequestConfig   `config:"request"`
+	RateLimit   RateLimitConfig `config:"rateLimit"`
+	Admin       AdminConfig     `config:"admin"`
+	Reload      ReloadConfig    `config:"reload"`
+	Secrets     SecretsConfig   `config:"secrets"`
+	Features    []string        `config:"features" env:"FEATURE_FLAGS" default:"" reload:"true"`
+}
+
+// FeatureEnabled reports whether name is listed in Features.
+func (c *Config) FeatureEnabled(name string) bool {
+	for _, feature := range c.Features {
+		if feature == name {
+			return true
+		}
+	}
+	return false
 }
 
 type DatabaseConfig struct {
-	User        string
-	Password    string
-	Host        string
-	Port        string
-	DBName      string
-	SSLMode     string
-	TimeZone    string
-	AutoMigrate bool
+	Driver     string `config:"driver" env:"DB_DRIVER" default:"postgres" validate:"oneof=postgres sqlite"`
+	SQLitePath string `config:"sqlitePath" env:"DB_SQLITE_PATH" default:"data/simple-gin-crud.db" validate:"required_if=Driver sqlite"`
+	User       string `config:"user" env:"DB_USER" validate:"required_if=Driver postgres"`
+	Password   string `config:"password" env:"DB_PASSWORD" secret:"true"`
+	// PasswordSecret names a secret in the configured SecretProvider. When
+	// set it replaces Password and is looked up for every new connection.
+	// Otherwise PasswordFile, the file Password was read from through
+	// DB_PASSWORD_FILE, is read again for every new connection.
+	PasswordSecret string `config:"passwordSecret" env:"DB_PASSWORD_SECRET"`
+	PasswordFile   string `config:"passwordFile" env:"DB_PASSWORD_FILE"`
+	Host           string `config:"host" env:"DB_HOST" validate:"required_if=Driver postgres"`
+	Port           string `config:"port" env:"DB_PORT" validate:"omitempty,numeric"`
+	DBName         string `config:"name" env:"DB_NAME" validate:"required_if=Driver postgres"`
+	SSLMode        string `config:"sslMode" env:"DB_SSLMODE" validate:"omitempty,oneof=disable allow prefer require verify-ca verify-full"`
+	TimeZone       string `config:"timeZone" env:"DB_TIMEZONE"`
+	AutoMigrate    bool   `config:"autoMigrate" env:"DB_AUTO_MIGRATE" default:"false"`
+
+	MaxOpenConns       int           `config:"maxOpenConns" env:"DB_MAX_OPEN_CONNS" default:"25" validate:"gte=0"`
+	MaxIdleConns       int           `config:"maxIdleConns" env:"DB_MAX_IDLE_CONNS" default:"10" validate:"gte=0"`
+	ConnMaxLifetime    time.Duration `config:"connMaxLifetime" env:"DB_CONN_MAX_LIFETIME" default:"30m" validate:"gte=0"`
+	ConnMaxIdleTime    time.Duration `config:"connMaxIdleTime" env:"DB_CONN_MAX_IDLE_TIME" default:"5m" validate:"gte=0"`
+	StatementTimeout   time.Duration `config:"statementTimeout" env:"DB_STATEMENT_TIMEOUT" default:"30s" validate:"gte=0"`
+	PrepareStmt        bool          `config:"prepareStmt" env:"DB_PREPARE_STMT" default:"false"`
+	LogLevel           string        `config:"logLevel" env:"DB_LOG_LEVEL" default:"warn" validate:"oneof=silent error warn info"`
+	SlowQueryThreshold time.Duration `config:"slowQueryThreshold" env:"DB_SLOW_QUERY_THRESHOLD" default:"200ms" validate:"gte=0"`
+	ConnectAttempts    int           `config:"connectAttempts" env:"DB_CONNECT_ATTEMPTS" default:"10" validate:"gte=1"`
+	ConnectBackoff     time.Duration `config:"connectBackoff" env:"DB_CONNECT_BACKOFF" default:"500ms" validate:"gt=0"`
+	ConnectMaxBackoff  time.Duration `config:"connectMaxBackoff" env:"DB_CONNECT_MAX_BACKOFF" default:"10s" validate:"gtefield=ConnectBackoff"`
+
+	ReplicaDSNs           []string      `config:"replicaDSNs" env:"DB_REPLICA_DSNS" default:"" secret:"true"`
+	ReplicaStickyWindow   time.Duration `config:"replicaStickyWindow" env:"DB_REPLICA_STICKY_WINDOW" default:"5s" validate:"gte=0"`
+	ReplicaHealthInterval time.Duration `config:"replicaHealthInterval" env:"DB_REPLICA_HEALTH_INTERVAL" default:"10s" validate:"gt=0"`
+	ReplicaHealthTimeout  time.Duration `config:"replicaHealthTimeout" env:"DB_REPLICA_HEALTH_TIMEOUT" default:"2s" validate:"gt=0"`
 }
 
 type ServerConfig struct {
-	Host string
-	Port string
+	Host              string        `config:"host" env:"SERVER_HOST" default:"0.0.0.0"`
+	Port              string        `config:"port" env:"SERVER_PORT" default:"8080" validate:"required,numeric"`
+	ReadTimeout       time.Duration `config:"readTimeout" env:"SERVER_READ_TIMEOUT" default:"15s" validate:"gte=0"`
+	ReadHeaderTimeout time.Duration `config:"readHeaderTimeout" env:"SERVER_READ_HEADER_TIMEOUT" default:"5s" validate:"gte=0"`
+	WriteTimeout      time.Duration `config:"writeTimeout" env:"SERVER_WRITE_TIMEOUT" default:"30s" validate:"gte=0"`
+	IdleTimeout       time.Duration `config:"idleTimeout" env:"SERVER_IDLE_TIMEOUT" default:"60s" validate:"gte=0"`
+	MaxHeaderBytes    int           `config:"maxHeaderBytes" env:"SERVER_MAX_HEADER_BYTES" default:"1048576" validate:"gt=0"`
+	ShutdownTimeout   time.Duration `config:"shutdownTimeout" env:"SERVER_SHUTDOWN_TIMEOUT" default:"20s" validate:"gt=0"`
+	ShutdownDelay     time.Duration `config:"shutdownDelay" env:"SERVER_SHUTDOWN_DELAY" default:"5s" validate:"gte=0"`
 }
 
-func NewConfig() *Config {
-	if os.Getenv("GIN_MODE") != "release" {
-		if err := godotenv.Load(); err != nil {
-			log.Printf("Warning: .env file not found, using default values")
-		}
-	}
-	return &Config{
-		Mode:        getValue("GIN_MODE", "debug"),
-		ServiceName: getValue("SERVICE_NAME", "simple-gin-crud"),
-		Database: DatabaseConfig{
-			User:        getValue("DB_USER", ""),
-			Password:    getValue("DB_PASSWORD", ""),
-			Host:        getValue("DB_HOST", ""),
-			Port:        getValue("DB_PORT", ""),
-			DBName:      getValue("DB_NAME", ""),
-			SSLMode:     getValue("DB_SSLMODE", ""),
-			TimeZone:    getValue("DB_TIMEZONE", ""),
-			AutoMigrate: getValue("DB_AUTO_MIGRATE", "false") == "true",
-		},
-		Server: ServerConfig{
-			Host: getValue("SERVER_HOST", "0.0.0.0"),
-			Port: getValue("SERVER_PORT", "8080"),
-		},
-	}
+// TLSConfig enables HTTPS on the main listener. The certificate files are
+// watched every ReloadInterval and swapped in without a restart.
+type TLSConfig struct {
+	Enabled        bool          `config:"enabled" env:"TLS_ENABLED" default:"false"`
+	CertFile       string        `config:"certFile" env:"TLS_CERT_FILE" validate:"required_if=Enabled true"`
+	KeyFile        string        `config:"keyFile" env:"TLS_KEY_FILE" validate:"required_if=Enabled true"`
+	MinVersion     string        `config:"minVersion" env:"TLS_MIN_VERSION" default:"1.2" validate:"oneof=1.0 1.1 1.2 1.3"`
+	CipherSuites   []string      `config:"cipherSuites" env:"TLS_CIPHER_SUITES" default:""`
+	ClientAuth     string        `config:"clientAuth" env:"TLS_CLIENT_AUTH" default:"none" validate:"oneof=none optional require"`
+	ClientCAFile   string        `config:"clientCAFile" env:"TLS_CLIENT_CA_FILE" validate:"required_unless=ClientAuth none"`
+	ReloadInterval time.Duration `config:"reloadInterval" env:"TLS_RELOAD_INTERVAL" default:"30s" validate:"gte=0"`
 }
 
-func getValue(key string, defaultValue string) string {
-	if value, ok := os.LookupEnv(key); ok {
-		return value
-	}
-	return defaultValue
+type LogConfig struct {
+	Level          string `config:"level" env:"LOG_LEVEL" default:"info" validate:"oneof=panic fatal error warn warning info debug trace" reload:"true"`
+	Format         string `config:"format" env:"LOG_FORMAT" default:"text" validate:"oneof=text json logfmt"`
+	TimeZone       string `config:"timeZone" env:"LOG_TIMEZONE" validate:"omitempty,timezone"`
+	Output         string `config:"output" env:"LOG_OUTPUT" default:"stdout" validate:"oneof=stdout stderr file"`
+	FilePath       string `config:"filePath" env:"LOG_FILE_PATH" validate:"required_if=Output file"`
+	FileMaxSizeMB  int    `config:"fileMaxSizeMB" env:"LOG_FILE_MAX_SIZE_MB" default:"100" validate:"gt=0"`
+	FileMaxBackups int    `config:"fileMaxBackups" env:"LOG_FILE_MAX_BACKUPS" default:"3" validate:"gte=0"`
+	FileMaxAgeDays int    `config:"fileMaxAgeDays" env:"LOG_FILE_MAX_AGE_DAYS" default:"28" validate:"gte=0"`
+	FileCompress   bool   `config:"fileCompress" env:"LOG_FILE_COMPRESS" default:"false"`
+}
+
+// MetricsConfig serves metrics on their own port unless Public is set, in
+// which case they are served on the API router instead. The admin routes are
+// always served on this port.
+type MetricsConfig struct {
+	Enabled bool   `config:"enabled" env:"METRICS_ENABLED" default:"true"`
+	Path    string `config:"path" env:"METRICS_PATH" default:"/metrics" validate:"startswith=/"`
+	Host    string `config:"host" env:"METRICS_HOST" default:"0.0.0.0"`
+	Port    string `config:"port" env:"METRICS_PORT" default:"9090" validate:"required,numeric"`
+	Public  bool   `config:"public" env:"METRICS_PUBLIC" default:"false"`
+}
+
+type HealthConfig struct {
+	CheckTimeout            time.Duration `config:"checkTimeout" env:"HEALTH_CHECK_TIMEOUT" default:"2s" validate:"gt=0"`
+	CacheTTL                time.Duration `config:"cacheTTL" env:"HEALTH_CACHE_TTL" default:"5s" validate:"gte=0"`
+	PoolSaturationThreshold float64       `config:"poolSaturationThreshold" env:"HEALTH_POOL_SATURATION_THRESHOLD" default:"0.9" validate:"gt=0,lte=1"`
+	DiskPath                string        `config:"diskPath" env:"HEALTH_DISK_PATH" default:"/" validate:"required"`
+	DiskMinFreeMB           int           `config:"diskMinFreeMB" env:"HEALTH_DISK_MIN_FREE_MB" default:"100" validate:"gte=0"`
+}
+
+type CacheConfig struct {
+	Enabled     bool          `config:"enabled" env:"CACHE_ENABLED" default:"false"`
+	Size        int           `config:"size" env:"CACHE_SIZE" default:"10000" validate:"gt=0"`
+	TTL         time.Duration `config:"ttl" env:"CACHE_TTL" default:"1m" validate:"gt=0" reload:"true"`
+	NegativeTTL time.Duration `config:"negativeTTL" env:"CACHE_NEGATIVE_TTL" default:"5s" validate:"gte=0" reload:"true"`
+}
+
+type HTTPCacheConfig struct {
+	CacheControl         string            `config:"cacheControl" env:"HTTP_CACHE_CONTROL" default:"no-cache"`
+	RouteCacheControl    map[string]string `config:"routeCacheControl" env:"HTTP_CACHE_CONTROL_ROUTES" default:""`
+	ResponseCacheEnabled bool              `config:"responseCacheEnabled" env:"HTTP_RESPONSE_CACHE_ENABLED" default:"false"`
+	ResponseCacheSize    int               `config:"responseCacheSize" env:"HTTP_RESPONSE_CACHE_SIZE" default:"1000" validate:"gt=0"`
+	ResponseCacheTTL     time.Duration     `config:"responseCacheTTL" env:"HTTP_RESPONSE_CACHE_TTL" default:"30s" validate:"gt=0" reload:"true"`
+}
+
+// CORSConfig starts from Preset (development, or production in release
+// mode, when empty); any list set here, or a MaxAge above zero, replaces
+// the preset's value.
+type CORSConfig struct {
+	Preset           string        `config:"preset" env:"CORS_PRESET" validate:"omitempty,oneof=development production" reload:"true"`
+	AllowOrigins     []string      `config:"allowOrigins" env:"CORS_ALLOW_ORIGINS" default:"" reload:"true"`
+	AllowMethods     []string      `config:"allowMethods" env:"CORS_ALLOW_METHODS" default:"" reload:"true"`
+	AllowHeaders     []string      `config:"allowHeaders" env:"CORS_ALLOW_HEADERS" default:"" reload:"true"`
+	ExposeHeaders    []string      `config:"exposeHeaders" env:"CORS_EXPOSE_HEADERS" default:"" reload:"true"`
+	AllowCredentials bool          `config:"allowCredentials" env:"CORS_ALLOW_CREDENTIALS" default:"false" reload:"true"`
+	MaxAge           time.Duration `config:"maxAge" env:"CORS_MAX_AGE" default:"0s" validate:"gte=0" reload:"true"`
+}
+
+type SecurityConfig struct {
+	HeadersEnabled             bool              `config:"headersEnabled" env:"SECURITY_HEADERS_ENABLED" default:"true"`
+	HSTSMaxAge                 time.Duration     `config:"hstsMaxAge" env:"SECURITY_HSTS_MAX_AGE" default:"8760h" validate:"gte=0"`
+	HSTSIncludeSubdomains      bool              `config:"hstsIncludeSubdomains" env:"SECURITY_HSTS_INCLUDE_SUBDOMAINS" default:"true"`
+	ContentSecurityPolicy      string            `config:"contentSecurityPolicy" env:"SECURITY_CSP" default:"default-src 'none'; frame-ancestors 'none'"`
+	RouteContentSecurityPolicy map[string]string `config:"routeContentSecurityPolicy" env:"SECURITY_CSP_ROUTES" default:""`
+	ReferrerPolicy             string            `config:"referrerPolicy" env:"SECURITY_REFERRER_POLICY" default:"no-referrer"`
+	FrameOptions               string            `config:"frameOptions" env:"SECURITY_FRAME_OPTIONS" default:"DENY" validate:"omitempty,oneof=DENY SAMEORIGIN"`
+	ContentTypeNosniff         bool              `config:"contentTypeNosniff" env:"SECURITY_CONTENT_TYPE_NOSNIFF" default:"true"`
+	TrustedProxies             []string          `config:"trustedProxies" env:"SECURITY_TRUSTED_PROXIES" default:"" validate:"dive,cidr|ip"`
+}
+
+// RequestConfig limits and checks request bodies. RouteMaxBodyBytes overrides
+// MaxBodyBytes per route pattern, e.g. "/v1/book/=4096".
+type RequestConfig struct {
+	MaxBodyBytes       int            `config:"maxBodyBytes" env:"REQUEST_MAX_BODY_BYTES" default:"1048576" validate:"gt=0"`
+	RouteMaxBodyBytes  map[string]int `config:"routeMaxBodyBytes" env:"REQUEST_MAX_BODY_BYTES_ROUTES" default:"" validate:"dive,gt=0"`
+	StrictJSON         bool           `config:"strictJson" env:"REQUEST_STRICT_JSON" default:"true"`
+	RequireContentType bool           `config:"requireContentType" env:"REQUEST_REQUIRE_CONTENT_TYPE" default:"true"`
+}
+
+type RateLimitConfig struct {
+	Enabled           bool    `config:"enabled" env:"RATE_LIMIT_ENABLED" default:"false" reload:"true"`
+	RequestsPerSecond float64 `config:"requestsPerSecond" env:"RATE_LIMIT_RPS" default:"20" validate:"gt=0" reload:"true"`
+	Burst             int     `config:"burst" env:"RATE_LIMIT_BURST" default:"40" validate:"gt=0" reload:"true"`
+}
+
+type AdminConfig struct {
+	Enabled bool   `config:"enabled" env:"ADMIN_ENABLED" default:"false"`
+	Token   string `config:"token" env:"ADMIN_TOKEN" secret:"true" validate:"required_if=Enabled true"`
+}
+
+type SecretsConfig struct {
+	Provider string `config:"provider" env:"SECRETS_PROVIDER" default:"env" validate:"oneof=env file encrypted-file"`
+	Dir      string `config:"dir" env:"SECRETS_DIR" default:"/run/secrets" validate:"required_if=Provider file"`
+	File     string `config:"file" env:"SECRETS_FILE" validate:"required_if=Provider encrypted-file"`
+	Key      string `config:"key" env:"SECRETS_KEY" secret:"true" validate:"required_if=Provider encrypted-file"`
+}
+
+type ReloadConfig struct {
+	WatchInterval time.Duration `config:"watchInterval" env:"CONFIG_WATCH_INTERVAL" default:"5s" validate:"gte=0"`
+}
+
+type TracingConfig struct {
+	Exporter     string  `config:"exporter" env:"TRACING_EXPORTER" default:"none" validate:"oneof=none stdout otlp"`
+	OTLPEndpoint string  `config:"otlpEndpoint" env:"TRACING_OTLP_ENDPOINT"`
+	OTLPInsecure bool    `config:"otlpInsecure" env:"TRACING_OTLP_INSECURE" default:"false"`
+	SampleRatio  float64 `config:"sampleRatio" env:"TRACING_SAMPLE_RATIO" default:"1" validate:"gte=0,lte=1"`
+}
+
+type AccessLogConfig struct {
+	Enabled           bool          `config:"enabled" env:"ACCESS_LOG_ENABLED" default:"true"`
+	SampleRate        float64       `config:"sampleRate" env:"ACCESS_LOG_SAMPLE_RATE" default:"1" validate:"gte=0,lte=1"`
+	SlowThreshold     time.Duration `config:"slowThreshold" env:"ACCESS_LOG_SLOW_THRESHOLD" default:"500ms" validate:"gte=0"`
+	VerySlowThreshold time.Duration `config:"verySlowThreshold" env:"ACCESS_LOG_VERY_SLOW_THRESHOLD" default:"2s" validate:"gtefield=SlowThreshold"`
+	SkipPaths         []string      `config:"skipPaths" env:"ACCESS_LOG_SKIP_PATHS" default:"/health,/livez,/readyz,/startupz"`
+	LogHeaders        bool          `config:"logHeaders" env:"ACCESS_LOG_HEADERS" default:"false"`
+	LogBody           bool          `config:"logBody" env:"ACCESS_LOG_BODY" default:"false"`
+	MaxBodyBytes      int           `config:"maxBodyBytes" env:"ACCESS_LOG_MAX_BODY_BYTES" default:"4096" validate:"gte=0"`
+	RedactHeaders     []string      `config:"redactHeaders" env:"ACCESS_LOG_REDACT_HEADERS" default:"Authorization,Cookie,Set-Cookie,X-Api-Key" validate:"min=1"`
+	RedactBodyFields  []string      `config:"redactBodyFields" env:"ACCESS_LOG_REDACT_FIELDS" default:"password,token,secret,accessToken,refreshToken" validate:"min=1"`
 }
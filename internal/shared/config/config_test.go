@@ -3,8 +3,10 @@ package config
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func clearEnvVars() {
@@ -12,6 +14,15 @@ func clearEnvVars() {
 		"SERVICE_NAME",
 		"SERVER_HOST",
 		"SERVER_PORT",
+		"SERVER_READ_TIMEOUT",
+		"SERVER_READ_HEADER_TIMEOUT",
+		"SERVER_WRITE_TIMEOUT",
+		"SERVER_IDLE_TIMEOUT",
+		"SERVER_MAX_HEADER_BYTES",
+		"SERVER_SHUTDOWN_TIMEOUT",
+		"SERVER_SHUTDOWN_DELAY",
+		"DB_DRIVER",
+		"DB_SQLITE_PATH",
 		"DB_USER",
 		"DB_PASSWORD",
 		"DB_HOST",
@@ -20,6 +31,105 @@ func clearEnvVars() {
 		"DB_SSLMODE",
 		"DB_TIMEZONE",
 		"DB_AUTO_MIGRATE",
+		"DB_MAX_OPEN_CONNS",
+		"DB_MAX_IDLE_CONNS",
+		"DB_CONN_MAX_LIFETIME",
+		"DB_CONN_MAX_IDLE_TIME",
+		"DB_STATEMENT_TIMEOUT",
+		"DB_PREPARE_STMT",
+		"DB_LOG_LEVEL",
+		"DB_SLOW_QUERY_THRESHOLD",
+		"DB_CONNECT_ATTEMPTS",
+		"DB_CONNECT_BACKOFF",
+		"DB_CONNECT_MAX_BACKOFF",
+		"DB_PASSWORD_SECRET",
+		"DB_PASSWORD_FILE",
+		"SECRETS_PROVIDER",
+		"SECRETS_DIR",
+		"SECRETS_FILE",
+		"SECRETS_KEY",
+		"LOG_LEVEL",
+		"LOG_FORMAT",
+		"LOG_TIMEZONE",
+		"LOG_OUTPUT",
+		"LOG_FILE_PATH",
+		"LOG_FILE_MAX_SIZE_MB",
+		"LOG_FILE_MAX_BACKUPS",
+		"LOG_FILE_MAX_AGE_DAYS",
+		"LOG_FILE_COMPRESS",
+		"ACCESS_LOG_ENABLED",
+		"ACCESS_LOG_SAMPLE_RATE",
+		"ACCESS_LOG_SLOW_THRESHOLD",
+		"ACCESS_LOG_VERY_SLOW_THRESHOLD",
+		"ACCESS_LOG_SKIP_PATHS",
+		"ACCESS_LOG_HEADERS",
+		"ACCESS_LOG_BODY",
+		"ACCESS_LOG_MAX_BODY_BYTES",
+		"ACCESS_LOG_REDACT_HEADERS",
+		"ACCESS_LOG_REDACT_FIELDS",
+		"METRICS_ENABLED",
+		"METRICS_PATH",
+		"METRICS_HOST",
+		"METRICS_PORT",
+		"METRICS_PUBLIC",
+		"TRACING_EXPORTER",
+		"TRACING_OTLP_ENDPOINT",
+		"TRACING_OTLP_INSECURE",
+		"TRACING_SAMPLE_RATIO",
+		"HEALTH_CHECK_TIMEOUT",
+		"HEALTH_CACHE_TTL",
+		"HEALTH_POOL_SATURATION_THRESHOLD",
+		"HEALTH_DISK_PATH",
+		"HEALTH_DISK_MIN_FREE_MB",
+		"DB_REPLICA_DSNS",
+		"DB_REPLICA_STICKY_WINDOW",
+		"DB_REPLICA_HEALTH_INTERVAL",
+		"DB_REPLICA_HEALTH_TIMEOUT",
+		"CACHE_ENABLED",
+		"CACHE_SIZE",
+		"CACHE_TTL",
+		"CACHE_NEGATIVE_TTL",
+		"HTTP_CACHE_CONTROL",
+		"HTTP_CACHE_CONTROL_ROUTES",
+		"HTTP_RESPONSE_CACHE_ENABLED",
+		"HTTP_RESPONSE_CACHE_SIZE",
+		"HTTP_RESPONSE_CACHE_TTL",
+		"CONFIG_FILE",
+		"CONFIG_WATCH_INTERVAL",
+		"TLS_ENABLED",
+		"TLS_CERT_FILE",
+		"TLS_KEY_FILE",
+		"TLS_MIN_VERSION",
+		"TLS_CIPHER_SUITES",
+		"TLS_CLIENT_AUTH",
+		"TLS_CLIENT_CA_FILE",
+		"TLS_RELOAD_INTERVAL",
+		"CORS_PRESET",
+		"CORS_ALLOW_ORIGINS",
+		"CORS_ALLOW_METHODS",
+		"CORS_ALLOW_HEADERS",
+		"CORS_EXPOSE_HEADERS",
+		"CORS_ALLOW_CREDENTIALS",
+		"CORS_MAX_AGE",
+		"SECURITY_HEADERS_ENABLED",
+		"SECURITY_HSTS_MAX_AGE",
+		"SECURITY_HSTS_INCLUDE_SUBDOMAINS",
+		"SECURITY_CSP",
+		"SECURITY_CSP_ROUTES",
+		"SECURITY_REFERRER_POLICY",
+		"SECURITY_FRAME_OPTIONS",
+		"SECURITY_CONTENT_TYPE_NOSNIFF",
+		"SECURITY_TRUSTED_PROXIES",
+		"REQUEST_MAX_BODY_BYTES",
+		"REQUEST_MAX_BODY_BYTES_ROUTES",
+		"REQUEST_STRICT_JSON",
+		"REQUEST_REQUIRE_CONTENT_TYPE",
+		"RATE_LIMIT_ENABLED",
+		"RATE_LIMIT_RPS",
+		"RATE_LIMIT_BURST",
+		"ADMIN_ENABLED",
+		"ADMIN_TOKEN",
+		"FEATURE_FLAGS",
 	}
 
 	for _, envVar := range envVars {
@@ -27,29 +137,150 @@ func clearEnvVars() {
 	}
 }
 
-func TestNewConfig_WithDefaults(t *testing.T) {
+func loadConfig(t *testing.T, args ...string) *Config {
+	config, _, err := Load(args)
+	require.NoError(t, err)
+	return config
+}
+
+func setRequiredEnv(t *testing.T) {
+	t.Setenv("DB_USER", "postgres")
+	t.Setenv("DB_HOST", "localhost")
+	t.Setenv("DB_NAME", "simple_gin_crud")
+}
+
+func TestLoad_WithDefaults(t *testing.T) {
 	clearEnvVars()
+	setRequiredEnv(t)
 
-	config := NewConfig()
+	config := loadConfig(t)
 
 	assert.NotNil(t, config)
 	assert.Equal(t, "simple-gin-crud", config.ServiceName)
 	assert.Equal(t, "0.0.0.0", config.Server.Host)
 	assert.Equal(t, "8080", config.Server.Port)
-	assert.Equal(t, "", config.Database.User)
+	assert.Equal(t, 15*time.Second, config.Server.ReadTimeout)
+	assert.Equal(t, 5*time.Second, config.Server.ReadHeaderTimeout)
+	assert.Equal(t, 30*time.Second, config.Server.WriteTimeout)
+	assert.Equal(t, 60*time.Second, config.Server.IdleTimeout)
+	assert.Equal(t, 1<<20, config.Server.MaxHeaderBytes)
+	assert.Equal(t, 20*time.Second, config.Server.ShutdownTimeout)
+	assert.Equal(t, 5*time.Second, config.Server.ShutdownDelay)
+	assert.Equal(t, "postgres", config.Database.Driver)
+	assert.Equal(t, "data/simple-gin-crud.db", config.Database.SQLitePath)
+	assert.Equal(t, "postgres", config.Database.User)
 	assert.Equal(t, "", config.Database.Password)
-	assert.Equal(t, "", config.Database.Host)
+	assert.Equal(t, "localhost", config.Database.Host)
 	assert.Equal(t, "", config.Database.Port)
-	assert.Equal(t, "", config.Database.DBName)
+	assert.Equal(t, "simple_gin_crud", config.Database.DBName)
 	assert.Equal(t, "", config.Database.SSLMode)
 	assert.Equal(t, "", config.Database.TimeZone)
 	assert.False(t, config.Database.AutoMigrate)
+	assert.Equal(t, 25, config.Database.MaxOpenConns)
+	assert.Equal(t, 10, config.Database.MaxIdleConns)
+	assert.Equal(t, 30*time.Minute, config.Database.ConnMaxLifetime)
+	assert.Equal(t, 5*time.Minute, config.Database.ConnMaxIdleTime)
+	assert.Equal(t, 30*time.Second, config.Database.StatementTimeout)
+	assert.False(t, config.Database.PrepareStmt)
+	assert.Equal(t, "warn", config.Database.LogLevel)
+	assert.Equal(t, 200*time.Millisecond, config.Database.SlowQueryThreshold)
+	assert.Equal(t, 10, config.Database.ConnectAttempts)
+	assert.Equal(t, 500*time.Millisecond, config.Database.ConnectBackoff)
+	assert.Equal(t, 10*time.Second, config.Database.ConnectMaxBackoff)
+	assert.Equal(t, "", config.Database.PasswordSecret)
+	assert.Equal(t, "env", config.Secrets.Provider)
+	assert.Equal(t, "/run/secrets", config.Secrets.Dir)
+	assert.Equal(t, "", config.Secrets.File)
+	assert.Equal(t, "", config.Secrets.Key)
+	assert.False(t, config.TLS.Enabled)
+	assert.Equal(t, "", config.TLS.CertFile)
+	assert.Equal(t, "", config.TLS.KeyFile)
+	assert.Equal(t, "1.2", config.TLS.MinVersion)
+	assert.Empty(t, config.TLS.CipherSuites)
+	assert.Equal(t, "none", config.TLS.ClientAuth)
+	assert.Equal(t, "", config.TLS.ClientCAFile)
+	assert.Equal(t, 30*time.Second, config.TLS.ReloadInterval)
+	assert.Equal(t, "", config.CORS.Preset)
+	assert.Empty(t, config.CORS.AllowOrigins)
+	assert.Empty(t, config.CORS.AllowMethods)
+	assert.Empty(t, config.CORS.AllowHeaders)
+	assert.Empty(t, config.CORS.ExposeHeaders)
+	assert.False(t, config.CORS.AllowCredentials)
+	assert.Equal(t, time.Duration(0), config.CORS.MaxAge)
+	assert.True(t, config.Security.HeadersEnabled)
+	assert.Equal(t, 8760*time.Hour, config.Security.HSTSMaxAge)
+	assert.True(t, config.Security.HSTSIncludeSubdomains)
+	assert.Equal(t, "default-src 'none'; frame-ancestors 'none'", config.Security.ContentSecurityPolicy)
+	assert.Empty(t, config.Security.RouteContentSecurityPolicy)
+	assert.Equal(t, "no-referrer", config.Security.ReferrerPolicy)
+	assert.Equal(t, "DENY", config.Security.FrameOptions)
+	assert.True(t, config.Security.ContentTypeNosniff)
+	assert.Empty(t, config.Security.TrustedProxies)
+	assert.Equal(t, 1048576, config.Request.MaxBodyBytes)
+	assert.Empty(t, config.Request.RouteMaxBodyBytes)
+	assert.True(t, config.Request.StrictJSON)
+	assert.True(t, config.Request.RequireContentType)
+	assert.False(t, config.RateLimit.Enabled)
+	assert.Equal(t, float64(20), config.RateLimit.RequestsPerSecond)
+	assert.Equal(t, 40, config.RateLimit.Burst)
+	assert.False(t, config.Admin.Enabled)
+	assert.Equal(t, "", config.Admin.Token)
+	assert.Equal(t, 5*time.Second, config.Reload.WatchInterval)
+	assert.Empty(t, config.Features)
+	assert.Equal(t, "info", config.Log.Level)
+	assert.Equal(t, "text", config.Log.Format)
+	assert.Equal(t, "", config.Log.TimeZone)
+	assert.Equal(t, "stdout", config.Log.Output)
+	assert.Equal(t, "", config.Log.FilePath)
+	assert.Equal(t, 100, config.Log.FileMaxSizeMB)
+	assert.Equal(t, 3, config.Log.FileMaxBackups)
+	assert.Equal(t, 28, config.Log.FileMaxAgeDays)
+	assert.False(t, config.Log.FileCompress)
+	assert.True(t, config.AccessLog.Enabled)
+	assert.Equal(t, float64(1), config.AccessLog.SampleRate)
+	assert.Equal(t, 500*time.Millisecond, config.AccessLog.SlowThreshold)
+	assert.Equal(t, 2*time.Second, config.AccessLog.VerySlowThreshold)
+	assert.Equal(t, []string{"/health", "/livez", "/readyz", "/startupz"}, config.AccessLog.SkipPaths)
+	assert.False(t, config.AccessLog.LogHeaders)
+	assert.False(t, config.AccessLog.LogBody)
+	assert.Equal(t, 4096, config.AccessLog.MaxBodyBytes)
+	assert.Contains(t, config.AccessLog.RedactHeaders, "Authorization")
+	assert.Contains(t, config.AccessLog.RedactBodyFields, "password")
+	assert.True(t, config.Metrics.Enabled)
+	assert.Equal(t, "/metrics", config.Metrics.Path)
+	assert.Equal(t, "0.0.0.0", config.Metrics.Host)
+	assert.Equal(t, "9090", config.Metrics.Port)
+	assert.False(t, config.Metrics.Public)
+	assert.Equal(t, "none", config.Tracing.Exporter)
+	assert.Equal(t, "", config.Tracing.OTLPEndpoint)
+	assert.False(t, config.Tracing.OTLPInsecure)
+	assert.Equal(t, float64(1), config.Tracing.SampleRatio)
+	assert.Equal(t, 2*time.Second, config.Health.CheckTimeout)
+	assert.Equal(t, 5*time.Second, config.Health.CacheTTL)
+	assert.Equal(t, 0.9, config.Health.PoolSaturationThreshold)
+	assert.Equal(t, "/", config.Health.DiskPath)
+	assert.Equal(t, 100, config.Health.DiskMinFreeMB)
+	assert.Empty(t, config.Database.ReplicaDSNs)
+	assert.Equal(t, 5*time.Second, config.Database.ReplicaStickyWindow)
+	assert.Equal(t, 10*time.Second, config.Database.ReplicaHealthInterval)
+	assert.Equal(t, 2*time.Second, config.Database.ReplicaHealthTimeout)
+	assert.False(t, config.Cache.Enabled)
+	assert.Equal(t, 10000, config.Cache.Size)
+	assert.Equal(t, time.Minute, config.Cache.TTL)
+	assert.Equal(t, 5*time.Second, config.Cache.NegativeTTL)
+	assert.Equal(t, "no-cache", config.HTTPCache.CacheControl)
+	assert.Empty(t, config.HTTPCache.RouteCacheControl)
+	assert.False(t, config.HTTPCache.ResponseCacheEnabled)
+	assert.Equal(t, 1000, config.HTTPCache.ResponseCacheSize)
+	assert.Equal(t, 30*time.Second, config.HTTPCache.ResponseCacheTTL)
 }
 
-func TestNewConfig_WithEnvironmentVariables(t *testing.T) {
+func TestLoad_WithEnvironmentVariables(t *testing.T) {
 	os.Setenv("SERVICE_NAME", "test-service")
 	os.Setenv("SERVER_HOST", "localhost")
 	os.Setenv("SERVER_PORT", "9090")
+	os.Setenv("DB_DRIVER", "sqlite")
+	os.Setenv("DB_SQLITE_PATH", ":memory:")
 	os.Setenv("DB_USER", "testuser")
 	os.Setenv("DB_PASSWORD", "testpass")
 	os.Setenv("DB_HOST", "localhost")
@@ -58,15 +289,76 @@ func TestNewConfig_WithEnvironmentVariables(t *testing.T) {
 	os.Setenv("DB_SSLMODE", "disable")
 	os.Setenv("DB_TIMEZONE", "UTC")
 	os.Setenv("DB_AUTO_MIGRATE", "true")
+	os.Setenv("DB_MAX_OPEN_CONNS", "50")
+	os.Setenv("DB_MAX_IDLE_CONNS", "20")
+	os.Setenv("DB_CONN_MAX_LIFETIME", "1h")
+	os.Setenv("DB_CONN_MAX_IDLE_TIME", "10m")
+	os.Setenv("DB_STATEMENT_TIMEOUT", "5s")
+	os.Setenv("DB_PREPARE_STMT", "true")
+	os.Setenv("DB_LOG_LEVEL", "info")
+	os.Setenv("DB_SLOW_QUERY_THRESHOLD", "1s")
+	os.Setenv("DB_CONNECT_ATTEMPTS", "3")
+	os.Setenv("DB_CONNECT_BACKOFF", "1s")
+	os.Setenv("DB_CONNECT_MAX_BACKOFF", "5s")
+	os.Setenv("DB_PASSWORD_SECRET", "db_password")
+	os.Setenv("SECRETS_PROVIDER", "file")
+	os.Setenv("SECRETS_DIR", "/etc/secrets")
+	os.Setenv("LOG_LEVEL", "debug")
+	os.Setenv("LOG_FORMAT", "json")
+	os.Setenv("LOG_TIMEZONE", "UTC")
+	os.Setenv("LOG_OUTPUT", "file")
+	os.Setenv("LOG_FILE_PATH", "/var/log/app.log")
+	os.Setenv("LOG_FILE_MAX_SIZE_MB", "50")
+	os.Setenv("LOG_FILE_MAX_BACKUPS", "5")
+	os.Setenv("LOG_FILE_MAX_AGE_DAYS", "7")
+	os.Setenv("LOG_FILE_COMPRESS", "true")
+	os.Setenv("DB_REPLICA_DSNS", "host=replica1 dbname=test, host=replica2 dbname=test")
+	os.Setenv("DB_REPLICA_STICKY_WINDOW", "1s")
+	os.Setenv("CACHE_ENABLED", "true")
+	os.Setenv("CACHE_SIZE", "500")
+	os.Setenv("CACHE_TTL", "30s")
+	os.Setenv("CACHE_NEGATIVE_TTL", "1s")
+	os.Setenv("TLS_ENABLED", "true")
+	os.Setenv("TLS_CERT_FILE", "/etc/tls/tls.crt")
+	os.Setenv("TLS_KEY_FILE", "/etc/tls/tls.key")
+	os.Setenv("TLS_MIN_VERSION", "1.3")
+	os.Setenv("TLS_CIPHER_SUITES", "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256")
+	os.Setenv("TLS_CLIENT_AUTH", "require")
+	os.Setenv("TLS_CLIENT_CA_FILE", "/etc/tls/ca.crt")
+	os.Setenv("TLS_RELOAD_INTERVAL", "1m")
+	os.Setenv("CORS_PRESET", "production")
+	os.Setenv("CORS_ALLOW_ORIGINS", "https://a.example,https://b.example")
+	os.Setenv("CORS_ALLOW_METHODS", "GET,POST")
+	os.Setenv("CORS_ALLOW_CREDENTIALS", "true")
+	os.Setenv("CORS_MAX_AGE", "10m")
+	os.Setenv("SECURITY_HSTS_MAX_AGE", "0s")
+	os.Setenv("SECURITY_CSP", "default-src 'self'")
+	os.Setenv("SECURITY_FRAME_OPTIONS", "SAMEORIGIN")
+	os.Setenv("SECURITY_TRUSTED_PROXIES", "10.0.0.0/8,192.168.1.1")
+	os.Setenv("METRICS_PUBLIC", "true")
+	os.Setenv("REQUEST_MAX_BODY_BYTES", "2048")
+	os.Setenv("REQUEST_MAX_BODY_BYTES_ROUTES", "/v1/book/=4096")
+	os.Setenv("REQUEST_STRICT_JSON", "false")
+	os.Setenv("REQUEST_REQUIRE_CONTENT_TYPE", "false")
+	os.Setenv("RATE_LIMIT_ENABLED", "true")
+	os.Setenv("RATE_LIMIT_RPS", "5")
+	os.Setenv("RATE_LIMIT_BURST", "10")
+	os.Setenv("ADMIN_ENABLED", "true")
+	os.Setenv("ADMIN_TOKEN", "secret")
+	os.Setenv("CONFIG_WATCH_INTERVAL", "0s")
+	os.Setenv("FEATURE_FLAGS", "beta, dark-mode")
+	os.Setenv("HTTP_CACHE_CONTROL_ROUTES", "/v1/author/:id=public, max-age=60; /v1/book/=no-store;")
 
 	defer clearEnvVars()
 
-	config := NewConfig()
+	config := loadConfig(t)
 
 	assert.NotNil(t, config)
 	assert.Equal(t, "test-service", config.ServiceName)
 	assert.Equal(t, "localhost", config.Server.Host)
 	assert.Equal(t, "9090", config.Server.Port)
+	assert.Equal(t, "sqlite", config.Database.Driver)
+	assert.Equal(t, ":memory:", config.Database.SQLitePath)
 	assert.Equal(t, "testuser", config.Database.User)
 	assert.Equal(t, "testpass", config.Database.Password)
 	assert.Equal(t, "localhost", config.Database.Host)
@@ -75,37 +367,80 @@ func TestNewConfig_WithEnvironmentVariables(t *testing.T) {
 	assert.Equal(t, "disable", config.Database.SSLMode)
 	assert.Equal(t, "UTC", config.Database.TimeZone)
 	assert.True(t, config.Database.AutoMigrate)
-}
-
-func TestGetValue_WithEnvironmentVariable(t *testing.T) {
-	os.Setenv("TEST_KEY", "test_value")
-	defer os.Unsetenv("TEST_KEY")
-
-	result := getValue("TEST_KEY", "default_value")
-	assert.Equal(t, "test_value", result)
-}
-
-func TestGetValue_WithoutEnvironmentVariable(t *testing.T) {
-	os.Unsetenv("NONEXISTENT_KEY")
-
-	result := getValue("NONEXISTENT_KEY", "default_value")
-	assert.Equal(t, "default_value", result)
-}
-
-func TestGetValue_WithEmptyEnvironmentVariable(t *testing.T) {
-	os.Setenv("EMPTY_KEY", "")
-	defer os.Unsetenv("EMPTY_KEY")
-
-	result := getValue("EMPTY_KEY", "default_value")
-	assert.Equal(t, "", result)
+	assert.Equal(t, 50, config.Database.MaxOpenConns)
+	assert.Equal(t, 20, config.Database.MaxIdleConns)
+	assert.Equal(t, time.Hour, config.Database.ConnMaxLifetime)
+	assert.Equal(t, 10*time.Minute, config.Database.ConnMaxIdleTime)
+	assert.Equal(t, 5*time.Second, config.Database.StatementTimeout)
+	assert.True(t, config.Database.PrepareStmt)
+	assert.Equal(t, "info", config.Database.LogLevel)
+	assert.Equal(t, time.Second, config.Database.SlowQueryThreshold)
+	assert.Equal(t, 3, config.Database.ConnectAttempts)
+	assert.Equal(t, time.Second, config.Database.ConnectBackoff)
+	assert.Equal(t, 5*time.Second, config.Database.ConnectMaxBackoff)
+	assert.Equal(t, "db_password", config.Database.PasswordSecret)
+	assert.Equal(t, "file", config.Secrets.Provider)
+	assert.Equal(t, "/etc/secrets", config.Secrets.Dir)
+	assert.Equal(t, "debug", config.Log.Level)
+	assert.Equal(t, "json", config.Log.Format)
+	assert.Equal(t, "UTC", config.Log.TimeZone)
+	assert.Equal(t, "file", config.Log.Output)
+	assert.Equal(t, "/var/log/app.log", config.Log.FilePath)
+	assert.Equal(t, 50, config.Log.FileMaxSizeMB)
+	assert.Equal(t, 5, config.Log.FileMaxBackups)
+	assert.Equal(t, 7, config.Log.FileMaxAgeDays)
+	assert.True(t, config.Log.FileCompress)
+	assert.Equal(t, []string{"host=replica1 dbname=test", "host=replica2 dbname=test"}, config.Database.ReplicaDSNs)
+	assert.Equal(t, time.Second, config.Database.ReplicaStickyWindow)
+	assert.True(t, config.Cache.Enabled)
+	assert.Equal(t, 500, config.Cache.Size)
+	assert.Equal(t, 30*time.Second, config.Cache.TTL)
+	assert.Equal(t, time.Second, config.Cache.NegativeTTL)
+	assert.Equal(t, map[string]string{
+		"/v1/author/:id": "public, max-age=60",
+		"/v1/book/":      "no-store",
+	}, config.HTTPCache.RouteCacheControl)
+	assert.True(t, config.TLS.Enabled)
+	assert.Equal(t, "/etc/tls/tls.crt", config.TLS.CertFile)
+	assert.Equal(t, "/etc/tls/tls.key", config.TLS.KeyFile)
+	assert.Equal(t, "1.3", config.TLS.MinVersion)
+	assert.Equal(t, []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"}, config.TLS.CipherSuites)
+	assert.Equal(t, "require", config.TLS.ClientAuth)
+	assert.Equal(t, "/etc/tls/ca.crt", config.TLS.ClientCAFile)
+	assert.Equal(t, time.Minute, config.TLS.ReloadInterval)
+	assert.Equal(t, "production", config.CORS.Preset)
+	assert.Equal(t, []string{"https://a.example", "https://b.example"}, config.CORS.AllowOrigins)
+	assert.Equal(t, []string{"GET", "POST"}, config.CORS.AllowMethods)
+	assert.True(t, config.CORS.AllowCredentials)
+	assert.Equal(t, 10*time.Minute, config.CORS.MaxAge)
+	assert.Equal(t, time.Duration(0), config.Security.HSTSMaxAge)
+	assert.Equal(t, "default-src 'self'", config.Security.ContentSecurityPolicy)
+	assert.Equal(t, "SAMEORIGIN", config.Security.FrameOptions)
+	assert.Equal(t, []string{"10.0.0.0/8", "192.168.1.1"}, config.Security.TrustedProxies)
+	assert.True(t, config.Metrics.Public)
+	assert.Equal(t, 2048, config.Request.MaxBodyBytes)
+	assert.Equal(t, map[string]int{"/v1/book/": 4096}, config.Request.RouteMaxBodyBytes)
+	assert.False(t, config.Request.StrictJSON)
+	assert.False(t, config.Request.RequireContentType)
+	assert.True(t, config.RateLimit.Enabled)
+	assert.Equal(t, float64(5), config.RateLimit.RequestsPerSecond)
+	assert.Equal(t, 10, config.RateLimit.Burst)
+	assert.True(t, config.Admin.Enabled)
+	assert.Equal(t, "secret", config.Admin.Token)
+	assert.Equal(t, time.Duration(0), config.Reload.WatchInterval)
+	assert.True(t, config.FeatureEnabled("dark-mode"))
+	assert.False(t, config.FeatureEnabled("alpha"))
 }
 
 func TestConfig_FieldTypes(t *testing.T) {
 	clearEnvVars()
+	setRequiredEnv(t)
 
-	config := NewConfig()
+	config := loadConfig(t)
 
 	assert.IsType(t, "", config.ServiceName)
+	assert.IsType(t, "", config.Database.Driver)
+	assert.IsType(t, "", config.Database.SQLitePath)
 	assert.IsType(t, "", config.Database.User)
 	assert.IsType(t, "", config.Database.Password)
 	assert.IsType(t, "", config.Database.Host)
@@ -0,0 +1,414 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Flags holds the command line options that are not configuration values.
+type Flags struct {
+	ConfigFile  string
+	PrintConfig bool
+}
+
+// Load builds the configuration from, in increasing precedence, the default
+// tags, the YAML or TOML file named by --config or CONFIG_FILE, environment
+// variables (including a .env file outside release mode, re-read on every
+// call) and command line flags. It fails with a *ValidationError listing every invalid field.
+func Load(args []string) (*Config, Flags, error) {
+	cfg := &Config{}
+	fields := fieldsOf(reflect.ValueOf(cfg).Elem(), "")
+
+	flags, flagValues, err := parseFlags(args, fields)
+	if err != nil {
+		return nil, flags, err
+	}
+
+	if os.Getenv("GIN_MODE") != "release" {
+		if err := loadDotenv(); err != nil {
+			log.Printf("Warning: .env file not found, using default values")
+		}
+	}
+
+	var errs []FieldError
+	for _, f := range fields {
+		if f.def != "" || f.value.Kind() == reflect.Slice || f.value.Kind() == reflect.Map {
+			errs = append(errs, f.set(f.def, "default")...)
+		}
+	}
+
+	if flags.ConfigFile == "" {
+		flags.ConfigFile = os.Getenv("CONFIG_FILE")
+	}
+	if flags.ConfigFile != "" {
+		values, err := readFile(flags.ConfigFile)
+		if err != nil {
+			return nil, flags, err
+		}
+		errs = append(errs, applyFile(fields, values, "file "+flags.ConfigFile)...)
+	}
+
+	for _, f := range fields {
+		value, source, err := lookupEnv(f)
+		if err != nil {
+			errs = append(errs, FieldError{Key: f.key, Source: source, Message: err.Error()})
+		} else if source != "" {
+			errs = append(errs, f.set(value, source)...)
+		}
+	}
+
+	for _, fv := range flagValues {
+		errs = append(errs, fv.field.set(fv.value, "flag --"+fv.field.flag)...)
+	}
+
+	errs = append(errs, validate(cfg, fields, errs)...)
+	if len(errs) > 0 {
+		return nil, flags, &ValidationError{Errors: errs}
+	}
+	return cfg, flags, nil
+}
+
+var (
+	dotenvMu   sync.Mutex
+	dotenvKeys = map[string]bool{}
+)
+
+// loadDotenv copies .env into the process environment. Unlike godotenv.Load
+// it runs again on every reload: variables that came from an earlier read
+// are updated or removed, while variables set outside .env still win.
+func loadDotenv() error {
+	values, err := godotenv.Read()
+
+	dotenvMu.Lock()
+	defer dotenvMu.Unlock()
+	for key := range dotenvKeys {
+		if _, ok := values[key]; !ok {
+			os.Unsetenv(key)
+			delete(dotenvKeys, key)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	for key, value := range values {
+		if _, ok := os.LookupEnv(key); ok && !dotenvKeys[key] {
+			continue
+		}
+		os.Setenv(key, value)
+		dotenvKeys[key] = true
+	}
+	return nil
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// clearValue sets a list or map to empty, since an empty variable leaves it
+// unchanged.
+const clearValue = "-"
+
+type field struct {
+	key    string
+	env    string
+	flag   string
+	def    string
+	secret bool
+	value  reflect.Value
+	source string
+}
+
+func fieldsOf(v reflect.Value, prefix string) []*field {
+	var fields []*field
+	for i := 0; i < v.NumField(); i++ {
+		structField := v.Type().Field(i)
+		key := prefix + structField.Tag.Get("config")
+		if structField.Type.Kind() == reflect.Struct {
+			fields = append(fields, fieldsOf(v.Field(i), key+".")...)
+			continue
+		}
+		env := structField.Tag.Get("env")
+		fields = append(fields, &field{
+			key:    key,
+			env:    env,
+			flag:   strings.ReplaceAll(strings.ToLower(env), "_", "-"),
+			def:    structField.Tag.Get("default"),
+			secret: structField.Tag.Get("secret") == "true",
+			value:  v.Field(i),
+		})
+	}
+	return fields
+}
+
+func (f *field) set(raw string, source string) []FieldError {
+	if err := setValue(f.value, raw); err != nil {
+		return []FieldError{{Key: f.key, Source: source, Message: err.Error()}}
+	}
+	f.source = source
+	return nil
+}
+
+// lookupEnv returns the variable's value, or the content of the file named
+// by the same variable with a _FILE suffix, and the source it came from. An
+// empty variable counts as unset, so a copied .env.example does not override
+// the defaults or the config file; lists and maps are cleared with
+// clearValue instead.
+func lookupEnv(f *field) (string, string, error) {
+	value, ok := os.LookupEnv(f.env)
+	if ok && value == "" {
+		ok = false
+	}
+
+	path := os.Getenv(f.env + "_FILE")
+	switch {
+	case path != "" && ok:
+		return "", "env " + f.env, fmt.Errorf("both %s and %s_FILE are set", f.env, f.env)
+	case path != "":
+		source := "env " + f.env + "_FILE"
+		value, err := readSecretFile(path)
+		return value, source, err
+	case ok:
+		return value, "env " + f.env, nil
+	default:
+		return "", "", nil
+	}
+}
+
+func setValue(v reflect.Value, raw string) error {
+	switch {
+	case v.Type() == durationType:
+		duration, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q", raw)
+		}
+		v.SetInt(int64(duration))
+	case v.Kind() == reflect.String:
+		v.SetString(raw)
+	case v.Kind() == reflect.Bool:
+		boolValue, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", raw)
+		}
+		v.SetBool(boolValue)
+	case v.Kind() == reflect.Int:
+		intValue, err := strconv.Atoi(raw)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", raw)
+		}
+		v.SetInt(int64(intValue))
+	case v.Kind() == reflect.Float64:
+		floatValue, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", raw)
+		}
+		v.SetFloat(floatValue)
+	case v.Kind() == reflect.Slice && raw == clearValue:
+		v.Set(reflect.ValueOf([]string{}))
+	case v.Kind() == reflect.Slice:
+		v.Set(reflect.ValueOf(parseList(raw)))
+	case v.Kind() == reflect.Map && raw == clearValue:
+		v.Set(reflect.MakeMap(v.Type()))
+	case v.Kind() == reflect.Map:
+		items, err := parseMap(raw)
+		if err != nil {
+			return err
+		}
+		return setMap(v, items)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+// setMap sets v to items, parsing each value as v's element type.
+func setMap(v reflect.Value, items map[string]string) error {
+	m := reflect.MakeMapWithSize(v.Type(), len(items))
+	for k, raw := range items {
+		elem := reflect.New(v.Type().Elem()).Elem()
+		if err := setValue(elem, raw); err != nil {
+			return fmt.Errorf("%s for key %q", err, k)
+		}
+		m.SetMapIndex(reflect.ValueOf(k), elem)
+	}
+	v.Set(m)
+	return nil
+}
+
+func parseList(raw string) []string {
+	items := []string{}
+	for _, item := range strings.Split(raw, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseMap parses "key=value" pairs separated by semicolons, so values may
+// themselves contain commas (e.g. "public, max-age=60").
+func parseMap(raw string) (map[string]string, error) {
+	items := map[string]string{}
+	for _, pair := range strings.Split(raw, ";") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		k, v, found := strings.Cut(pair, "=")
+		if k = strings.TrimSpace(k); !found || k == "" {
+			return nil, fmt.Errorf("invalid entry %q, expected key=value", pair)
+		}
+		items[k] = strings.TrimSpace(v)
+	}
+	return items, nil
+}
+
+type flagValue struct {
+	field *field
+	value string
+}
+
+func parseFlags(args []string, fields []*field) (Flags, []flagValue, error) {
+	var flags Flags
+	var values []flagValue
+
+	flagSet := flag.NewFlagSet(filepath.Base(os.Args[0]), flag.ContinueOnError)
+	flagSet.StringVar(&flags.ConfigFile, "config", "", "path to a YAML or TOML config file (env CONFIG_FILE)")
+	flagSet.BoolVar(&flags.PrintConfig, "print-config", false, "print the resolved configuration with secrets redacted and exit")
+	for _, f := range fields {
+		flagSet.Func(f.flag, fmt.Sprintf("%s (env %s)", f.key, f.env), func(value string) error {
+			values = append(values, flagValue{field: f, value: value})
+			return nil
+		})
+	}
+
+	if err := flagSet.Parse(args); err != nil {
+		return flags, nil, err
+	}
+	if flagSet.NArg() > 0 {
+		return flags, nil, fmt.Errorf("unexpected argument %q", flagSet.Arg(0))
+	}
+	return flags, values, nil
+}
+
+func readFile(path string) (map[string]any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	values := map[string]any{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &values)
+	case ".toml":
+		err = toml.Unmarshal(data, &values)
+	default:
+		return nil, fmt.Errorf("unsupported config file format %q", filepath.Ext(path))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return values, nil
+}
+
+func applyFile(fields []*field, values map[string]any, source string) []FieldError {
+	known := map[string]bool{}
+	var errs []FieldError
+	for _, f := range fields {
+		known[f.key] = true
+		for prefix := f.key; strings.Contains(prefix, "."); {
+			prefix = prefix[:strings.LastIndex(prefix, ".")]
+			known[prefix] = true
+		}
+
+		raw, ok := lookupKey(values, f.key)
+		if !ok || raw == nil {
+			continue
+		}
+		if err := setFileValue(f.value, raw); err != nil {
+			errs = append(errs, FieldError{Key: f.key, Source: source, Message: err.Error()})
+			continue
+		}
+		f.source = source
+	}
+	return append(errs, unknownKeys(values, "", known, source)...)
+}
+
+func lookupKey(values map[string]any, key string) (any, bool) {
+	parts := strings.Split(key, ".")
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := values[part].(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		values = next
+	}
+	value, ok := values[parts[len(parts)-1]]
+	return value, ok
+}
+
+func setFileValue(v reflect.Value, raw any) error {
+	switch value := raw.(type) {
+	case []any:
+		if v.Kind() != reflect.Slice {
+			return fmt.Errorf("unexpected list")
+		}
+		items := []string{}
+		for _, item := range value {
+			items = append(items, fmt.Sprint(item))
+		}
+		v.Set(reflect.ValueOf(items))
+	case map[string]any:
+		if v.Kind() != reflect.Map {
+			return fmt.Errorf("unexpected mapping")
+		}
+		items := map[string]string{}
+		for k, item := range value {
+			items[k] = fmt.Sprint(item)
+		}
+		return setMap(v, items)
+	default:
+		return setValue(v, fmt.Sprint(value))
+	}
+	return nil
+}
+
+func unknownKeys(values map[string]any, prefix string, known map[string]bool, source string) []FieldError {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var errs []FieldError
+	for _, k := range keys {
+		key, value := prefix+k, values[k]
+		if !known[key] {
+			errs = append(errs, FieldError{Key: key, Source: source, Message: "unknown key"})
+			continue
+		}
+		if nested, ok := value.(map[string]any); ok && !isLeaf(key, known) {
+			errs = append(errs, unknownKeys(nested, key+".", known, source)...)
+		}
+	}
+	return errs
+}
+
+func isLeaf(key string, known map[string]bool) bool {
+	for k := range known {
+		if strings.HasPrefix(k, key+".") {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,359 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoad_YAMLFile(t *testing.T) {
+	clearEnvVars()
+	path := writeFile(t, "config.yaml", `
+serviceName: from-file
+database:
+  driver: sqlite
+  sqlitePath: /tmp/app.db
+  maxOpenConns: 5
+  connectBackoff: 1s
+  replicaDSNs:
+    - host=replica1
+    - host=replica2
+accessLog:
+  sampleRate: 0.5
+httpCache:
+  routeCacheControl:
+    /v1/author/:id: public, max-age=60
+request:
+  routeMaxBodyBytes:
+    /v1/book/: 4096
+`)
+
+	config := loadConfig(t, "--config", path)
+
+	assert.Equal(t, "from-file", config.ServiceName)
+	assert.Equal(t, "sqlite", config.Database.Driver)
+	assert.Equal(t, "/tmp/app.db", config.Database.SQLitePath)
+	assert.Equal(t, 5, config.Database.MaxOpenConns)
+	assert.Equal(t, time.Second, config.Database.ConnectBackoff)
+	assert.Equal(t, []string{"host=replica1", "host=replica2"}, config.Database.ReplicaDSNs)
+	assert.Equal(t, 0.5, config.AccessLog.SampleRate)
+	assert.Equal(t, map[string]string{"/v1/author/:id": "public, max-age=60"}, config.HTTPCache.RouteCacheControl)
+	assert.Equal(t, map[string]int{"/v1/book/": 4096}, config.Request.RouteMaxBodyBytes)
+	assert.Equal(t, 10, config.Database.MaxIdleConns)
+}
+
+func TestLoad_TOMLFile(t *testing.T) {
+	clearEnvVars()
+	path := writeFile(t, "config.toml", `
+serviceName = "from-file"
+
+[database]
+driver = "sqlite"
+maxOpenConns = 5
+statementTimeout = "10s"
+`)
+	t.Setenv("CONFIG_FILE", path)
+
+	config := loadConfig(t)
+
+	assert.Equal(t, "from-file", config.ServiceName)
+	assert.Equal(t, "sqlite", config.Database.Driver)
+	assert.Equal(t, 5, config.Database.MaxOpenConns)
+	assert.Equal(t, 10*time.Second, config.Database.StatementTimeout)
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	clearEnvVars()
+	path := writeFile(t, "config.yaml", `
+database:
+  driver: sqlite
+server:
+  port: "7000"
+  host: 127.0.0.1
+log:
+  level: warn
+`)
+	t.Setenv("SERVER_PORT", "8000")
+	t.Setenv("LOG_LEVEL", "error")
+	t.Setenv("SERVER_HOST", "")
+
+	config := loadConfig(t, "--config", path, "--server-port", "9000")
+
+	assert.Equal(t, "9000", config.Server.Port)
+	assert.Equal(t, "error", config.Log.Level)
+	assert.Equal(t, "127.0.0.1", config.Server.Host)
+}
+
+func TestLoad_ReportsEveryInvalidField(t *testing.T) {
+	clearEnvVars()
+	path := writeFile(t, "config.yaml", `
+server:
+  readTimeout: 30
+  unknown: true
+`)
+	t.Setenv("DB_MAX_OPEN_CONNS", "abc")
+	t.Setenv("LOG_FORMAT", "xml")
+	t.Setenv("TLS_ENABLED", "true")
+	t.Setenv("TLS_CLIENT_AUTH", "require")
+	t.Setenv("REQUEST_MAX_BODY_BYTES_ROUTES", "/v1/book/=4k")
+
+	config, _, err := Load([]string{"--config", path, "--tracing-sample-ratio", "2"})
+
+	assert.Nil(t, config)
+	var validationErr *ValidationError
+	require.True(t, errors.As(err, &validationErr))
+	assert.ElementsMatch(t, []FieldError{
+		{Key: "server.readTimeout", Source: "file " + path, Message: `invalid duration "30"`},
+		{Key: "server.unknown", Source: "file " + path, Message: "unknown key"},
+		{Key: "database.maxOpenConns", Source: "env DB_MAX_OPEN_CONNS", Message: `invalid integer "abc"`},
+		{Key: "database.user", Source: "default", Message: "is required when driver is postgres; set DB_USER or --db-user"},
+		{Key: "database.host", Source: "default", Message: "is required when driver is postgres; set DB_HOST or --db-host"},
+		{Key: "database.name", Source: "default", Message: "is required when driver is postgres; set DB_NAME or --db-name"},
+		{Key: "tls.certFile", Source: "default", Message: "is required when enabled is true; set TLS_CERT_FILE or --tls-cert-file"},
+		{Key: "tls.keyFile", Source: "default", Message: "is required when enabled is true; set TLS_KEY_FILE or --tls-key-file"},
+		{Key: "tls.clientCAFile", Source: "default", Message: "is required unless clientAuth is none; set TLS_CLIENT_CA_FILE or --tls-client-ca-file"},
+		{Key: "log.format", Source: "env LOG_FORMAT", Message: "must be one of text, json, logfmt"},
+		{Key: "request.routeMaxBodyBytes", Source: "env REQUEST_MAX_BODY_BYTES_ROUTES", Message: `invalid integer "4k" for key "/v1/book/"`},
+		{Key: "tracing.sampleRatio", Source: "flag --tracing-sample-ratio", Message: "must be at most 1"},
+	}, validationErr.Errors)
+	assert.Contains(t, err.Error(), "invalid configuration:\n")
+}
+
+func TestLoad_RouteMaxBodyBytes(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected FieldError
+	}{
+		{
+			name:     "not a number",
+			value:    "/v1/book/=4k",
+			expected: FieldError{Key: "request.routeMaxBodyBytes", Source: "env REQUEST_MAX_BODY_BYTES_ROUTES", Message: `invalid integer "4k" for key "/v1/book/"`},
+		},
+		{
+			name:     "zero",
+			value:    "/v1/book/=0",
+			expected: FieldError{Key: "request.routeMaxBodyBytes[/v1/book/]", Source: "env REQUEST_MAX_BODY_BYTES_ROUTES", Message: "must be greater than 0"},
+		},
+		{
+			name:     "negative",
+			value:    "/v1/author/=1024;/v1/book/=-1",
+			expected: FieldError{Key: "request.routeMaxBodyBytes[/v1/book/]", Source: "env REQUEST_MAX_BODY_BYTES_ROUTES", Message: "must be greater than 0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnvVars()
+			setRequiredEnv(t)
+			t.Setenv("REQUEST_MAX_BODY_BYTES_ROUTES", tt.value)
+
+			_, _, err := Load(nil)
+
+			var validationErr *ValidationError
+			require.True(t, errors.As(err, &validationErr))
+			assert.Equal(t, []FieldError{tt.expected}, validationErr.Errors)
+		})
+	}
+}
+
+func TestLoad_AdminTokenRequired(t *testing.T) {
+	clearEnvVars()
+	setRequiredEnv(t)
+	t.Setenv("ADMIN_ENABLED", "true")
+
+	_, _, err := Load(nil)
+
+	var validationErr *ValidationError
+	require.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, []FieldError{
+		{Key: "admin.token", Source: "default", Message: "is required when enabled is true; set ADMIN_TOKEN or --admin-token"},
+	}, validationErr.Errors)
+}
+
+func TestLoad_EmptyRedactionLists(t *testing.T) {
+	clearEnvVars()
+	setRequiredEnv(t)
+	t.Setenv("ACCESS_LOG_REDACT_HEADERS", "-")
+	t.Setenv("ACCESS_LOG_REDACT_FIELDS", "-")
+
+	_, _, err := Load(nil)
+
+	var validationErr *ValidationError
+	require.True(t, errors.As(err, &validationErr))
+	assert.ElementsMatch(t, []FieldError{
+		{Key: "accessLog.redactHeaders", Source: "env ACCESS_LOG_REDACT_HEADERS", Message: "must not be empty"},
+		{Key: "accessLog.redactBodyFields", Source: "env ACCESS_LOG_REDACT_FIELDS", Message: "must not be empty"},
+	}, validationErr.Errors)
+}
+
+func TestLoad_EmptyEnvKeepsFileValues(t *testing.T) {
+	clearEnvVars()
+	path := writeFile(t, "config.yaml", `
+database:
+  driver: sqlite
+  replicaDSNs:
+    - host=replica1
+cors:
+  allowOrigins:
+    - https://a.example
+features:
+  - beta
+httpCache:
+  routeCacheControl:
+    /v1/author/:id: public, max-age=60
+`)
+	t.Setenv("DB_REPLICA_DSNS", "")
+	t.Setenv("CORS_ALLOW_ORIGINS", "")
+	t.Setenv("FEATURE_FLAGS", "")
+	t.Setenv("HTTP_CACHE_CONTROL_ROUTES", "-")
+
+	config := loadConfig(t, "--config", path)
+
+	assert.Equal(t, []string{"host=replica1"}, config.Database.ReplicaDSNs)
+	assert.Equal(t, []string{"https://a.example"}, config.CORS.AllowOrigins)
+	assert.Equal(t, []string{"beta"}, config.Features)
+	assert.Empty(t, config.HTTPCache.RouteCacheControl)
+}
+
+func TestLoad_CORSCredentialsWithAnyOrigin(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		invalid bool
+	}{
+		{
+			name:    "wildcard origin",
+			env:     map[string]string{"GIN_MODE": "release", "CORS_ALLOW_ORIGINS": "https://a.example,*"},
+			invalid: true,
+		},
+		{
+			name:    "development preset in debug mode",
+			env:     map[string]string{"GIN_MODE": "debug"},
+			invalid: true,
+		},
+		{
+			name:    "explicit development preset",
+			env:     map[string]string{"GIN_MODE": "release", "CORS_PRESET": "development"},
+			invalid: true,
+		},
+		{
+			name: "listed origins",
+			env:  map[string]string{"GIN_MODE": "debug", "CORS_ALLOW_ORIGINS": "https://a.example"},
+		},
+		{
+			name: "production preset in release mode",
+			env:  map[string]string{"GIN_MODE": "release"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnvVars()
+			setRequiredEnv(t)
+			t.Setenv("CORS_ALLOW_CREDENTIALS", "true")
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			_, _, err := Load(nil)
+
+			if !tt.invalid {
+				assert.NoError(t, err)
+				return
+			}
+			var validationErr *ValidationError
+			require.True(t, errors.As(err, &validationErr))
+			assert.Equal(t, []FieldError{
+				{Key: "cors.allowCredentials", Source: "env CORS_ALLOW_CREDENTIALS", Message: "cannot be true when every origin is allowed; list the origins in allowOrigins"},
+			}, validationErr.Errors)
+		})
+	}
+}
+
+func TestLoad_RereadsDotenv(t *testing.T) {
+	clearEnvVars()
+	setRequiredEnv(t)
+	t.Setenv("LOG_FORMAT", "text")
+	t.Setenv("LOG_LEVEL", "")
+	os.Unsetenv("LOG_LEVEL")
+	t.Chdir(t.TempDir())
+
+	require.NoError(t, os.WriteFile(".env", []byte("LOG_LEVEL=warn\nLOG_FORMAT=json\n"), 0o600))
+	config := loadConfig(t)
+	assert.Equal(t, "warn", config.Log.Level)
+	assert.Equal(t, "text", config.Log.Format, "the process environment wins over .env")
+
+	require.NoError(t, os.WriteFile(".env", []byte("LOG_LEVEL=error\n"), 0o600))
+	config = loadConfig(t)
+	assert.Equal(t, "error", config.Log.Level)
+
+	require.NoError(t, os.Remove(".env"))
+	config = loadConfig(t)
+	assert.Equal(t, "info", config.Log.Level)
+	_, ok := os.LookupEnv("LOG_LEVEL")
+	assert.False(t, ok)
+}
+
+func TestLoad_Flags(t *testing.T) {
+	clearEnvVars()
+	setRequiredEnv(t)
+
+	_, flags, err := Load([]string{"--print-config"})
+	assert.NoError(t, err)
+	assert.True(t, flags.PrintConfig)
+
+	_, _, err = Load([]string{"-h"})
+	assert.ErrorIs(t, err, flag.ErrHelp)
+
+	_, _, err = Load([]string{"extra"})
+	assert.EqualError(t, err, `unexpected argument "extra"`)
+
+	_, _, err = Load([]string{"--config", "config.json"})
+	assert.Error(t, err)
+}
+
+func TestParseMap(t *testing.T) {
+	items, err := parseMap(" a = 1, 2 ;; b=")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"a": "1, 2", "b": ""}, items)
+
+	_, err = parseMap("a=1;invalid")
+	assert.EqualError(t, err, `invalid entry "invalid", expected key=value`)
+}
+
+func TestParseList(t *testing.T) {
+	assert.Equal(t, []string{"a", "b", "c"}, parseList(" a, b ,,c "))
+	assert.Equal(t, []string{}, parseList(""))
+}
+
+func TestLoad_FileIndirection(t *testing.T) {
+	clearEnvVars()
+	setRequiredEnv(t)
+	t.Setenv("DB_PASSWORD_FILE", writeFile(t, "db_password", "s3cret\n"))
+
+	config := loadConfig(t)
+	assert.Equal(t, "s3cret", config.Database.Password)
+	assert.Equal(t, os.Getenv("DB_PASSWORD_FILE"), config.Database.PasswordFile)
+
+	t.Setenv("DB_PASSWORD", "plain")
+	_, _, err := Load(nil)
+	assert.ErrorContains(t, err, "database.password: both DB_PASSWORD and DB_PASSWORD_FILE are set (from env DB_PASSWORD)")
+
+	t.Setenv("DB_PASSWORD", "")
+	t.Setenv("DB_PASSWORD_FILE", filepath.Join(t.TempDir(), "missing"))
+	_, _, err = Load(nil)
+	assert.ErrorContains(t, err, "database.password: failed to read secret")
+}
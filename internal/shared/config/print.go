@@ -0,0 +1,93 @@
+package config
+
+import (
+	"io"
+	"reflect"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const redacted = "[REDACTED]"
+
+// Print writes cfg as YAML in the config file layout, so the output can be
+// fed back through --config. Fields tagged secret are redacted.
+func Print(w io.Writer, cfg *Config) error {
+	node, err := toNode(reflect.ValueOf(cfg).Elem())
+	if err != nil {
+		return err
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(node); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// Redacted returns cfg as nested maps keyed like the config file, with
+// secrets redacted, for serving as JSON.
+func Redacted(cfg *Config) (map[string]any, error) {
+	node, err := toNode(reflect.ValueOf(cfg).Elem())
+	if err != nil {
+		return nil, err
+	}
+
+	values := map[string]any{}
+	if err := node.Decode(&values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+func toNode(v reflect.Value) (*yaml.Node, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	for i := 0; i < v.NumField(); i++ {
+		structField := v.Type().Field(i)
+
+		var value *yaml.Node
+		var err error
+		switch {
+		case structField.Type.Kind() == reflect.Struct:
+			value, err = toNode(v.Field(i))
+		case structField.Tag.Get("secret") == "true":
+			value, err = encodeNode(redact(v.Field(i)))
+		case structField.Type == durationType:
+			value, err = encodeNode(time.Duration(v.Field(i).Int()).String())
+		default:
+			value, err = encodeNode(v.Field(i).Interface())
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		key := &yaml.Node{Kind: yaml.ScalarNode, Value: structField.Tag.Get("config")}
+		node.Content = append(node.Content, key, value)
+	}
+	return node, nil
+}
+
+func encodeNode(value any) (*yaml.Node, error) {
+	node := &yaml.Node{}
+	if err := node.Encode(value); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+// redact masks non-empty values, keeping list lengths so it stays visible
+// how many entries are configured.
+func redact(v reflect.Value) any {
+	if v.Kind() == reflect.Slice {
+		items := make([]string, v.Len())
+		for i := range items {
+			items[i] = redacted
+		}
+		return items
+	}
+	if v.IsZero() {
+		return v.Interface()
+	}
+	return redacted
+}
@@ -0,0 +1,36 @@
+package config
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrint_RedactsSecrets(t *testing.T) {
+	clearEnvVars()
+	setRequiredEnv(t)
+	t.Setenv("DB_PASSWORD", "s3cret")
+	t.Setenv("DB_REPLICA_DSNS", "host=replica1 password=s3cret,host=replica2 password=s3cret")
+
+	config := loadConfig(t)
+
+	var buf bytes.Buffer
+	require.NoError(t, Print(&buf, config))
+
+	output := buf.String()
+	assert.NotContains(t, output, "s3cret")
+	assert.Contains(t, output, "  password: '[REDACTED]'\n")
+	assert.Contains(t, output, "  replicaDSNs:\n    - '[REDACTED]'\n    - '[REDACTED]'\n")
+	assert.Contains(t, output, "  connMaxLifetime: 30m0s\n")
+	assert.Contains(t, output, "serviceName: simple-gin-crud\n")
+
+	// The output is a valid config file.
+	path := writeFile(t, "config.yaml", output)
+	clearEnvVars()
+	setRequiredEnv(t)
+	reloaded := loadConfig(t, "--config", path)
+	assert.Equal(t, config.Database.ConnMaxLifetime, reloaded.Database.ConnMaxLifetime)
+	assert.Equal(t, config.AccessLog.SkipPaths, reloaded.AccessLog.SkipPaths)
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	SecretProviderEnv           = "env"
+	SecretProviderFile          = "file"
+	SecretProviderEncryptedFile = "encrypted-file"
+)
+
+// SecretProvider looks up secrets by name. Implementations read the source
+// on every call (or whenever it changed), so rotated secrets are picked up
+// without a restart.
+type SecretProvider interface {
+	Secret(ctx context.Context, name string) (string, error)
+}
+
+// NewSecretProvider builds the provider selected by cfg.Provider.
+func NewSecretProvider(cfg SecretsConfig) (SecretProvider, error) {
+	switch cfg.Provider {
+	case "", SecretProviderEnv:
+		return NewEnvSecretProvider(), nil
+	case SecretProviderFile:
+		return NewFileSecretProvider(cfg.Dir), nil
+	case SecretProviderEncryptedFile:
+		return NewEncryptedFileSecretProvider(cfg.File, cfg.Key)
+	default:
+		return nil, fmt.Errorf("unsupported secret provider %q", cfg.Provider)
+	}
+}
+
+type envSecretProvider struct{}
+
+// NewEnvSecretProvider reads the environment variable named after the secret.
+func NewEnvSecretProvider() SecretProvider {
+	return envSecretProvider{}
+}
+
+func (envSecretProvider) Secret(_ context.Context, name string) (string, error) {
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return "", fmt.Errorf("secret %q is not set", name)
+	}
+	return value, nil
+}
+
+type fileSecretProvider struct {
+	dir string
+}
+
+// NewFileSecretProvider reads each secret from the file of the same name in
+// dir, the layout used by Docker and Kubernetes secret mounts.
+func NewFileSecretProvider(dir string) SecretProvider {
+	return fileSecretProvider{dir: dir}
+}
+
+func (p fileSecretProvider) Secret(_ context.Context, name string) (string, error) {
+	if name == "" || name != filepath.Base(name) {
+		return "", fmt.Errorf("invalid secret name %q", name)
+	}
+	return readSecretFile(filepath.Join(p.dir, name))
+}
+
+// readSecretFile returns the file content without the trailing newline most
+// editors and `echo` add.
+func readSecretFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret: %w", err)
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
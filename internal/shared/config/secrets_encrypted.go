@@ -0,0 +1,133 @@
+package config
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// encryptedFileSecretProvider reads a YAML map of secret names to values
+// sealed with AES-256-GCM (see EncryptSecrets). The file is decrypted again
+// whenever its modification time or size changes.
+type encryptedFileSecretProvider struct {
+	path string
+	aead cipher.AEAD
+
+	mu      sync.Mutex
+	modTime time.Time
+	size    int64
+	secrets map[string]string
+}
+
+// NewEncryptedFileSecretProvider opens path with key, a base64 encoded
+// 32 byte key.
+func NewEncryptedFileSecretProvider(path string, key string) (SecretProvider, error) {
+	aead, err := newSecretsAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	return &encryptedFileSecretProvider{path: path, aead: aead}, nil
+}
+
+func (p *encryptedFileSecretProvider) Secret(_ context.Context, name string) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	info, err := os.Stat(p.path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read secrets file: %w", err)
+	}
+	if p.secrets == nil || !info.ModTime().Equal(p.modTime) || info.Size() != p.size {
+		data, err := os.ReadFile(p.path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read secrets file: %w", err)
+		}
+		secrets, err := decryptSecrets(p.aead, data)
+		if err != nil {
+			return "", err
+		}
+		p.secrets, p.modTime, p.size = secrets, info.ModTime(), info.Size()
+	}
+
+	value, ok := p.secrets[name]
+	if !ok {
+		return "", fmt.Errorf("secret %q is not set", name)
+	}
+	return value, nil
+}
+
+// GenerateSecretsKey returns a new base64 encoded key for EncryptSecrets.
+func GenerateSecretsKey() (string, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+// EncryptSecrets seals a YAML map of secrets with key into the format read
+// by the encrypted-file provider: base64 of the nonce followed by the
+// ciphertext.
+func EncryptSecrets(plaintext []byte, key string) ([]byte, error) {
+	aead, err := newSecretsAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := parseSecrets(plaintext); err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	sealed := aead.Seal(nonce, nonce, plaintext, nil)
+	return []byte(base64.StdEncoding.EncodeToString(sealed) + "\n"), nil
+}
+
+func decryptSecrets(aead cipher.AEAD, data []byte) (map[string]string, error) {
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid secrets file: %w", err)
+	}
+	if len(sealed) < aead.NonceSize() {
+		return nil, errors.New("invalid secrets file: too short")
+	}
+
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, errors.New("failed to decrypt secrets file: wrong key or corrupted file")
+	}
+	return parseSecrets(plaintext)
+}
+
+func parseSecrets(plaintext []byte) (map[string]string, error) {
+	secrets := map[string]string{}
+	if err := yaml.Unmarshal(plaintext, &secrets); err != nil {
+		return nil, fmt.Errorf("invalid secrets: %w", err)
+	}
+	return secrets, nil
+}
+
+func newSecretsAEAD(key string) (cipher.AEAD, error) {
+	rawKey, err := base64.StdEncoding.DecodeString(strings.TrimSpace(key))
+	if err != nil || len(rawKey) != 32 {
+		return nil, errors.New("secrets key must be 32 bytes, base64 encoded")
+	}
+	block, err := aes.NewCipher(rawKey)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
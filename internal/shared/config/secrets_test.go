@@ -0,0 +1,126 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvSecretProvider(t *testing.T) {
+	t.Setenv("TEST_SECRET", "value")
+	provider := NewEnvSecretProvider()
+
+	value, err := provider.Secret(context.Background(), "TEST_SECRET")
+	assert.NoError(t, err)
+	assert.Equal(t, "value", value)
+
+	_, err = provider.Secret(context.Background(), "MISSING_SECRET")
+	assert.EqualError(t, err, `secret "MISSING_SECRET" is not set`)
+}
+
+func TestFileSecretProvider(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "db_password")
+	require.NoError(t, os.WriteFile(path, []byte("first\n"), 0o600))
+	provider := NewFileSecretProvider(dir)
+
+	value, err := provider.Secret(context.Background(), "db_password")
+	assert.NoError(t, err)
+	assert.Equal(t, "first", value)
+
+	// Rotation is picked up on the next lookup.
+	require.NoError(t, os.WriteFile(path, []byte("second"), 0o600))
+	value, err = provider.Secret(context.Background(), "db_password")
+	assert.NoError(t, err)
+	assert.Equal(t, "second", value)
+
+	_, err = provider.Secret(context.Background(), "../db_password")
+	assert.EqualError(t, err, `invalid secret name "../db_password"`)
+
+	_, err = provider.Secret(context.Background(), "missing")
+	assert.Error(t, err)
+}
+
+func TestEncryptedFileSecretProvider(t *testing.T) {
+	key, err := GenerateSecretsKey()
+	require.NoError(t, err)
+
+	sealed, err := EncryptSecrets([]byte("db_password: first\n"), key)
+	require.NoError(t, err)
+	assert.NotContains(t, string(sealed), "first")
+	path := writeFile(t, "secrets.enc", string(sealed))
+
+	provider, err := NewEncryptedFileSecretProvider(path, key)
+	require.NoError(t, err)
+
+	value, err := provider.Secret(context.Background(), "db_password")
+	assert.NoError(t, err)
+	assert.Equal(t, "first", value)
+
+	_, err = provider.Secret(context.Background(), "missing")
+	assert.EqualError(t, err, `secret "missing" is not set`)
+
+	sealed, err = EncryptSecrets([]byte("db_password: second\n"), key)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, sealed, 0o600))
+	require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Second)))
+
+	value, err = provider.Secret(context.Background(), "db_password")
+	assert.NoError(t, err)
+	assert.Equal(t, "second", value)
+
+	otherKey, err := GenerateSecretsKey()
+	require.NoError(t, err)
+	provider, err = NewEncryptedFileSecretProvider(path, otherKey)
+	require.NoError(t, err)
+	_, err = provider.Secret(context.Background(), "db_password")
+	assert.EqualError(t, err, "failed to decrypt secrets file: wrong key or corrupted file")
+}
+
+func TestEncryptSecrets_InvalidInput(t *testing.T) {
+	_, err := EncryptSecrets([]byte("a: b"), "short")
+	assert.EqualError(t, err, "secrets key must be 32 bytes, base64 encoded")
+
+	key, err := GenerateSecretsKey()
+	require.NoError(t, err)
+	_, err = EncryptSecrets([]byte("- not a map"), key)
+	assert.Error(t, err)
+}
+
+func TestNewSecretProvider(t *testing.T) {
+	key, err := GenerateSecretsKey()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		cfg         SecretsConfig
+		expected    SecretProvider
+		expectedErr string
+	}{
+		{name: "env", cfg: SecretsConfig{Provider: SecretProviderEnv}, expected: envSecretProvider{}},
+		{name: "file", cfg: SecretsConfig{Provider: SecretProviderFile, Dir: "/run/secrets"}, expected: fileSecretProvider{dir: "/run/secrets"}},
+		{name: "encrypted file", cfg: SecretsConfig{Provider: SecretProviderEncryptedFile, File: "secrets.enc", Key: key}},
+		{name: "unsupported", cfg: SecretsConfig{Provider: "vault"}, expectedErr: `unsupported secret provider "vault"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, err := NewSecretProvider(tt.cfg)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			if tt.expected != nil {
+				assert.Equal(t, tt.expected, provider)
+			} else {
+				assert.IsType(t, &encryptedFileSecretProvider{}, provider)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type FieldError struct {
+	Key     string
+	Source  string
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s: %s (from %s)", e.Key, e.Message, e.Source)
+}
+
+type ValidationError struct {
+	Errors []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	lines := []string{"invalid configuration:"}
+	for _, err := range e.Errors {
+		lines = append(lines, "  "+err.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// validate checks the struct tag rules, skipping fields that already failed
+// to parse so each field is reported once.
+func validate(cfg *Config, fields []*field, parseErrs []FieldError) []FieldError {
+	validate := validator.New()
+	validate.RegisterTagNameFunc(func(structField reflect.StructField) string {
+		return structField.Tag.Get("config")
+	})
+	validate.RegisterStructValidation(validateCORS, Config{})
+
+	err := validate.Struct(cfg)
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return nil
+	}
+
+	byKey := map[string]*field{}
+	for _, f := range fields {
+		byKey[f.key] = f
+	}
+	failed := map[string]bool{}
+	for _, parseErr := range parseErrs {
+		failed[parseErr.Key] = true
+	}
+
+	var errs []FieldError
+	for _, validationErr := range validationErrors {
+		_, key, _ := strings.Cut(validationErr.Namespace(), ".")
+		// Entries of lists and maps, e.g. "routes[/v1/book/]", belong to
+		// the field holding them.
+		fieldKey, _, _ := strings.Cut(key, "[")
+		if failed[fieldKey] {
+			continue
+		}
+		fieldErr := FieldError{Key: key, Source: "default", Message: ruleMessage(validationErr)}
+		if f, ok := byKey[fieldKey]; ok {
+			if f.source != "" {
+				fieldErr.Source = f.source
+			}
+			if fieldErr.Source == "default" {
+				fieldErr.Message += fmt.Sprintf("; set %s or --%s", f.env, f.flag)
+			}
+		}
+		errs = append(errs, fieldErr)
+	}
+	return errs
+}
+
+// validateCORS rejects credentials when every origin is allowed, either by
+// listing "*" or through the development preset, which allows every origin
+// when AllowOrigins is empty.
+func validateCORS(sl validator.StructLevel) {
+	cfg := sl.Current().Interface().(Config)
+	if !cfg.CORS.AllowCredentials {
+		return
+	}
+
+	anyOrigin := slices.Contains(cfg.CORS.AllowOrigins, "*")
+	if len(cfg.CORS.AllowOrigins) == 0 {
+		preset := cfg.CORS.Preset
+		if preset == "" && cfg.Mode != "release" {
+			preset = "development"
+		}
+		anyOrigin = preset == "development"
+	}
+	if anyOrigin {
+		sl.ReportError(cfg.CORS.AllowCredentials, "cors.allowCredentials", "AllowCredentials", "cors_any_origin", "")
+	}
+}
+
+func ruleMessage(err validator.FieldError) string {
+	switch err.Tag() {
+	case "required":
+		return "is required"
+	case "required_if":
+		params := strings.Fields(err.Param())
+		return fmt.Sprintf("is required when %s is %s", lowerFirst(params[0]), strings.Join(params[1:], " "))
+	case "required_unless":
+		params := strings.Fields(err.Param())
+		return fmt.Sprintf("is required unless %s is %s", lowerFirst(params[0]), strings.Join(params[1:], " "))
+	case "oneof":
+		return "must be one of " + strings.ReplaceAll(err.Param(), " ", ", ")
+	case "gt":
+		return "must be greater than " + err.Param()
+	case "gte":
+		return "must be at least " + err.Param()
+	case "min":
+		if err.Param() == "1" {
+			return "must not be empty"
+		}
+		return fmt.Sprintf("must have at least %s entries", err.Param())
+	case "lte":
+		return "must be at most " + err.Param()
+	case "gtefield":
+		return "must be at least " + lowerFirst(err.Param())
+	case "numeric":
+		return "must be numeric"
+	case "startswith":
+		return fmt.Sprintf("must start with %q", err.Param())
+	case "cors_any_origin":
+		return "cannot be true when every origin is allowed; list the origins in allowOrigins"
+	case "timezone":
+		return "must be a valid time zone"
+	default:
+		return fmt.Sprintf("failed %s validation", err.Tag())
+	}
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
@@ -0,0 +1,162 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"reflect"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+var ErrReloadDisabled = errors.New("configuration reload is not enabled")
+
+// Watcher holds the effective configuration and reloads it on demand, on
+// SIGHUP or when the config file changes. Only fields tagged reload are
+// updated; other changes are logged and wait for a restart.
+type Watcher struct {
+	load   func() (*Config, error)
+	logger *logrus.Logger
+
+	reloadMu    sync.Mutex
+	mu          sync.RWMutex
+	current     *Config
+	reloadedAt  time.Time
+	subscribers []func(old, new *Config)
+}
+
+// NewWatcher starts from cfg and reloads through load, typically a closure
+// over Load with the original arguments. A nil load disables reloading.
+func NewWatcher(cfg *Config, load func() (*Config, error), logger *logrus.Logger) *Watcher {
+	return &Watcher{
+		load:       load,
+		logger:     logger,
+		current:    cfg,
+		reloadedAt: time.Now(),
+	}
+}
+
+// Current returns the effective configuration. It must not be modified.
+func (w *Watcher) Current() *Config {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.current
+}
+
+// ReloadedAt returns when the configuration was loaded or last reloaded.
+func (w *Watcher) ReloadedAt() time.Time {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.reloadedAt
+}
+
+// Watch calls fn with the value selected from the configuration every time a
+// reload changes it.
+func Watch[T any](w *Watcher, selector func(cfg *Config) T, fn func(value T)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.subscribers = append(w.subscribers, func(old, new *Config) {
+		if value := selector(new); !reflect.DeepEqual(selector(old), value) {
+			fn(value)
+		}
+	})
+}
+
+// Reload loads the configuration again and applies its reloadable fields.
+// On error the current configuration is kept.
+func (w *Watcher) Reload() error {
+	if w.load == nil {
+		return ErrReloadDisabled
+	}
+
+	w.reloadMu.Lock()
+	defer w.reloadMu.Unlock()
+
+	next, err := w.load()
+	if err != nil {
+		w.logger.Errorf("[ConfigWatcher] Failed to reload configuration: %v", err)
+		return err
+	}
+
+	old := w.Current()
+	merged := *old
+	pending := applyReloadable(reflect.ValueOf(&merged).Elem(), reflect.ValueOf(next).Elem(), "")
+
+	w.mu.Lock()
+	w.current = &merged
+	w.reloadedAt = time.Now()
+	subscribers := append([]func(old, new *Config){}, w.subscribers...)
+	w.mu.Unlock()
+
+	for _, key := range pending {
+		w.logger.Warnf("[ConfigWatcher] %s changed but requires a restart to take effect", key)
+	}
+	w.logger.Info("[ConfigWatcher] Configuration reloaded")
+
+	for _, subscriber := range subscribers {
+		subscriber(old, &merged)
+	}
+	return nil
+}
+
+// Run reloads on SIGHUP and, when path is set and interval positive, when
+// the file's modification time or size changes. It blocks until ctx is done.
+func (w *Watcher) Run(ctx context.Context, path string, interval time.Duration) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	defer signal.Stop(signals)
+
+	var ticks <-chan time.Time
+	var last os.FileInfo
+	if path != "" && interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		ticks = ticker.C
+		last, _ = os.Stat(path)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-signals:
+			w.logger.Info("[ConfigWatcher] SIGHUP received, reloading configuration")
+			_ = w.Reload()
+		case <-ticks:
+			info, err := os.Stat(path)
+			if err != nil || fileUnchanged(last, info) {
+				continue
+			}
+			last = info
+			w.logger.Infof("[ConfigWatcher] %s changed, reloading configuration", path)
+			_ = w.Reload()
+		}
+	}
+}
+
+func fileUnchanged(last, current os.FileInfo) bool {
+	return last != nil && last.ModTime().Equal(current.ModTime()) && last.Size() == current.Size()
+}
+
+// applyReloadable copies reloadable fields from next into dst and returns
+// the keys of other fields that differ.
+func applyReloadable(dst, next reflect.Value, prefix string) []string {
+	var pending []string
+	for i := 0; i < dst.NumField(); i++ {
+		structField := dst.Type().Field(i)
+		key := prefix + structField.Tag.Get("config")
+		switch {
+		case structField.Type.Kind() == reflect.Struct:
+			pending = append(pending, applyReloadable(dst.Field(i), next.Field(i), key+".")...)
+		case structField.Tag.Get("reload") == "true":
+			dst.Field(i).Set(next.Field(i))
+		case !reflect.DeepEqual(dst.Field(i).Interface(), next.Field(i).Interface()):
+			pending = append(pending, key)
+		}
+	}
+	return pending
+}
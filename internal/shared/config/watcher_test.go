@@ -0,0 +1,95 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestWatcher(t *testing.T, cfg *Config, load func() (*Config, error)) (*Watcher, *bytes.Buffer) {
+	var buf bytes.Buffer
+	logger := logrus.New()
+	logger.SetOutput(&buf)
+	return NewWatcher(cfg, load, logger), &buf
+}
+
+func TestWatcher_Reload(t *testing.T) {
+	initial := &Config{
+		Log:    LogConfig{Level: "info", Format: "text"},
+		Server: ServerConfig{Port: "8080"},
+		CORS:   CORSConfig{AllowOrigins: []string{"*"}},
+	}
+	next := &Config{
+		Log:      LogConfig{Level: "debug", Format: "json"},
+		Server:   ServerConfig{Port: "8080"},
+		CORS:     CORSConfig{AllowOrigins: []string{"*"}},
+		Features: []string{"beta"},
+	}
+	watcher, logs := newTestWatcher(t, initial, func() (*Config, error) { return next, nil })
+	loadedAt := watcher.ReloadedAt()
+
+	var levels []string
+	Watch(watcher, func(cfg *Config) string { return cfg.Log.Level }, func(level string) {
+		levels = append(levels, level)
+	})
+	var originChanges int
+	Watch(watcher, func(cfg *Config) []string { return cfg.CORS.AllowOrigins }, func([]string) {
+		originChanges++
+	})
+
+	require.NoError(t, watcher.Reload())
+
+	current := watcher.Current()
+	assert.Equal(t, "debug", current.Log.Level)
+	assert.True(t, current.FeatureEnabled("beta"))
+	assert.Equal(t, "text", current.Log.Format, "non-reloadable fields keep their value")
+	assert.Equal(t, "info", initial.Log.Level, "the previous config is not modified")
+	assert.Equal(t, []string{"debug"}, levels)
+	assert.Equal(t, 0, originChanges)
+	assert.False(t, watcher.ReloadedAt().Before(loadedAt))
+	assert.Contains(t, logs.String(), "log.format changed but requires a restart")
+
+	// Reloading unchanged values notifies nobody.
+	require.NoError(t, watcher.Reload())
+	assert.Equal(t, []string{"debug"}, levels)
+}
+
+func TestWatcher_ReloadErrorKeepsCurrent(t *testing.T) {
+	initial := &Config{Log: LogConfig{Level: "info"}}
+	watcher, _ := newTestWatcher(t, initial, func() (*Config, error) { return nil, errors.New("invalid") })
+
+	assert.EqualError(t, watcher.Reload(), "invalid")
+	assert.Same(t, initial, watcher.Current())
+
+	watcher, _ = newTestWatcher(t, initial, nil)
+	assert.ErrorIs(t, watcher.Reload(), ErrReloadDisabled)
+}
+
+func TestWatcher_RunReloadsOnFileChange(t *testing.T) {
+	path := writeFile(t, "config.yaml", "log:\n  level: info\n")
+	reloaded := make(chan struct{}, 1)
+	watcher, _ := newTestWatcher(t, &Config{}, func() (*Config, error) {
+		reloaded <- struct{}{}
+		return &Config{Log: LogConfig{Level: "debug"}}, nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go watcher.Run(ctx, path, 10*time.Millisecond)
+
+	time.Sleep(30 * time.Millisecond)
+	require.NoError(t, os.WriteFile(path, []byte("log:\n  level: debug\n"), 0o600))
+
+	select {
+	case <-reloaded:
+	case <-time.After(time.Second):
+		t.Fatal("configuration was not reloaded")
+	}
+}
@@ -1,6 +1,7 @@
 package dto
 
 import (
+	"github.com/gin-gonic/gin"
 	english "github.com/go-playground/locales/en"
 	ut "github.com/go-playground/universal-translator"
 	"github.com/go-playground/validator/v10"
@@ -20,6 +21,13 @@ func BuildBaseResponse(code Code, data interface{}) *BaseResponse {
 	}
 }
 
+// Respond writes a BaseResponse with the message in the request's locale.
+func Respond(c *gin.Context, status int, code Code, data interface{}) {
+	response := BuildBaseResponse(code, data)
+	response.Message = Message(c.Request.Context(), code)
+	c.JSON(status, response)
+}
+
 func BuildValidationErrorResponse(err error) *BaseResponse {
 	eng := english.New()
 	uni := ut.New(eng, eng)
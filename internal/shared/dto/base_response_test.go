@@ -3,6 +3,7 @@ package dto
 import (
 	"testing"
 
+	"github.com/sirawatc/simple-gin-crud/pkg/validator"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -102,3 +103,11 @@ func TestNewBaseResponse(t *testing.T) {
 		})
 	}
 }
+
+func TestCodeMessages_CoverEveryCode(t *testing.T) {
+	for _, locale := range validator.Locales {
+		for code := range CodeMessage {
+			assert.NotEmpty(t, codeMessages[locale][code], "%s %s", locale, code)
+		}
+	}
+}
@@ -1,23 +1,30 @@
 package dto
 
 import (
+	"context"
 	"net/http"
 	"strconv"
+
+	"github.com/sirawatc/simple-gin-crud/pkg/validator"
 )
 
 type Code string
 
 // Standard response codes
 const (
-	Success             Code = "20000"
-	Updated             Code = "20010"
-	Deleted             Code = "20020"
-	Created             Code = "20100"
-	BadRequest          Code = "40000"
-	NotFound            Code = "40400"
-	Conflict            Code = "40900"
-	UnprocessableEntity Code = "42200"
-	InternalError       Code = "50000"
+	Success               Code = "20000"
+	Updated               Code = "20010"
+	Deleted               Code = "20020"
+	Created               Code = "20100"
+	BadRequest            Code = "40000"
+	Unauthorized          Code = "40100"
+	NotFound              Code = "40400"
+	Conflict              Code = "40900"
+	RequestEntityTooLarge Code = "41300"
+	UnsupportedMediaType  Code = "41500"
+	UnprocessableEntity   Code = "42200"
+	TooManyRequests       Code = "42900"
+	InternalError         Code = "50000"
 )
 
 // Custom response codes
@@ -34,14 +41,19 @@ const (
 )
 
 var CodeMessage = map[Code]string{
-	Success:             "Success",
-	Updated:             "Updated successfully",
-	Deleted:             "Deleted successfully",
-	Created:             "Created successfully",
-	BadRequest:          "Bad Request",
-	NotFound:            "Not Found",
-	UnprocessableEntity: "Unprocessable Entity",
-	InternalError:       "Internal Server Error",
+	Success:               "Success",
+	Updated:               "Updated successfully",
+	Deleted:               "Deleted successfully",
+	Created:               "Created successfully",
+	BadRequest:            "Bad Request",
+	Unauthorized:          "Unauthorized",
+	NotFound:              "Not Found",
+	Conflict:              "Conflict",
+	RequestEntityTooLarge: "Request Entity Too Large",
+	UnsupportedMediaType:  "Unsupported Media Type",
+	UnprocessableEntity:   "Unprocessable Entity",
+	TooManyRequests:       "Too Many Requests",
+	InternalError:         "Internal Server Error",
 
 	// Custom response codes
 	BindingError:        "JSON parse error",
@@ -53,6 +65,46 @@ var CodeMessage = map[Code]string{
 	AuthorAlreadyExists: "Author already exists",
 }
 
+var thaiCodeMessage = map[Code]string{
+	Success:               "สำเร็จ",
+	Updated:               "อัปเดตสำเร็จ",
+	Deleted:               "ลบสำเร็จ",
+	Created:               "สร้างสำเร็จ",
+	BadRequest:            "คำขอไม่ถูกต้อง",
+	Unauthorized:          "ไม่ได้รับอนุญาต",
+	NotFound:              "ไม่พบข้อมูล",
+	Conflict:              "ข้อมูลขัดแย้ง",
+	RequestEntityTooLarge: "เนื้อหาคำขอมีขนาดใหญ่เกินไป",
+	UnsupportedMediaType:  "ไม่รองรับประเภทเนื้อหานี้",
+	UnprocessableEntity:   "ไม่สามารถประมวลผลข้อมูลได้",
+	TooManyRequests:       "มีคำขอมากเกินไป",
+	InternalError:         "เกิดข้อผิดพลาดภายในระบบ",
+
+	// Custom response codes
+	BindingError:        "รูปแบบ JSON ไม่ถูกต้อง",
+	UUIDFormatInvalid:   "รูปแบบ UUID ไม่ถูกต้อง",
+	BookNotFound:        "ไม่พบหนังสือ",
+	AuthorNotFound:      "ไม่พบผู้แต่ง",
+	ValidationError:     "ข้อมูลไม่ผ่านการตรวจสอบ",
+	BookAlreadyExists:   "มีหนังสือนี้อยู่แล้ว",
+	AuthorAlreadyExists: "มีผู้แต่งนี้อยู่แล้ว",
+}
+
+// codeMessages holds the message catalogue of each supported locale.
+var codeMessages = map[string]map[Code]string{
+	"en": CodeMessage,
+	"th": thaiCodeMessage,
+}
+
+// Message returns the message of code in the locale chosen for the request
+// in ctx, falling back to English.
+func Message(ctx context.Context, code Code) string {
+	if message, ok := codeMessages[validator.Locale(ctx)][code]; ok {
+		return message
+	}
+	return CodeMessage[code]
+}
+
 func (c Code) GetHTTPCode() int {
 	if len(c) < 3 {
 		return http.StatusInternalServerError
@@ -0,0 +1,164 @@
+package dto
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirawatc/simple-gin-crud/pkg/metrics"
+	"github.com/sirawatc/simple-gin-crud/pkg/middleware"
+	"github.com/sirawatc/simple-gin-crud/pkg/validator"
+)
+
+const ProblemContentType = "application/problem+json"
+
+// ProblemTypeBaseURI prefixes the type of every Problem. It is a relative
+// reference, resolved against the API's own URL.
+var ProblemTypeBaseURI = "/problems/"
+
+var problemTypes = map[Code]string{
+	BadRequest:            "bad-request",
+	Unauthorized:          "unauthorized",
+	NotFound:              "not-found",
+	Conflict:              "conflict",
+	RequestEntityTooLarge: "request-entity-too-large",
+	UnsupportedMediaType:  "unsupported-media-type",
+	UnprocessableEntity:   "unprocessable-entity",
+	TooManyRequests:       "too-many-requests",
+	InternalError:         "internal-error",
+
+	BindingError:        "binding-error",
+	UUIDFormatInvalid:   "uuid-format-invalid",
+	ValidationError:     "validation-error",
+	BookNotFound:        "book-not-found",
+	AuthorNotFound:      "author-not-found",
+	BookAlreadyExists:   "book-already-exists",
+	AuthorAlreadyExists: "author-already-exists",
+}
+
+// Problem is an RFC 7807 problem details object, extended with the response
+// code, the request ID and any validation errors.
+type Problem struct {
+	Type      string                 `json:"type"`
+	Title     string                 `json:"title"`
+	Status    int                    `json:"status"`
+	Detail    string                 `json:"detail,omitempty"`
+	Instance  string                 `json:"instance,omitempty"`
+	Code      Code                   `json:"code"`
+	RequestID string                 `json:"requestId,omitempty"`
+	Errors    []validator.FieldError `json:"errors,omitempty"`
+}
+
+// ProblemType returns the type URI of code, or "about:blank" for codes
+// without a specific problem type.
+func ProblemType(code Code) string {
+	if slug, ok := problemTypes[code]; ok {
+		return ProblemTypeBaseURI + slug
+	}
+	return "about:blank"
+}
+
+// BuildProblem describes code as a Problem. data is used as the detail when
+// it is a string or a list of strings, and as the errors when it is
+// validator.Errors.
+func BuildProblem(code Code, status int, data interface{}) *Problem {
+	problem := &Problem{
+		Type:   ProblemType(code),
+		Title:  CodeMessage[code],
+		Status: status,
+		Code:   code,
+	}
+
+	switch data := data.(type) {
+	case string:
+		problem.Detail = data
+	case []string:
+		problem.Detail = strings.Join(data, "; ")
+	case validator.Errors:
+		problem.Errors = data
+	}
+	return problem
+}
+
+// WantsProblem reports whether the Accept header prefers
+// application/problem+json over application/json.
+func WantsProblem(accept string) bool {
+	problemQ, jsonQ := 0.0, 0.0
+	for _, mediaRange := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(mediaRange))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if value, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(value, 64); err != nil {
+				continue
+			}
+		}
+		switch mediaType {
+		case ProblemContentType:
+			problemQ = max(problemQ, q)
+		case "application/json":
+			jsonQ = max(jsonQ, q)
+		}
+	}
+	return problemQ > 0 && problemQ >= jsonQ
+}
+
+// RespondError writes an error response: a Problem when the client accepts
+// application/problem+json and a BaseResponse otherwise. Either way the
+// message is in the request's locale.
+func RespondError(c *gin.Context, status int, code Code, data interface{}) {
+	c.Writer.Header().Add("Vary", "Accept")
+	if !WantsProblem(c.GetHeader("Accept")) {
+		Respond(c, status, code, data)
+		return
+	}
+
+	problem := BuildProblem(code, status, data)
+	problem.Title = Message(c.Request.Context(), code)
+	problem.Instance = c.Request.URL.Path
+	problem.RequestID = middleware.GetRequestID(c.Request.Context())
+	metrics.SetResponseCode(c, string(code))
+	c.Render(status, problemRender{problem: problem})
+}
+
+// RespondBindingError writes errors from binding a request body: 413 when
+// the body is over the size limit and 400 otherwise.
+func RespondBindingError(c *gin.Context, errors validator.Errors) {
+	for _, fieldErr := range errors {
+		if fieldErr.Rule == validator.RuleMaxBytes {
+			RespondError(c, http.StatusRequestEntityTooLarge, RequestEntityTooLarge, errors)
+			return
+		}
+	}
+	RespondError(c, http.StatusBadRequest, BindingError, errors)
+}
+
+// AbortWithError is RespondError for middleware; it also stops the handler
+// chain.
+func AbortWithError(c *gin.Context, status int, code Code, data interface{}) {
+	c.Abort()
+	RespondError(c, status, code, data)
+}
+
+type problemRender struct {
+	problem *Problem
+}
+
+func (r problemRender) Render(w http.ResponseWriter) error {
+	r.WriteContentType(w)
+	body, err := json.Marshal(r.problem)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(body)
+	return err
+}
+
+func (r problemRender) WriteContentType(w http.ResponseWriter) {
+	w.Header()["Content-Type"] = []string{ProblemContentType}
+}
@@ -0,0 +1,250 @@
+package dto
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirawatc/simple-gin-crud/pkg/metrics"
+	"github.com/sirawatc/simple-gin-crud/pkg/middleware"
+	"github.com/sirawatc/simple-gin-crud/pkg/validator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWantsProblem(t *testing.T) {
+	tests := []struct {
+		accept   string
+		expected bool
+	}{
+		{accept: "", expected: false},
+		{accept: "*/*", expected: false},
+		{accept: "application/json", expected: false},
+		{accept: "application/problem+json", expected: true},
+		{accept: "application/problem+json, application/json", expected: true},
+		{accept: "application/json, application/problem+json;q=0.9", expected: false},
+		{accept: "application/json;q=0.5, application/problem+json", expected: true},
+		{accept: "application/problem+json;q=0", expected: false},
+		{accept: "application/problem+json;q=abc", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			assert.Equal(t, tt.expected, WantsProblem(tt.accept))
+		})
+	}
+}
+
+func TestProblemType_CoversEveryErrorCode(t *testing.T) {
+	for code := range CodeMessage {
+		if code.GetHTTPCode() < http.StatusBadRequest {
+			assert.Equal(t, "about:blank", ProblemType(code))
+			continue
+		}
+		assert.NotEqual(t, "about:blank", ProblemType(code), code)
+	}
+}
+
+func TestBuildProblem(t *testing.T) {
+	errors := validator.Errors{{Field: "penName", Rule: "required", Message: "PenName is a required field"}}
+
+	tests := []struct {
+		name     string
+		code     Code
+		status   int
+		data     interface{}
+		expected *Problem
+	}{
+		{
+			name:   "string detail",
+			code:   BindingError,
+			status: http.StatusBadRequest,
+			data:   "unexpected EOF",
+			expected: &Problem{
+				Type:   "/problems/binding-error",
+				Title:  CodeMessage[BindingError],
+				Status: http.StatusBadRequest,
+				Detail: "unexpected EOF",
+				Code:   BindingError,
+			},
+		},
+		{
+			name:   "list detail",
+			code:   UnprocessableEntity,
+			status: http.StatusUnprocessableEntity,
+			data:   []string{"first", "second"},
+			expected: &Problem{
+				Type:   "/problems/unprocessable-entity",
+				Title:  CodeMessage[UnprocessableEntity],
+				Status: http.StatusUnprocessableEntity,
+				Detail: "first; second",
+				Code:   UnprocessableEntity,
+			},
+		},
+		{
+			name:   "validation errors",
+			code:   ValidationError,
+			status: http.StatusBadRequest,
+			data:   errors,
+			expected: &Problem{
+				Type:   "/problems/validation-error",
+				Title:  CodeMessage[ValidationError],
+				Status: http.StatusBadRequest,
+				Code:   ValidationError,
+				Errors: errors,
+			},
+		},
+		{
+			name:   "no data",
+			code:   AuthorNotFound,
+			status: http.StatusNotFound,
+			expected: &Problem{
+				Type:   "/problems/author-not-found",
+				Title:  CodeMessage[AuthorNotFound],
+				Status: http.StatusNotFound,
+				Code:   AuthorNotFound,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, BuildProblem(tt.code, tt.status, tt.data))
+		})
+	}
+}
+
+func TestRespondError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/v1/author", func(c *gin.Context) {
+		RespondError(c, http.StatusBadRequest, ValidationError, validator.Errors{
+			{Field: "page", Rule: "gt", Message: "Page must be greater than 0"},
+		})
+	})
+
+	request := func(accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/v1/author", nil)
+		req.Header.Set("Accept", accept)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := request("application/json")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, "Accept", w.Header().Get("Vary"))
+	var response BaseResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, ValidationError, response.Code)
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"field":   "page",
+		"rule":    "gt",
+		"message": "Page must be greater than 0",
+	}}, response.Data)
+
+	w = request("application/problem+json")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, ProblemContentType, w.Header().Get("Content-Type"))
+	var problem Problem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	assert.Equal(t, Problem{
+		Type:     "/problems/validation-error",
+		Title:    CodeMessage[ValidationError],
+		Status:   http.StatusBadRequest,
+		Instance: "/v1/author",
+		Code:     ValidationError,
+		Errors:   []validator.FieldError{{Field: "page", Rule: "gt", Message: "Page must be greater than 0"}},
+	}, problem)
+}
+
+func TestRespondError_ProblemMetrics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(metrics.HTTPMiddleware("/metrics"))
+	router.GET("/v1/book/:id", func(c *gin.Context) {
+		RespondError(c, http.StatusNotFound, BookNotFound, nil)
+	})
+	router.GET("/metrics", gin.WrapH(metrics.Handler()))
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/book/1", nil)
+	req.Header.Set("Accept", ProblemContentType)
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Contains(t, w.Body.String(), `http_requests_total{code="40401",method="GET",route="/v1/book/:id",status="404"}`)
+}
+
+func TestRespondError_Localized(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.LocaleMiddleware(validator.Locales...))
+	router.GET("/v1/author/:id", func(c *gin.Context) {
+		RespondError(c, http.StatusNotFound, AuthorNotFound, nil)
+	})
+
+	request := func(accept, acceptLanguage string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/v1/author/1", nil)
+		req.Header.Set("Accept", accept)
+		req.Header.Set("Accept-Language", acceptLanguage)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := request("application/json", "th")
+	assert.Equal(t, "th", w.Header().Get("Content-Language"))
+	var response BaseResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "ไม่พบผู้แต่ง", response.Message)
+
+	w = request("application/problem+json", "th-TH")
+	var problem Problem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	assert.Equal(t, "ไม่พบผู้แต่ง", problem.Title)
+
+	w = request("application/json", "de")
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, CodeMessage[AuthorNotFound], response.Message)
+}
+
+func TestRespondBindingError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name           string
+		errors         validator.Errors
+		expectedStatus int
+		expectedCode   Code
+	}{
+		{
+			name:           "malformed body",
+			errors:         validator.Errors{{Rule: "json", Message: "Request body must contain a single JSON value"}},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   BindingError,
+		},
+		{
+			name:           "body too large",
+			errors:         validator.Errors{{Rule: validator.RuleMaxBytes, Param: "8", Message: "Request body must not be larger than 8 bytes"}},
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expectedCode:   RequestEntityTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/v1/author/", nil)
+			RespondBindingError(c, tt.errors)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			var response BaseResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedCode, response.Code)
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBaseModel_BeforeCreate(t *testing.T) {
+	model := &BaseModel{}
+	assert.NoError(t, model.BeforeCreate(nil))
+	assert.NotEqual(t, uuid.Nil, model.ID)
+
+	id := uuid.New()
+	model = &BaseModel{ID: id}
+	assert.NoError(t, model.BeforeCreate(nil))
+	assert.Equal(t, id, model.ID)
+}
@@ -0,0 +1,33 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sirawatc/simple-gin-crud/internal/shared/dto"
+	repoPkg "github.com/sirawatc/simple-gin-crud/pkg/repository"
+)
+
+var errRollback = errors.New("transaction rolled back")
+
+// Run runs fn as a unit of work and rolls it back unless fn returns
+// dto.Success. The error is only set when the transaction itself fails to
+// begin or commit, in which case the code is dto.InternalError.
+func Run(ctx context.Context, tm repoPkg.ITransactionManager, fn func(ctx context.Context) dto.Code, opts ...repoPkg.TxOption) (dto.Code, error) {
+	code := dto.InternalError
+	err := tm.WithinTransaction(ctx, func(ctx context.Context) error {
+		if code = fn(ctx); code != dto.Success {
+			return errRollback
+		}
+		return nil
+	}, opts...)
+
+	switch {
+	case errors.Is(err, errRollback):
+		return code, nil
+	case err != nil:
+		return dto.InternalError, err
+	default:
+		return code, nil
+	}
+}
@@ -0,0 +1,71 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sirawatc/simple-gin-crud/internal/shared/dto"
+	repoPkg "github.com/sirawatc/simple-gin-crud/pkg/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+type counter struct {
+	value int
+}
+
+func (c *counter) Snapshot() func() {
+	value := c.value
+	return func() { c.value = value }
+}
+
+type failingTransactionManager struct {
+	repoPkg.ITransactionManager
+	err error
+}
+
+func (tm *failingTransactionManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error, opts ...repoPkg.TxOption) error {
+	return tm.err
+}
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name          string
+		code          dto.Code
+		expectedValue int
+	}{
+		{name: "success commits", code: dto.Success, expectedValue: 2},
+		{name: "failure code rolls back", code: dto.BookAlreadyExists, expectedValue: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tm := repoPkg.NewMemoryTransactionManager()
+			c := &counter{value: 1}
+			tm.Register(c)
+
+			code, err := Run(context.Background(), tm, func(ctx context.Context) dto.Code {
+				assert.True(t, repoPkg.InTransaction(ctx))
+				c.value++
+				return tt.code
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.code, code)
+			assert.Equal(t, tt.expectedValue, c.value)
+		})
+	}
+}
+
+func TestRun_TransactionError(t *testing.T) {
+	beginErr := errors.New("connection refused")
+	tm := &failingTransactionManager{err: beginErr}
+
+	code, err := Run(context.Background(), tm, func(ctx context.Context) dto.Code {
+		t.Fatal("fn must not run")
+		return dto.Success
+	})
+
+	assert.Equal(t, beginErr, err)
+	assert.Equal(t, dto.InternalError, code)
+}
@@ -0,0 +1,128 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirawatc/simple-gin-crud/pkg/metrics"
+	"golang.org/x/sync/singleflight"
+)
+
+// notFound is stored for negative cache entries. It cannot collide with a
+// JSON-encoded entity, which always starts with '{'.
+var notFound = []byte("null")
+
+type Options struct {
+	TTL         time.Duration
+	NegativeTTL time.Duration
+}
+
+// Loader implements read-through caching of *T values on top of a Cache
+// backend. Concurrent misses for the same key share a single load, and
+// loads that find nothing are cached for NegativeTTL when it is positive.
+type Loader[T any] struct {
+	name    string
+	backend Cache
+	opts    atomic.Pointer[Options]
+	group   singleflight.Group
+
+	// generation is bumped on every invalidation so a load that started
+	// before it does not write a stale value back into the cache.
+	generation atomic.Uint64
+}
+
+func NewLoader[T any](name string, backend Cache, opts Options) *Loader[T] {
+	l := &Loader[T]{
+		name:    name,
+		backend: backend,
+	}
+	l.SetOptions(opts)
+	return l
+}
+
+// SetOptions changes the TTLs used for entries stored from now on.
+func (l *Loader[T]) SetOptions(opts Options) {
+	l.opts.Store(&opts)
+}
+
+func (l *Loader[T]) Get(ctx context.Context, key string, load func(ctx context.Context) (*T, error)) (*T, error) {
+	key = l.name + ":" + key
+
+	if value, ok, err := l.backend.Get(ctx, key); err == nil && ok {
+		if item, err := decode[T](value); err == nil {
+			metrics.RecordCacheResult(l.name, metrics.CacheHit)
+			return item, nil
+		}
+	}
+	metrics.RecordCacheResult(l.name, metrics.CacheMiss)
+
+	// The load is shared by every caller joined on the key, so it runs without
+	// the first caller's cancellation; each caller still waits on its own ctx.
+	loadCtx := context.WithoutCancel(ctx)
+	ch := l.group.DoChan(key, func() (interface{}, error) {
+		generation := l.generation.Load()
+		item, err := load(loadCtx)
+		if err != nil {
+			return nil, err
+		}
+		if l.generation.Load() == generation {
+			l.store(loadCtx, key, item)
+		}
+		return item, nil
+	})
+
+	var result singleflight.Result
+	select {
+	case result = <-ch:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if result.Err != nil {
+		return nil, result.Err
+	}
+
+	item := result.Val.(*T)
+	if item == nil {
+		return nil, nil
+	}
+	// Callers sharing a singleflight result must not share the pointer.
+	clone := *item
+	return &clone, nil
+}
+
+func (l *Loader[T]) Invalidate(ctx context.Context, keys ...string) error {
+	l.generation.Add(1)
+
+	prefixed := make([]string, 0, len(keys))
+	for _, key := range keys {
+		prefixed = append(prefixed, l.name+":"+key)
+		l.group.Forget(l.name + ":" + key)
+	}
+	return l.backend.Delete(ctx, prefixed...)
+}
+
+func (l *Loader[T]) store(ctx context.Context, key string, item *T) {
+	opts := l.opts.Load()
+	if item == nil {
+		if opts.NegativeTTL > 0 {
+			_ = l.backend.Set(ctx, key, notFound, opts.NegativeTTL)
+		}
+		return
+	}
+
+	value, err := json.Marshal(item)
+	if err != nil {
+		return
+	}
+	_ = l.backend.Set(ctx, key, value, opts.TTL)
+}
+
+func decode[T any](value []byte) (*T, error) {
+	var item *T
+	if err := json.Unmarshal(value, &item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
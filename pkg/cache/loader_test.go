@@ -0,0 +1,208 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type item struct {
+	Name string `json:"name"`
+}
+
+func TestLoader_Get(t *testing.T) {
+	ctx := context.Background()
+	loader := NewLoader[item]("item", NewLRU(10), Options{TTL: time.Minute})
+
+	var calls int
+	load := func(ctx context.Context) (*item, error) {
+		calls++
+		return &item{Name: "first"}, nil
+	}
+
+	got, err := loader.Get(ctx, "1", load)
+	assert.NoError(t, err)
+	assert.Equal(t, "first", got.Name)
+
+	got.Name = "mutated"
+	got, err = loader.Get(ctx, "1", load)
+	assert.NoError(t, err)
+	assert.Equal(t, "first", got.Name)
+	assert.Equal(t, 1, calls)
+}
+
+func TestLoader_Get_LoadError(t *testing.T) {
+	ctx := context.Background()
+	loader := NewLoader[item]("item", NewLRU(10), Options{TTL: time.Minute})
+	loadErr := errors.New("boom")
+
+	var calls int
+	load := func(ctx context.Context) (*item, error) {
+		calls++
+		return nil, loadErr
+	}
+
+	_, err := loader.Get(ctx, "1", load)
+	assert.Equal(t, loadErr, err)
+	_, err = loader.Get(ctx, "1", load)
+	assert.Equal(t, loadErr, err)
+	assert.Equal(t, 2, calls)
+}
+
+func TestLoader_Get_NegativeCaching(t *testing.T) {
+	tests := []struct {
+		name        string
+		negativeTTL time.Duration
+		expected    int
+	}{
+		{name: "enabled", negativeTTL: time.Minute, expected: 1},
+		{name: "disabled", negativeTTL: 0, expected: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			loader := NewLoader[item]("item", NewLRU(10), Options{TTL: time.Minute, NegativeTTL: tt.negativeTTL})
+
+			var calls int
+			load := func(ctx context.Context) (*item, error) {
+				calls++
+				return nil, nil
+			}
+
+			for i := 0; i < 2; i++ {
+				got, err := loader.Get(ctx, "1", load)
+				assert.NoError(t, err)
+				assert.Nil(t, got)
+			}
+			assert.Equal(t, tt.expected, calls)
+		})
+	}
+}
+
+func TestLoader_Get_CollapsesConcurrentLoads(t *testing.T) {
+	ctx := context.Background()
+	loader := NewLoader[item]("item", NewLRU(10), Options{TTL: time.Minute})
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+	load := func(ctx context.Context) (*item, error) {
+		calls.Add(1)
+		<-release
+		return &item{Name: "shared"}, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got, err := loader.Get(ctx, "1", load)
+			assert.NoError(t, err)
+			assert.Equal(t, "shared", got.Name)
+		}()
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestLoader_Get_CancelledCallerDoesNotFailJoinedCallers(t *testing.T) {
+	loader := NewLoader[item]("item", NewLRU(10), Options{TTL: time.Minute})
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	load := func(ctx context.Context) (*item, error) {
+		close(started)
+		select {
+		case <-release:
+			return &item{Name: "shared"}, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	firstCtx, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := loader.Get(firstCtx, "1", load)
+		firstErr <- err
+	}()
+	<-started
+
+	joined := make(chan *item, 1)
+	go func() {
+		got, err := loader.Get(context.Background(), "1", load)
+		assert.NoError(t, err)
+		joined <- got
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	assert.ErrorIs(t, <-firstErr, context.Canceled)
+
+	close(release)
+	got := <-joined
+	if assert.NotNil(t, got) {
+		assert.Equal(t, "shared", got.Name)
+	}
+}
+
+func TestLoader_Invalidate(t *testing.T) {
+	ctx := context.Background()
+	loader := NewLoader[item]("item", NewLRU(10), Options{TTL: time.Minute})
+
+	name := "first"
+	load := func(ctx context.Context) (*item, error) {
+		return &item{Name: name}, nil
+	}
+
+	_, _ = loader.Get(ctx, "1", load)
+	name = "second"
+	assert.NoError(t, loader.Invalidate(ctx, "1"))
+
+	got, err := loader.Get(ctx, "1", load)
+	assert.NoError(t, err)
+	assert.Equal(t, "second", got.Name)
+}
+
+func TestLoader_Invalidate_DuringLoadSkipsStore(t *testing.T) {
+	ctx := context.Background()
+	backend := NewLRU(10)
+	loader := NewLoader[item]("item", backend, Options{TTL: time.Minute})
+
+	_, err := loader.Get(ctx, "1", func(ctx context.Context) (*item, error) {
+		assert.NoError(t, loader.Invalidate(ctx, "1"))
+		return &item{Name: "stale"}, nil
+	})
+	assert.NoError(t, err)
+
+	_, ok, _ := backend.Get(ctx, "item:1")
+	assert.False(t, ok)
+}
+
+func TestLoader_SetOptions(t *testing.T) {
+	ctx := context.Background()
+	loader := NewLoader[item]("item", NewLRU(10), Options{TTL: time.Minute})
+	loader.SetOptions(Options{TTL: time.Minute, NegativeTTL: time.Minute})
+
+	var calls int
+	load := func(ctx context.Context) (*item, error) {
+		calls++
+		return nil, nil
+	}
+
+	for i := 0; i < 2; i++ {
+		_, err := loader.Get(ctx, "1", load)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 1, calls)
+}
@@ -1,6 +1,11 @@
 package dto
 
-import "strconv"
+import (
+	"context"
+	"strconv"
+
+	"github.com/sirawatc/simple-gin-crud/pkg/validator"
+)
 
 type PaginationRequest struct {
 	Page     int `json:"page" form:"page" binding:"min=1"`
@@ -27,8 +32,10 @@ type PaginationDataResponse[T any] struct {
 	Pagination PaginationResponse `json:"pagination"`
 }
 
-func NewPaginationRequest(page, pageSize string) (*PaginationRequest, []string) {
-	errors := []string{}
+// NewPaginationRequest parses the page and pageSize query parameters, with
+// error messages in the locale chosen for the request in ctx.
+func NewPaginationRequest(ctx context.Context, page, pageSize string) (*PaginationRequest, validator.Errors) {
+	errors := validator.Errors{}
 
 	pagination := &PaginationRequest{
 		Page:     1,
@@ -39,7 +46,7 @@ func NewPaginationRequest(page, pageSize string) (*PaginationRequest, []string)
 		if page, err := strconv.Atoi(page); err == nil && page > 0 {
 			pagination.Page = page
 		} else {
-			errors = append(errors, "Page must be greater than 0")
+			errors = append(errors, validator.FieldError{Field: "page", Rule: "gt", Message: validator.Message(ctx, "pageGreaterThan", 0)})
 		}
 	}
 
@@ -47,7 +54,7 @@ func NewPaginationRequest(page, pageSize string) (*PaginationRequest, []string)
 		if pageSize, err := strconv.Atoi(pageSize); err == nil && pageSize > 0 {
 			pagination.PageSize = pageSize
 		} else {
-			errors = append(errors, "Page size must be greater than 0")
+			errors = append(errors, validator.FieldError{Field: "pageSize", Rule: "gt", Message: validator.Message(ctx, "pageSizeGreaterThan", 0)})
 		}
 	}
 
@@ -1,6 +1,7 @@
 package dto
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -98,7 +99,7 @@ func TestNewPaginationRequest(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, errors := NewPaginationRequest(tt.page, tt.pageSize)
+			result, errors := NewPaginationRequest(context.Background(), tt.page, tt.pageSize)
 
 			if tt.expectError {
 				assert.NotEmpty(t, errors)
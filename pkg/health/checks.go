@@ -0,0 +1,77 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+var errDiskSpaceUnsupported = errors.New("disk space check is not supported on this platform")
+
+func DBPingCheck(db *gorm.DB) CheckFunc {
+	return func(ctx context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+		return sqlDB.PingContext(ctx)
+	}
+}
+
+func MigrationCheck(pending func(ctx context.Context) ([]string, error)) CheckFunc {
+	return func(ctx context.Context) error {
+		migrations, err := pending(ctx)
+		if err != nil {
+			return err
+		}
+		if len(migrations) > 0 {
+			return fmt.Errorf("%d pending migration(s): %v", len(migrations), migrations)
+		}
+		return nil
+	}
+}
+
+func PoolSaturationCheck(db *gorm.DB, threshold float64) CheckFunc {
+	return func(ctx context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+		stats := sqlDB.Stats()
+		if stats.MaxOpenConnections <= 0 {
+			return nil
+		}
+		usage := float64(stats.InUse) / float64(stats.MaxOpenConnections)
+		if usage >= threshold {
+			return fmt.Errorf("connection pool saturated: %d/%d in use, %d waiting", stats.InUse, stats.MaxOpenConnections, stats.WaitCount)
+		}
+		return nil
+	}
+}
+
+func DiskSpaceCheck(path string, minFreeBytes uint64) CheckFunc {
+	return func(ctx context.Context) error {
+		free, err := freeDiskSpace(path)
+		if err != nil {
+			if errors.Is(err, errDiskSpaceUnsupported) {
+				return nil
+			}
+			return err
+		}
+		if free < minFreeBytes {
+			return fmt.Errorf("low disk space on %s: %d bytes free, %d required", path, free, minFreeBytes)
+		}
+		return nil
+	}
+}
+
+func ReadyCheck(isReady func() bool) CheckFunc {
+	return func(ctx context.Context) error {
+		if !isReady() {
+			return errors.New("server is shutting down")
+		}
+		return nil
+	}
+}
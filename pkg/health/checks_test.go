@@ -0,0 +1,69 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func mockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	sqlDB, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	assert.NoError(t, err)
+	mock.ExpectPing()
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	assert.NoError(t, err)
+	return db, mock
+}
+
+func TestDBPingCheck(t *testing.T) {
+	db, mock := mockDB(t)
+
+	mock.ExpectPing()
+	assert.NoError(t, DBPingCheck(db)(context.Background()))
+
+	mock.ExpectPing().WillReturnError(errors.New("connection refused"))
+	assert.Error(t, DBPingCheck(db)(context.Background()))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrationCheck(t *testing.T) {
+	upToDate := MigrationCheck(func(ctx context.Context) ([]string, error) { return nil, nil })
+	assert.NoError(t, upToDate(context.Background()))
+
+	pending := MigrationCheck(func(ctx context.Context) ([]string, error) { return []string{"books"}, nil })
+	assert.EqualError(t, pending(context.Background()), "1 pending migration(s): [books]")
+
+	failing := MigrationCheck(func(ctx context.Context) ([]string, error) { return nil, errors.New("boom") })
+	assert.Error(t, failing(context.Background()))
+}
+
+func TestPoolSaturationCheck(t *testing.T) {
+	db, _ := mockDB(t)
+	sqlDB, err := db.DB()
+	assert.NoError(t, err)
+
+	assert.NoError(t, PoolSaturationCheck(db, 0.9)(context.Background()))
+
+	sqlDB.SetMaxOpenConns(10)
+	assert.NoError(t, PoolSaturationCheck(db, 0.9)(context.Background()))
+	assert.Error(t, PoolSaturationCheck(db, 0)(context.Background()))
+}
+
+func TestDiskSpaceCheck(t *testing.T) {
+	dir := t.TempDir()
+
+	assert.NoError(t, DiskSpaceCheck(dir, 0)(context.Background()))
+	assert.Error(t, DiskSpaceCheck(dir, ^uint64(0))(context.Background()))
+	assert.Error(t, DiskSpaceCheck(dir+"/missing", 0)(context.Background()))
+}
+
+func TestReadyCheck(t *testing.T) {
+	assert.NoError(t, ReadyCheck(func() bool { return true })(context.Background()))
+	assert.Error(t, ReadyCheck(func() bool { return false })(context.Background()))
+}
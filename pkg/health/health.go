@@ -0,0 +1,269 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Probe string
+
+const (
+	Liveness  Probe = "liveness"
+	Readiness Probe = "readiness"
+	Startup   Probe = "startup"
+)
+
+type Status string
+
+const (
+	StatusOK        Status = "ok"
+	StatusDegraded  Status = "degraded"
+	StatusUnhealthy Status = "unhealthy"
+)
+
+const DefaultTimeout = 2 * time.Second
+
+type CheckFunc func(ctx context.Context) error
+
+type Check struct {
+	Name     string
+	Check    CheckFunc
+	Probes   []Probe
+	Timeout  time.Duration
+	Critical bool
+	CacheTTL time.Duration
+}
+
+type Result struct {
+	Name      string  `json:"name"`
+	Status    Status  `json:"status"`
+	Critical  bool    `json:"critical"`
+	LatencyMs float64 `json:"latencyMs"`
+	Error     string  `json:"error,omitempty"`
+	Cached    bool    `json:"cached"`
+	CheckedAt string  `json:"checkedAt"`
+}
+
+type Report struct {
+	Status    Status   `json:"status"`
+	Timestamp string   `json:"timestamp"`
+	Checks    []Result `json:"checks,omitempty"`
+}
+
+type cachedResult struct {
+	result    Result
+	expiresAt time.Time
+}
+
+type Registry struct {
+	mu     sync.RWMutex
+	checks []Check
+	cache  map[string]cachedResult
+	now    func() time.Time
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		cache: map[string]cachedResult{},
+		now:   time.Now,
+	}
+}
+
+func (r *Registry) Register(check Check) error {
+	if check.Name == "" {
+		return errors.New("health check name is required")
+	}
+	if check.Check == nil {
+		return fmt.Errorf("health check %q has no check function", check.Name)
+	}
+	if len(check.Probes) == 0 {
+		return fmt.Errorf("health check %q has no probes", check.Name)
+	}
+	if check.Timeout <= 0 {
+		check.Timeout = DefaultTimeout
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.checks {
+		if existing.Name == check.Name {
+			return fmt.Errorf("health check %q is already registered", check.Name)
+		}
+	}
+	r.checks = append(r.checks, check)
+	return nil
+}
+
+func (r *Registry) MustRegister(checks ...Check) {
+	for _, check := range checks {
+		if err := r.Register(check); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func (r *Registry) Run(ctx context.Context, probe Probe) Report {
+	checks := r.checksFor(probe)
+	results := make([]Result, len(checks))
+
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func(i int, check Check) {
+			defer wg.Done()
+			results[i] = r.run(ctx, check)
+		}(i, check)
+	}
+	wg.Wait()
+
+	status := StatusOK
+	for _, result := range results {
+		if result.Status == StatusOK {
+			continue
+		}
+		if result.Critical {
+			status = StatusUnhealthy
+			break
+		}
+		status = StatusDegraded
+	}
+
+	return Report{
+		Status:    status,
+		Timestamp: r.now().Format(time.RFC3339),
+		Checks:    results,
+	}
+}
+
+func (r *Registry) checksFor(probe Probe) []Check {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	checks := []Check{}
+	for _, check := range r.checks {
+		for _, p := range check.Probes {
+			if p == probe {
+				checks = append(checks, check)
+				break
+			}
+		}
+	}
+	return checks
+}
+
+func (r *Registry) run(ctx context.Context, check Check) Result {
+	if check.CacheTTL > 0 {
+		r.mu.RLock()
+		cached, ok := r.cache[check.Name]
+		r.mu.RUnlock()
+		if ok && r.now().Before(cached.expiresAt) {
+			result := cached.result
+			result.Cached = true
+			return result
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, check.Timeout)
+	defer cancel()
+
+	start := r.now()
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- check.Check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-errCh:
+	case <-ctx.Done():
+		err = fmt.Errorf("timed out after %v", check.Timeout)
+	}
+
+	result := Result{
+		Name:      check.Name,
+		Status:    StatusOK,
+		Critical:  check.Critical,
+		LatencyMs: float64(r.now().Sub(start).Microseconds()) / 1000,
+		CheckedAt: start.Format(time.RFC3339),
+	}
+	if err != nil {
+		result.Status = StatusUnhealthy
+		result.Error = err.Error()
+	}
+
+	if check.CacheTTL > 0 {
+		r.mu.Lock()
+		r.cache[check.Name] = cachedResult{result: result, expiresAt: start.Add(check.CacheTTL)}
+		r.mu.Unlock()
+	}
+
+	return result
+}
+
+func (r *Registry) Handler(probe Probe) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		report := r.Run(c.Request.Context(), probe)
+
+		httpStatus := http.StatusOK
+		if report.Status == StatusUnhealthy {
+			httpStatus = http.StatusServiceUnavailable
+		}
+
+		if !isVerbose(c) {
+			report.Checks = nil
+		}
+
+		c.JSON(httpStatus, report)
+	}
+}
+
+// LegacyHandler serves the original /health body, {status, checks,
+// timestamp}, reporting each named check as "ok" or "down". Any check that
+// is down makes the status "unhealthy" with a 503, whether critical or not.
+func (r *Registry) LegacyHandler(names ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		status, httpStatus := "ok", http.StatusOK
+		checks := gin.H{}
+		for _, check := range r.checksNamed(names) {
+			checks[check.Name] = "ok"
+			if r.run(c.Request.Context(), check).Status != StatusOK {
+				checks[check.Name] = "down"
+				status, httpStatus = "unhealthy", http.StatusServiceUnavailable
+			}
+		}
+
+		c.JSON(httpStatus, gin.H{
+			"status":    status,
+			"checks":    checks,
+			"timestamp": r.now().Format(time.RFC3339),
+		})
+	}
+}
+
+func (r *Registry) checksNamed(names []string) []Check {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	checks := []Check{}
+	for _, name := range names {
+		for _, check := range r.checks {
+			if check.Name == name {
+				checks = append(checks, check)
+				break
+			}
+		}
+	}
+	return checks
+}
+
+func isVerbose(c *gin.Context) bool {
+	value, ok := c.GetQuery("verbose")
+	return ok && value != "false" && value != "0"
+}
@@ -0,0 +1,251 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func okCheck(context.Context) error {
+	return nil
+}
+
+func failingCheck(context.Context) error {
+	return errors.New("boom")
+}
+
+func TestRegistry_Register(t *testing.T) {
+	tests := []struct {
+		name        string
+		check       Check
+		expectError bool
+	}{
+		{name: "valid check", check: Check{Name: "ok", Check: okCheck, Probes: []Probe{Liveness}}},
+		{name: "missing name", check: Check{Check: okCheck, Probes: []Probe{Liveness}}, expectError: true},
+		{name: "missing function", check: Check{Name: "nil", Probes: []Probe{Liveness}}, expectError: true},
+		{name: "missing probes", check: Check{Name: "noprobe", Check: okCheck}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewRegistry().Register(tt.check)
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRegistry_RegisterDuplicate(t *testing.T) {
+	registry := NewRegistry()
+	check := Check{Name: "dup", Check: okCheck, Probes: []Probe{Liveness}}
+
+	assert.NoError(t, registry.Register(check))
+	assert.Error(t, registry.Register(check))
+	assert.Panics(t, func() { registry.MustRegister(check) })
+}
+
+func TestRegistry_Run(t *testing.T) {
+	tests := []struct {
+		name     string
+		checks   []Check
+		probe    Probe
+		expected Status
+		count    int
+	}{
+		{
+			name:     "no checks is ok",
+			probe:    Liveness,
+			expected: StatusOK,
+		},
+		{
+			name: "all passing",
+			checks: []Check{
+				{Name: "a", Check: okCheck, Probes: []Probe{Readiness}, Critical: true},
+				{Name: "b", Check: okCheck, Probes: []Probe{Readiness}},
+			},
+			probe:    Readiness,
+			expected: StatusOK,
+			count:    2,
+		},
+		{
+			name: "non critical failure degrades",
+			checks: []Check{
+				{Name: "a", Check: okCheck, Probes: []Probe{Readiness}, Critical: true},
+				{Name: "b", Check: failingCheck, Probes: []Probe{Readiness}},
+			},
+			probe:    Readiness,
+			expected: StatusDegraded,
+			count:    2,
+		},
+		{
+			name: "critical failure is unhealthy",
+			checks: []Check{
+				{Name: "a", Check: failingCheck, Probes: []Probe{Readiness}, Critical: true},
+				{Name: "b", Check: failingCheck, Probes: []Probe{Readiness}},
+			},
+			probe:    Readiness,
+			expected: StatusUnhealthy,
+			count:    2,
+		},
+		{
+			name: "only checks for the probe run",
+			checks: []Check{
+				{Name: "a", Check: failingCheck, Probes: []Probe{Startup}, Critical: true},
+				{Name: "b", Check: okCheck, Probes: []Probe{Liveness, Startup}},
+			},
+			probe:    Liveness,
+			expected: StatusOK,
+			count:    1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := NewRegistry()
+			registry.MustRegister(tt.checks...)
+
+			report := registry.Run(context.Background(), tt.probe)
+
+			assert.Equal(t, tt.expected, report.Status)
+			assert.Len(t, report.Checks, tt.count)
+		})
+	}
+}
+
+func TestRegistry_Timeout(t *testing.T) {
+	registry := NewRegistry()
+	registry.MustRegister(Check{
+		Name: "slow",
+		Check: func(ctx context.Context) error {
+			time.Sleep(200 * time.Millisecond)
+			return nil
+		},
+		Probes:   []Probe{Readiness},
+		Timeout:  10 * time.Millisecond,
+		Critical: true,
+	})
+
+	start := time.Now()
+	report := registry.Run(context.Background(), Readiness)
+
+	assert.Less(t, time.Since(start), 150*time.Millisecond)
+	assert.Equal(t, StatusUnhealthy, report.Status)
+	assert.Contains(t, report.Checks[0].Error, "timed out")
+}
+
+func TestRegistry_Cache(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	var calls atomic.Int32
+
+	registry := NewRegistry()
+	registry.now = func() time.Time { return now }
+	registry.MustRegister(Check{
+		Name: "cached",
+		Check: func(ctx context.Context) error {
+			calls.Add(1)
+			return nil
+		},
+		Probes:   []Probe{Readiness},
+		CacheTTL: time.Minute,
+	})
+
+	first := registry.Run(context.Background(), Readiness)
+	second := registry.Run(context.Background(), Readiness)
+	assert.False(t, first.Checks[0].Cached)
+	assert.True(t, second.Checks[0].Cached)
+	assert.Equal(t, int32(1), calls.Load())
+
+	now = now.Add(2 * time.Minute)
+	third := registry.Run(context.Background(), Readiness)
+	assert.False(t, third.Checks[0].Cached)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestRegistry_Handler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	registry := NewRegistry()
+	registry.MustRegister(
+		Check{Name: "database", Check: failingCheck, Probes: []Probe{Readiness}, Critical: true},
+		Check{Name: "disk", Check: okCheck, Probes: []Probe{Readiness, Liveness}},
+	)
+
+	router := gin.New()
+	router.GET("/livez", registry.Handler(Liveness))
+	router.GET("/readyz", registry.Handler(Readiness))
+
+	tests := []struct {
+		name           string
+		path           string
+		expectedStatus int
+		expectedBody   Status
+		expectedChecks int
+	}{
+		{name: "liveness", path: "/livez", expectedStatus: http.StatusOK, expectedBody: StatusOK},
+		{name: "readiness", path: "/readyz", expectedStatus: http.StatusServiceUnavailable, expectedBody: StatusUnhealthy},
+		{name: "verbose readiness", path: "/readyz?verbose", expectedStatus: http.StatusServiceUnavailable, expectedBody: StatusUnhealthy, expectedChecks: 2},
+		{name: "verbose disabled", path: "/readyz?verbose=false", expectedStatus: http.StatusServiceUnavailable, expectedBody: StatusUnhealthy},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			var report Report
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedBody, report.Status)
+			assert.Len(t, report.Checks, tt.expectedChecks)
+			assert.NotEmpty(t, report.Timestamp)
+		})
+	}
+}
+
+func TestRegistry_LegacyHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	healthy := true
+	registry := NewRegistry()
+	registry.MustRegister(
+		Check{Name: "database", Check: func(context.Context) error {
+			if healthy {
+				return nil
+			}
+			return errors.New("down")
+		}, Probes: []Probe{Readiness}, Critical: true},
+		Check{Name: "migrations", Check: failingCheck, Probes: []Probe{Readiness}},
+	)
+
+	router := gin.New()
+	router.GET("/health", registry.LegacyHandler("database"))
+
+	get := func() (int, map[string]interface{}) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+		var body map[string]interface{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		return w.Code, body
+	}
+
+	code, body := get()
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "ok", body["status"])
+	assert.Equal(t, map[string]interface{}{"database": "ok"}, body["checks"], "only the named checks are reported")
+	assert.NotEmpty(t, body["timestamp"])
+
+	healthy = false
+	code, body = get()
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "unhealthy", body["status"])
+	assert.Equal(t, map[string]interface{}{"database": "down"}, body["checks"])
+}
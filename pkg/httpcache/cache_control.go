@@ -0,0 +1,43 @@
+package httpcache
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CacheControl sets the Cache-Control header on GET and HEAD responses.
+// routes overrides defaultValue by gin route pattern, e.g. "/v1/author/:id".
+// Error responses are always sent with no-store so clients never cache them.
+func CacheControl(defaultValue string, routes map[string]string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+			c.Next()
+			return
+		}
+
+		value, ok := routes[c.FullPath()]
+		if !ok {
+			value = defaultValue
+		}
+		if value == "" {
+			c.Next()
+			return
+		}
+
+		c.Header("Cache-Control", value)
+		c.Writer = &cacheControlWriter{ResponseWriter: c.Writer}
+		c.Next()
+	}
+}
+
+type cacheControlWriter struct {
+	gin.ResponseWriter
+}
+
+func (w *cacheControlWriter) WriteHeader(code int) {
+	if code >= http.StatusBadRequest {
+		w.Header().Set("Cache-Control", "no-store")
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
@@ -0,0 +1,51 @@
+package httpcache
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheControl(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(CacheControl("no-cache", map[string]string{
+		"/item/:id": "public, max-age=60",
+		"/private":  "",
+	}))
+	router.GET("/item/:id", func(c *gin.Context) {
+		if c.Param("id") == "missing" {
+			c.Status(http.StatusNotFound)
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+	router.GET("/list", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/private", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.POST("/list", func(c *gin.Context) { c.Status(http.StatusCreated) })
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		expected string
+	}{
+		{name: "route override", method: http.MethodGet, path: "/item/1", expected: "public, max-age=60"},
+		{name: "default", method: http.MethodGet, path: "/list", expected: "no-cache"},
+		{name: "disabled for route", method: http.MethodGet, path: "/private", expected: ""},
+		{name: "error response", method: http.MethodGet, path: "/item/missing", expected: "no-store"},
+		{name: "write request", method: http.MethodPost, path: "/list", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+
+			assert.Equal(t, tt.expected, w.Header().Get("Cache-Control"))
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package httpcache
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NotModified sets Last-Modified and reports whether the request's
+// If-Modified-Since makes the response unnecessary, in which case a 304 has
+// already been written and the handler should return.
+func NotModified(c *gin.Context, lastModified time.Time) bool {
+	if lastModified.IsZero() {
+		return false
+	}
+
+	// HTTP dates have one second precision.
+	lastModified = lastModified.UTC().Truncate(time.Second)
+	c.Header("Last-Modified", lastModified.Format(http.TimeFormat))
+
+	if !notModifiedSince(c.Request, lastModified) {
+		return false
+	}
+
+	c.Status(http.StatusNotModified)
+	c.Writer.WriteHeaderNow()
+	return true
+}
+
+// notModifiedSince reports whether r is a GET or HEAD whose
+// If-Modified-Since is not older than lastModified.
+func notModifiedSince(r *http.Request, lastModified time.Time) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	return err == nil && !lastModified.After(since)
+}
+
+// Latest returns the most recent of times, for responses that embed
+// several resources.
+func Latest(times ...time.Time) time.Time {
+	var latest time.Time
+	for _, t := range times {
+		if t.After(latest) {
+			latest = t
+		}
+	}
+	return latest
+}
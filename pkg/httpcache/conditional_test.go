@@ -0,0 +1,98 @@
+package httpcache
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotModified(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	lastModified := time.Date(2024, 1, 2, 3, 4, 5, 600, time.UTC)
+
+	tests := []struct {
+		name            string
+		method          string
+		ifModifiedSince string
+		expected        bool
+		expectedStatus  int
+	}{
+		{
+			name:           "no conditional header",
+			method:         http.MethodGet,
+			expected:       false,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:            "not modified since",
+			method:          http.MethodGet,
+			ifModifiedSince: lastModified.Format(http.TimeFormat),
+			expected:        true,
+			expectedStatus:  http.StatusNotModified,
+		},
+		{
+			name:            "modified since",
+			method:          http.MethodGet,
+			ifModifiedSince: lastModified.Add(-time.Second).Format(http.TimeFormat),
+			expected:        false,
+			expectedStatus:  http.StatusOK,
+		},
+		{
+			name:            "invalid date",
+			method:          http.MethodGet,
+			ifModifiedSince: "yesterday",
+			expected:        false,
+			expectedStatus:  http.StatusOK,
+		},
+		{
+			name:            "unsafe method",
+			method:          http.MethodPut,
+			ifModifiedSince: lastModified.Format(http.TimeFormat),
+			expected:        false,
+			expectedStatus:  http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(tt.method, "/", nil)
+			if tt.ifModifiedSince != "" {
+				c.Request.Header.Set("If-Modified-Since", tt.ifModifiedSince)
+			}
+
+			assert.Equal(t, tt.expected, NotModified(c, lastModified))
+			if !tt.expected {
+				c.Status(http.StatusOK)
+				c.Writer.WriteHeaderNow()
+			}
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, "Tue, 02 Jan 2024 03:04:05 GMT", w.Header().Get("Last-Modified"))
+		})
+	}
+}
+
+func TestNotModified_ZeroTime(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+	assert.False(t, NotModified(c, time.Time{}))
+	assert.Empty(t, w.Header().Get("Last-Modified"))
+}
+
+func TestLatest(t *testing.T) {
+	earlier := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	later := earlier.Add(time.Hour)
+
+	assert.Equal(t, later, Latest(earlier, later))
+	assert.Equal(t, later, Latest(later, earlier))
+	assert.True(t, Latest().IsZero())
+}
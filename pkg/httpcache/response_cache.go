@@ -0,0 +1,186 @@
+package httpcache
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirawatc/simple-gin-crud/pkg/cache"
+	"github.com/sirawatc/simple-gin-crud/pkg/metrics"
+)
+
+// cachedResponse holds the headers set downstream of the cache middleware,
+// such as Content-Type, Last-Modified and Vary, along with the body.
+type cachedResponse struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header"`
+	Body   []byte      `json:"body"`
+}
+
+// ResponseCache is a shared server-side cache for GET responses, grouped by
+// resource. Flushing a resource bumps its generation, so entries written
+// under an older generation are never served again and age out of the
+// backend on their own.
+type ResponseCache struct {
+	backend cache.Cache
+	ttl     atomic.Int64
+
+	mu          sync.Mutex
+	generations map[string]uint64
+}
+
+func NewResponseCache(backend cache.Cache, ttl time.Duration) *ResponseCache {
+	rc := &ResponseCache{
+		backend:     backend,
+		generations: map[string]uint64{},
+	}
+	rc.SetTTL(ttl)
+	return rc
+}
+
+// SetTTL changes the lifetime of responses stored from now on.
+func (rc *ResponseCache) SetTTL(ttl time.Duration) {
+	rc.ttl.Store(int64(ttl))
+}
+
+// Cache serves successful GET responses for resource from the cache, keyed
+// by path, normalized query string and the Content-Language already set by
+// earlier middleware, so each locale gets its own entry.
+func (rc *ResponseCache) Cache(resource string) gin.HandlerFunc {
+	name := "response_" + resource
+
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet {
+			c.Next()
+			return
+		}
+
+		ctx := c.Request.Context()
+		key := rc.key(resource, c.Writer.Header().Get("Content-Language"), c.Request.URL)
+
+		if value, ok, err := rc.backend.Get(ctx, key); err == nil && ok {
+			var response cachedResponse
+			if err := json.Unmarshal(value, &response); err == nil {
+				metrics.RecordCacheResult(name, metrics.CacheHit)
+				c.Header("X-Cache", "HIT")
+				replay(c, &response)
+				c.Abort()
+				return
+			}
+		}
+		metrics.RecordCacheResult(name, metrics.CacheMiss)
+
+		c.Header("X-Cache", "MISS")
+		before := c.Writer.Header().Clone()
+		writer := &bodyWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.Next()
+
+		if c.Writer.Status() != http.StatusOK {
+			return
+		}
+		value, err := json.Marshal(cachedResponse{
+			Status: http.StatusOK,
+			Header: addedHeaders(before, c.Writer.Header()),
+			Body:   writer.body.Bytes(),
+		})
+		if err != nil {
+			return
+		}
+		_ = rc.backend.Set(ctx, key, value, time.Duration(rc.ttl.Load()))
+	}
+}
+
+// replay writes a stored response, or a 304 when its Last-Modified satisfies
+// the request's If-Modified-Since, as the handler would have.
+func replay(c *gin.Context, response *cachedResponse) {
+	header := c.Writer.Header()
+	for key, values := range response.Header {
+		header[key] = values
+	}
+
+	if lastModified, err := http.ParseTime(response.Header.Get("Last-Modified")); err == nil && notModifiedSince(c.Request, lastModified) {
+		c.Status(http.StatusNotModified)
+		c.Writer.WriteHeaderNow()
+		return
+	}
+	c.Writer.WriteHeader(response.Status)
+	_, _ = c.Writer.Write(response.Body)
+}
+
+// addedHeaders returns the headers in after that are missing from or differ
+// in before, leaving out those set by earlier middleware for every request.
+func addedHeaders(before, after http.Header) http.Header {
+	added := http.Header{}
+	for key, values := range after {
+		if key == "X-Cache" || slices.Equal(before[key], values) {
+			continue
+		}
+		added[key] = slices.Clone(values)
+	}
+	return added
+}
+
+// FlushOnWrite flushes resources after any successful non-GET request.
+func (rc *ResponseCache) FlushOnWrite(resources ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			return
+		}
+		if c.Writer.Status() < http.StatusBadRequest {
+			rc.Flush(resources...)
+		}
+	}
+}
+
+func (rc *ResponseCache) Flush(resources ...string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	for _, resource := range resources {
+		rc.generations[resource]++
+	}
+}
+
+func (rc *ResponseCache) key(resource, language string, u *url.URL) string {
+	rc.mu.Lock()
+	generation := rc.generations[resource]
+	rc.mu.Unlock()
+
+	return resource + ":" + strconv.FormatUint(generation, 10) + ":" + language + ":" + u.Path + "?" + normalizeQuery(u.Query())
+}
+
+// normalizeQuery sorts keys and values so equivalent query strings share a
+// cache entry.
+func normalizeQuery(query url.Values) string {
+	for _, values := range query {
+		sort.Strings(values)
+	}
+	return query.Encode()
+}
+
+type bodyWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bodyWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *bodyWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
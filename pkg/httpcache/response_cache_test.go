@@ -0,0 +1,177 @@
+package httpcache
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirawatc/simple-gin-crud/pkg/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+type responseCacheFixture struct {
+	router *gin.Engine
+	calls  int
+	status int
+}
+
+func newResponseCacheFixture() *responseCacheFixture {
+	gin.SetMode(gin.TestMode)
+	f := &responseCacheFixture{router: gin.New(), status: http.StatusOK}
+	rc := NewResponseCache(cache.NewLRU(100), time.Minute)
+
+	items := f.router.Group("/items")
+	items.Use(rc.FlushOnWrite("item"))
+	items.GET("/", rc.Cache("item"), func(c *gin.Context) {
+		f.calls++
+		c.JSON(f.status, gin.H{"calls": f.calls, "page": c.Query("page")})
+	})
+	items.POST("/", func(c *gin.Context) {
+		c.Status(f.status)
+	})
+	return f
+}
+
+func (f *responseCacheFixture) do(method, target string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	f.router.ServeHTTP(w, httptest.NewRequest(method, target, nil))
+	return w
+}
+
+func TestResponseCache_ServesCachedResponse(t *testing.T) {
+	f := newResponseCacheFixture()
+
+	first := f.do(http.MethodGet, "/items/?page=1&pageSize=10")
+	second := f.do(http.MethodGet, "/items/?pageSize=10&page=1")
+
+	assert.Equal(t, 1, f.calls)
+	assert.Equal(t, "MISS", first.Header().Get("X-Cache"))
+	assert.Equal(t, "HIT", second.Header().Get("X-Cache"))
+	assert.Equal(t, http.StatusOK, second.Code)
+	assert.Equal(t, first.Body.String(), second.Body.String())
+	assert.Equal(t, first.Header().Get("Content-Type"), second.Header().Get("Content-Type"))
+}
+
+func TestResponseCache_ReplaysHeaders(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	rc := NewResponseCache(cache.NewLRU(100), time.Minute)
+	lastModified := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	requests := 0
+	router.Use(func(c *gin.Context) {
+		requests++
+		c.Header("X-Request-Id", strconv.Itoa(requests))
+		c.Header("Cache-Control", "public, max-age=60")
+	})
+	router.GET("/items/", rc.Cache("item"), func(c *gin.Context) {
+		c.Header("Vary", "Accept-Language")
+		if NotModified(c, lastModified) {
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"items": []string{}})
+	})
+
+	get := func(header http.Header) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/items/", nil)
+		for key, values := range header {
+			req.Header[key] = values
+		}
+		router.ServeHTTP(w, req)
+		return w
+	}
+	miss := get(nil)
+	hit := get(nil)
+
+	assert.Equal(t, "MISS", miss.Header().Get("X-Cache"))
+	assert.Equal(t, "HIT", hit.Header().Get("X-Cache"))
+	for _, key := range []string{"Content-Type", "Last-Modified", "Cache-Control", "Vary"} {
+		assert.NotEmpty(t, miss.Header().Get(key), key)
+		assert.Equal(t, miss.Header().Values(key), hit.Header().Values(key), key)
+	}
+	assert.Equal(t, "2", hit.Header().Get("X-Request-Id"), "headers from earlier middleware are not replayed")
+
+	conditional := get(http.Header{"If-Modified-Since": {lastModified.Format(http.TimeFormat)}})
+	assert.Equal(t, "HIT", conditional.Header().Get("X-Cache"))
+	assert.Equal(t, http.StatusNotModified, conditional.Code)
+	assert.Empty(t, conditional.Body.String())
+	assert.Equal(t, miss.Header().Get("Last-Modified"), conditional.Header().Get("Last-Modified"))
+}
+
+func TestResponseCache_KeysByQuery(t *testing.T) {
+	f := newResponseCacheFixture()
+
+	for page := 1; page <= 2; page++ {
+		f.do(http.MethodGet, "/items/?page="+strconv.Itoa(page))
+	}
+
+	assert.Equal(t, 2, f.calls)
+}
+
+func TestResponseCache_KeysByContentLanguage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	rc := NewResponseCache(cache.NewLRU(100), time.Minute)
+	calls := 0
+	router.Use(func(c *gin.Context) {
+		c.Header("Content-Language", c.GetHeader("Accept-Language"))
+	})
+	router.GET("/items/", rc.Cache("item"), func(c *gin.Context) {
+		calls++
+		c.JSON(http.StatusOK, gin.H{"language": c.GetHeader("Accept-Language")})
+	})
+
+	get := func(language string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/items/", nil)
+		req.Header.Set("Accept-Language", language)
+		router.ServeHTTP(w, req)
+		return w
+	}
+	get("en")
+	thai := get("th")
+	again := get("th")
+
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, "MISS", thai.Header().Get("X-Cache"))
+	assert.Equal(t, "HIT", again.Header().Get("X-Cache"))
+	assert.JSONEq(t, `{"language":"th"}`, again.Body.String())
+}
+
+func TestResponseCache_SkipsErrorResponses(t *testing.T) {
+	f := newResponseCacheFixture()
+	f.status = http.StatusInternalServerError
+
+	f.do(http.MethodGet, "/items/")
+	f.do(http.MethodGet, "/items/")
+
+	assert.Equal(t, 2, f.calls)
+}
+
+func TestResponseCache_FlushOnWrite(t *testing.T) {
+	tests := []struct {
+		name        string
+		writeStatus int
+		expected    int
+	}{
+		{name: "successful write flushes", writeStatus: http.StatusCreated, expected: 2},
+		{name: "failed write keeps entries", writeStatus: http.StatusBadRequest, expected: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newResponseCacheFixture()
+			f.do(http.MethodGet, "/items/")
+
+			f.status = tt.writeStatus
+			f.do(http.MethodPost, "/items/")
+			f.status = http.StatusOK
+
+			f.do(http.MethodGet, "/items/")
+			assert.Equal(t, tt.expected, f.calls)
+		})
+	}
+}
@@ -1,15 +1,56 @@
 package logger
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/sirawatc/simple-gin-crud/pkg/middleware"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+	"gopkg.in/natefinch/lumberjack.v2"
 )
 
+const (
+	FormatText   = "text"
+	FormatJSON   = "json"
+	FormatLogfmt = "logfmt"
+
+	OutputStdout = "stdout"
+	OutputStderr = "stderr"
+	OutputFile   = "file"
+)
+
+const timestampFormat = "2006-01-02T15:04:05Z07:00"
+
+var defaultLocation = time.FixedZone("GMT+7", 7*3600)
+
+// reservedKeys are written by every format. Entry fields with the same name
+// are renamed by fieldKey, as logrus does, so they cannot replace them.
+var reservedKeys = map[string]bool{"service": true, "time": true, "level": true, "msg": true}
+
+type Options struct {
+	Level    string
+	Format   string
+	TimeZone string
+	Output   string
+	File     FileOptions
+}
+
+type FileOptions struct {
+	Path       string
+	MaxSizeMB  int
+	MaxBackups int
+	MaxAgeDays int
+	Compress   bool
+}
+
 type customFormatter struct {
 	format func(entry *logrus.Entry) ([]byte, error)
 }
@@ -30,17 +71,191 @@ func NewLogger(serviceName string) *logrus.Logger {
 	return logger
 }
 
+func NewLoggerWithOptions(serviceName string, opts Options) (*logrus.Logger, error) {
+	level := logrus.InfoLevel
+	if opts.Level != "" {
+		parsed, err := logrus.ParseLevel(opts.Level)
+		if err != nil {
+			return nil, err
+		}
+		level = parsed
+	}
+
+	location, err := loadLocation(opts.TimeZone)
+	if err != nil {
+		return nil, err
+	}
+
+	format, err := newLogFormat(opts.Format, serviceName, location)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := newOutput(opts.Output, opts.File)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := logrus.New()
+
+	logger.SetOutput(output)
+
+	logger.SetLevel(level)
+
+	logger.SetFormatter(&customFormatter{format})
+
+	return logger, nil
+}
+
+func loadLocation(timeZone string) (*time.Location, error) {
+	if timeZone == "" {
+		return defaultLocation, nil
+	}
+	location, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid log timezone %q: %w", timeZone, err)
+	}
+	return location, nil
+}
+
+func newLogFormat(format string, serviceName string, location *time.Location) (func(entry *logrus.Entry) ([]byte, error), error) {
+	switch strings.ToLower(format) {
+	case "", FormatText:
+		return TextLogFormat(serviceName, location), nil
+	case FormatJSON:
+		return JSONLogFormat(serviceName, location), nil
+	case FormatLogfmt:
+		return LogfmtLogFormat(serviceName, location), nil
+	default:
+		return nil, fmt.Errorf("invalid log format %q", format)
+	}
+}
+
+func newOutput(output string, file FileOptions) (io.Writer, error) {
+	switch strings.ToLower(output) {
+	case "", OutputStdout:
+		return os.Stdout, nil
+	case OutputStderr:
+		return os.Stderr, nil
+	case OutputFile:
+		if file.Path == "" {
+			return nil, fmt.Errorf("log file path is required when output is %q", OutputFile)
+		}
+		return &lumberjack.Logger{
+			Filename:   file.Path,
+			MaxSize:    file.MaxSizeMB,
+			MaxBackups: file.MaxBackups,
+			MaxAge:     file.MaxAgeDays,
+			Compress:   file.Compress,
+		}, nil
+	default:
+		return nil, fmt.Errorf("invalid log output %q", output)
+	}
+}
+
 func DefaultLogFormat(serviceName string) func(entry *logrus.Entry) ([]byte, error) {
+	return TextLogFormat(serviceName, defaultLocation)
+}
+
+func TextLogFormat(serviceName string, location *time.Location) func(entry *logrus.Entry) ([]byte, error) {
 	return func(entry *logrus.Entry) ([]byte, error) {
-		timestamp := entry.Time.In(time.FixedZone("GMT+7", 7*3600)).Format("2006-01-02T15:04:05Z07:00")
+		timestamp := entry.Time.In(location).Format(timestampFormat)
 		logLevel := entry.Level.String()
 		message := entry.Message
 		requestId := entry.Data["requestId"]
-		formattedMsg := fmt.Sprintf("[%s] [%s] [%s] : { requestId: %v, msg: %s }\n", serviceName, timestamp, logLevel, requestId, message)
+
+		var fields strings.Builder
+		for _, key := range sortedKeys(entry.Data) {
+			if key == "requestId" {
+				continue
+			}
+			fmt.Fprintf(&fields, ", %s: %v", fieldKey(key), fieldValue(entry.Data[key]))
+		}
+
+		formattedMsg := fmt.Sprintf("[%s] [%s] [%s] : { requestId: %v, msg: %s%s }\n", serviceName, timestamp, logLevel, requestId, message, fields.String())
 		return []byte(formattedMsg), nil
 	}
 }
 
+func JSONLogFormat(serviceName string, location *time.Location) func(entry *logrus.Entry) ([]byte, error) {
+	return func(entry *logrus.Entry) ([]byte, error) {
+		data := make(map[string]interface{}, len(entry.Data)+4)
+		for key, value := range entry.Data {
+			data[fieldKey(key)] = fieldValue(value)
+		}
+		data["service"] = serviceName
+		data["time"] = entry.Time.In(location).Format(timestampFormat)
+		data["level"] = entry.Level.String()
+		data["msg"] = entry.Message
+
+		var buf bytes.Buffer
+		encoder := json.NewEncoder(&buf)
+		encoder.SetEscapeHTML(false)
+		if err := encoder.Encode(data); err != nil {
+			return nil, fmt.Errorf("failed to marshal log entry to JSON: %w", err)
+		}
+		return buf.Bytes(), nil
+	}
+}
+
+func LogfmtLogFormat(serviceName string, location *time.Location) func(entry *logrus.Entry) ([]byte, error) {
+	return func(entry *logrus.Entry) ([]byte, error) {
+		var buf bytes.Buffer
+		writeLogfmtPair(&buf, "service", serviceName)
+		writeLogfmtPair(&buf, "time", entry.Time.In(location).Format(timestampFormat))
+		writeLogfmtPair(&buf, "level", entry.Level.String())
+		writeLogfmtPair(&buf, "msg", entry.Message)
+		for _, key := range sortedKeys(entry.Data) {
+			writeLogfmtPair(&buf, fieldKey(key), fmt.Sprint(fieldValue(entry.Data[key])))
+		}
+		buf.WriteByte('\n')
+		return buf.Bytes(), nil
+	}
+}
+
+func writeLogfmtPair(buf *bytes.Buffer, key string, value string) {
+	if buf.Len() > 0 {
+		buf.WriteByte(' ')
+	}
+	buf.WriteString(key)
+	buf.WriteByte('=')
+	if value == "" || strings.ContainsAny(value, " =\"\t\r\n") {
+		buf.WriteString(fmt.Sprintf("%q", value))
+		return
+	}
+	buf.WriteString(value)
+}
+
+// fieldKey prefixes an entry field that clashes with a reserved key with
+// "fields.".
+func fieldKey(key string) string {
+	if reservedKeys[key] {
+		return "fields." + key
+	}
+	return key
+}
+
+func fieldValue(value interface{}) interface{} {
+	if err, ok := value.(error); ok {
+		return err.Error()
+	}
+	return value
+}
+
+func sortedKeys(data logrus.Fields) []string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 func InjectRequestIDWithLogger(ctx context.Context, logger *logrus.Logger) *logrus.Entry {
-	return logger.WithField("requestId", middleware.GetRequestID(ctx))
+	fields := logrus.Fields{"requestId": middleware.GetRequestID(ctx)}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		fields["traceId"] = spanContext.TraceID().String()
+		fields["spanId"] = spanContext.SpanID().String()
+	}
+	return logger.WithFields(fields)
 }
@@ -2,11 +2,19 @@ package logger
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func TestNewLogger(t *testing.T) {
@@ -92,3 +100,214 @@ func TestLogger_Formatting(t *testing.T) {
 	assert.Contains(t, output, "test-service")
 	assert.Contains(t, output, "requestId: <nil>")
 }
+
+func TestLogger_TextFormatKeepsAllFields(t *testing.T) {
+	logger := NewLogger("test-service")
+	var buf bytes.Buffer
+	logger.SetOutput(&buf)
+
+	logger.WithFields(logrus.Fields{
+		"requestId": "req-123",
+		"user_id":   "123",
+		"action":    "login",
+	}).Info("User logged in")
+
+	output := buf.String()
+	assert.Contains(t, output, "{ requestId: req-123, msg: User logged in, action: login, user_id: 123 }")
+}
+
+func TestNewLoggerWithOptions(t *testing.T) {
+	tests := []struct {
+		name          string
+		opts          Options
+		expectedLevel logrus.Level
+		expectError   bool
+	}{
+		{
+			name:          "defaults",
+			opts:          Options{},
+			expectedLevel: logrus.InfoLevel,
+		},
+		{
+			name:          "debug level with json format",
+			opts:          Options{Level: "debug", Format: FormatJSON, TimeZone: "UTC"},
+			expectedLevel: logrus.DebugLevel,
+		},
+		{
+			name:        "invalid level",
+			opts:        Options{Level: "verbose"},
+			expectError: true,
+		},
+		{
+			name:        "invalid format",
+			opts:        Options{Format: "xml"},
+			expectError: true,
+		},
+		{
+			name:        "invalid timezone",
+			opts:        Options{TimeZone: "Mars/Olympus"},
+			expectError: true,
+		},
+		{
+			name:        "invalid output",
+			opts:        Options{Output: "syslog"},
+			expectError: true,
+		},
+		{
+			name:        "file output without path",
+			opts:        Options{Output: OutputFile},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger, err := NewLoggerWithOptions("test-service", tt.opts)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Nil(t, logger)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedLevel, logger.GetLevel())
+		})
+	}
+}
+
+func TestNewLoggerWithOptions_StderrOutput(t *testing.T) {
+	logger, err := NewLoggerWithOptions("test-service", Options{Output: OutputStderr})
+
+	assert.NoError(t, err)
+	assert.Equal(t, os.Stderr, logger.Out)
+}
+
+func TestNewLoggerWithOptions_FileOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	logger, err := NewLoggerWithOptions("test-service", Options{
+		Output: OutputFile,
+		File:   FileOptions{Path: path, MaxSizeMB: 1},
+	})
+	assert.NoError(t, err)
+
+	logger.Info("Written to file")
+	closer, ok := logger.Out.(io.Closer)
+	assert.True(t, ok)
+	assert.NoError(t, closer.Close())
+
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "msg: Written to file")
+}
+
+func TestLogger_JSONFormat(t *testing.T) {
+	logger, err := NewLoggerWithOptions("test-service", Options{Format: FormatJSON, TimeZone: "UTC"})
+	assert.NoError(t, err)
+	var buf bytes.Buffer
+	logger.SetOutput(&buf)
+
+	logger.WithFields(logrus.Fields{
+		"requestId": "req-123",
+		"count":     2,
+		"error":     errors.New("boom"),
+	}).Warn("Something happened")
+
+	var output map[string]interface{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &output))
+	assert.Equal(t, "test-service", output["service"])
+	assert.Equal(t, "warning", output["level"])
+	assert.Equal(t, "Something happened", output["msg"])
+	assert.Equal(t, "req-123", output["requestId"])
+	assert.Equal(t, float64(2), output["count"])
+	assert.Equal(t, "boom", output["error"])
+	assert.True(t, strings.HasSuffix(output["time"].(string), "Z"))
+}
+
+func TestLogger_LogfmtFormat(t *testing.T) {
+	logger, err := NewLoggerWithOptions("test-service", Options{Format: FormatLogfmt, TimeZone: "UTC"})
+	assert.NoError(t, err)
+	var buf bytes.Buffer
+	logger.SetOutput(&buf)
+
+	logger.WithFields(logrus.Fields{
+		"requestId": "req-123",
+		"path":      "/v1/book",
+	}).Info("Request processed")
+
+	output := buf.String()
+	assert.True(t, strings.HasPrefix(output, "service=test-service time="))
+	assert.Contains(t, output, "level=info")
+	assert.Contains(t, output, `msg="Request processed"`)
+	assert.Contains(t, output, "path=/v1/book requestId=req-123\n")
+}
+
+func TestLogger_FieldsCannotReplaceReservedKeys(t *testing.T) {
+	fields := logrus.Fields{
+		"msg":     "from field",
+		"level":   "debug",
+		"time":    "yesterday",
+		"service": "other",
+	}
+
+	logger, err := NewLoggerWithOptions("test-service", Options{Format: FormatJSON, TimeZone: "UTC"})
+	assert.NoError(t, err)
+	var buf bytes.Buffer
+	logger.SetOutput(&buf)
+	logger.WithFields(fields).Error("Real message")
+
+	var output map[string]interface{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &output))
+	assert.Equal(t, "Real message", output["msg"])
+	assert.Equal(t, "error", output["level"])
+	assert.Equal(t, "test-service", output["service"])
+	assert.True(t, strings.HasSuffix(output["time"].(string), "Z"))
+	assert.Equal(t, "from field", output["fields.msg"])
+	assert.Equal(t, "debug", output["fields.level"])
+	assert.Equal(t, "yesterday", output["fields.time"])
+	assert.Equal(t, "other", output["fields.service"])
+
+	logger, err = NewLoggerWithOptions("test-service", Options{Format: FormatLogfmt, TimeZone: "UTC"})
+	assert.NoError(t, err)
+	buf.Reset()
+	logger.SetOutput(&buf)
+	logger.WithFields(fields).Error("Real message")
+
+	line := buf.String()
+	assert.Equal(t, 1, strings.Count(line, " msg="))
+	assert.Contains(t, line, `msg="Real message"`)
+	assert.Contains(t, line, `fields.msg="from field"`)
+	assert.Contains(t, line, "fields.level=debug")
+}
+
+func TestLogger_TimeZone(t *testing.T) {
+	logger, err := NewLoggerWithOptions("test-service", Options{TimeZone: "UTC"})
+	assert.NoError(t, err)
+	var buf bytes.Buffer
+	logger.SetOutput(&buf)
+
+	entry := logger.WithTime(time.Date(2025, 1, 2, 3, 4, 5, 0, time.FixedZone("GMT+7", 7*3600)))
+	entry.Info("Timestamped")
+
+	assert.Contains(t, buf.String(), "[2025-01-01T20:04:05Z]")
+}
+
+func TestInjectRequestIDWithLogger(t *testing.T) {
+	logger := NewLogger("test-service")
+
+	entry := InjectRequestIDWithLogger(context.Background(), logger)
+	assert.Equal(t, "", entry.Data["requestId"])
+	assert.NotContains(t, entry.Data, "traceId")
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+
+	entry = InjectRequestIDWithLogger(ctx, logger)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", entry.Data["traceId"])
+	assert.Equal(t, "00f067aa0ba902b7", entry.Data["spanId"])
+}
@@ -0,0 +1,52 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type metricsTestModel struct {
+	ID   int
+	Name string
+}
+
+func TestGormPlugin(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer sqlDB.Close()
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, Instrument(db, "gorm_plugin_test"))
+
+	mock.ExpectQuery(`SELECT \* FROM "metrics_test_models"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "name"))
+	mock.ExpectQuery(`SELECT \* FROM "metrics_test_models"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+
+	before := sampleCount(t, "query", "metrics_test_models", "ok")
+
+	var model metricsTestModel
+	assert.NoError(t, db.First(&model).Error)
+	assert.ErrorIs(t, db.First(&model).Error, gorm.ErrRecordNotFound)
+
+	assert.Equal(t, before+2, sampleCount(t, "query", "metrics_test_models", "ok"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func sampleCount(t *testing.T, operation string, table string, result string) uint64 {
+	metric := &dto.Metric{}
+	observer := dbQueryDuration.WithLabelValues(operation, table, result)
+	assert.NoError(t, observer.(prometheus.Metric).Write(metric))
+	return metric.GetHistogram().GetSampleCount()
+}
+
+func TestGormPlugin_Name(t *testing.T) {
+	assert.Equal(t, "metrics", NewGormPlugin().Name())
+}
@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	httpRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Total number of HTTP requests by method, route template, status and response code.",
+	}, []string{"method", "route", "status", "code"})
+
+	httpRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "HTTP request latency by method, route template, status and response code.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route", "status", "code"})
+
+	dbQueryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "db_query_duration_seconds",
+		Help:    "Database query latency by operation, table and result.",
+		Buckets: []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"operation", "table", "result"})
+
+	domainEventsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "domain_events_total",
+		Help: "Total number of domain events by domain and event.",
+	}, []string{"domain", "event"})
+
+	cacheRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "cache_requests_total",
+		Help: "Total number of cache lookups by cache name and result.",
+	}, []string{"cache", "result"})
+)
+
+const (
+	DomainAuthor = "author"
+	DomainBook   = "book"
+
+	EventCreated       = "created"
+	EventNotFound      = "not_found"
+	EventAlreadyExists = "already_exists"
+	EventISBNConflict  = "isbn_conflict"
+
+	CacheHit  = "hit"
+	CacheMiss = "miss"
+)
+
+func RecordDomainEvent(domain string, event string) {
+	domainEventsTotal.WithLabelValues(domain, event).Inc()
+}
+
+func RecordCacheResult(cache string, result string) {
+	cacheRequestsTotal.WithLabelValues(cache, result).Inc()
+}
+
+func RegisterDBStats(db *sql.DB, dbName string) error {
+	err := prometheus.Register(collectors.NewDBStatsCollector(db, dbName))
+	var alreadyRegistered prometheus.AlreadyRegisteredError
+	if errors.As(err, &alreadyRegistered) {
+		return nil
+	}
+	return err
+}
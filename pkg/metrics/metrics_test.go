@@ -0,0 +1,40 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordDomainEvent(t *testing.T) {
+	before := testutil.ToFloat64(domainEventsTotal.WithLabelValues(DomainBook, EventCreated))
+
+	RecordDomainEvent(DomainBook, EventCreated)
+	RecordDomainEvent(DomainBook, EventCreated)
+
+	after := testutil.ToFloat64(domainEventsTotal.WithLabelValues(DomainBook, EventCreated))
+	assert.Equal(t, before+2, after)
+}
+
+func TestRecordCacheResult(t *testing.T) {
+	hits := testutil.ToFloat64(cacheRequestsTotal.WithLabelValues("record_cache_test", CacheHit))
+	misses := testutil.ToFloat64(cacheRequestsTotal.WithLabelValues("record_cache_test", CacheMiss))
+
+	RecordCacheResult("record_cache_test", CacheHit)
+	RecordCacheResult("record_cache_test", CacheHit)
+	RecordCacheResult("record_cache_test", CacheMiss)
+
+	assert.Equal(t, hits+2, testutil.ToFloat64(cacheRequestsTotal.WithLabelValues("record_cache_test", CacheHit)))
+	assert.Equal(t, misses+1, testutil.ToFloat64(cacheRequestsTotal.WithLabelValues("record_cache_test", CacheMiss)))
+}
+
+func TestRegisterDBStats(t *testing.T) {
+	db, _, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	assert.NoError(t, RegisterDBStats(db, "register_db_stats_test"))
+	assert.NoError(t, RegisterDBStats(db, "register_db_stats_test"))
+}
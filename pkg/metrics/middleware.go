@@ -0,0 +1,107 @@
+package metrics
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	unmatchedRoute  = "unmatched"
+	unknownCode     = "unknown"
+	codePrefixSize  = 64
+	responseCodeKey = "metrics.responseCode"
+)
+
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+func HTTPMiddleware(skipPaths ...string) gin.HandlerFunc {
+	skip := make(map[string]struct{}, len(skipPaths))
+	for _, path := range skipPaths {
+		skip[path] = struct{}{}
+	}
+
+	return func(c *gin.Context) {
+		if _, ok := skip[c.Request.URL.Path]; ok {
+			c.Next()
+			return
+		}
+
+		start := time.Now()
+		writer := &codeCapturingWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		status := strconv.Itoa(c.Writer.Status())
+		code := c.GetString(responseCodeKey)
+		if code == "" {
+			code = responseCode(writer.prefix.Bytes())
+		}
+
+		httpRequestsTotal.WithLabelValues(c.Request.Method, route, status, code).Inc()
+		httpRequestDuration.WithLabelValues(c.Request.Method, route, status, code).Observe(time.Since(start).Seconds())
+	}
+}
+
+// SetResponseCode records the response code for HTTPMiddleware. Responses
+// whose body does not start with a "code" field, such as problem details,
+// are otherwise counted as unknown.
+func SetResponseCode(c *gin.Context, code string) {
+	c.Set(responseCodeKey, code)
+}
+
+type codeCapturingWriter struct {
+	gin.ResponseWriter
+	prefix bytes.Buffer
+}
+
+func (w *codeCapturingWriter) Write(data []byte) (int, error) {
+	w.capture(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *codeCapturingWriter) WriteString(s string) (int, error) {
+	w.capture([]byte(s))
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *codeCapturingWriter) capture(data []byte) {
+	if remaining := codePrefixSize - w.prefix.Len(); remaining > 0 {
+		if len(data) > remaining {
+			data = data[:remaining]
+		}
+		w.prefix.Write(data)
+	}
+}
+
+// responseCode extracts the dto.Code from the leading "code" field of a
+// BaseResponse body without decoding the rest of the payload.
+func responseCode(prefix []byte) string {
+	decoder := json.NewDecoder(bytes.NewReader(prefix))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return unknownCode
+	}
+	if token, err := decoder.Token(); err != nil || token != "code" {
+		return unknownCode
+	}
+	token, err := decoder.Token()
+	if err != nil {
+		return unknownCode
+	}
+	if code, ok := token.(string); ok {
+		return code
+	}
+	return unknownCode
+}
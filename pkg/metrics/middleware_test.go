@@ -0,0 +1,81 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(HTTPMiddleware("/metrics"))
+	router.GET("/item/:id", func(c *gin.Context) {
+		c.JSON(http.StatusNotFound, gin.H{"code": "40401", "message": "Book not found"})
+	})
+	router.GET("/metrics", gin.WrapH(Handler()))
+
+	counter := httpRequestsTotal.WithLabelValues(http.MethodGet, "/item/:id", "404", "40401")
+	before := testutil.ToFloat64(counter)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/item/1", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, before+1, testutil.ToFloat64(counter))
+
+	unmatched := httpRequestsTotal.WithLabelValues(http.MethodGet, unmatchedRoute, "404", unknownCode)
+	before = testutil.ToFloat64(unmatched)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/does/not/exist", nil))
+	assert.Equal(t, before+1, testutil.ToFloat64(unmatched))
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `http_requests_total{code="40401",method="GET",route="/item/:id",status="404"}`)
+	assert.NotContains(t, w.Body.String(), `route="/metrics"`)
+}
+
+func TestHTTPMiddleware_ProblemResponse(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(HTTPMiddleware())
+	router.GET("/problem/:id", func(c *gin.Context) {
+		SetResponseCode(c, "40401")
+		c.Header("Content-Type", "application/problem+json")
+		c.String(http.StatusNotFound, `{"type":"/problems/book-not-found","title":"Book not found","status":404,"code":"40401"}`)
+	})
+
+	counter := httpRequestsTotal.WithLabelValues(http.MethodGet, "/problem/:id", "404", "40401")
+	before := testutil.ToFloat64(counter)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/problem/1", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, before+1, testutil.ToFloat64(counter))
+}
+
+func TestResponseCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected string
+	}{
+		{name: "base response", body: `{"code":"20000","message":"Success","data":{}}`, expected: "20000"},
+		{name: "truncated body", body: `{"code":"40901","mess`, expected: "40901"},
+		{name: "code not first", body: `{"message":"x","code":"20000"}`, expected: unknownCode},
+		{name: "non string code", body: `{"code":200}`, expected: unknownCode},
+		{name: "not json", body: `plain text`, expected: unknownCode},
+		{name: "empty", body: ``, expected: unknownCode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, responseCode([]byte(tt.body)))
+		})
+	}
+}
@@ -0,0 +1,276 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const redactedValue = "[REDACTED]"
+
+// maxCapturedBodyBytes bounds how much of a request body is buffered for
+// logging. The access log runs before the body limit, so it must not read
+// an unbounded body itself; larger bodies are passed on but not logged.
+const maxCapturedBodyBytes = 1 << 20
+
+type AccessLogOptions struct {
+	// SampleRate is the fraction (0..1) of ordinary requests that are logged.
+	// Failed and slow requests are always logged.
+	SampleRate        float64
+	SlowThreshold     time.Duration
+	VerySlowThreshold time.Duration
+	SkipPaths         []string
+	LogHeaders        bool
+	LogBody           bool
+	MaxBodyBytes      int
+	RedactHeaders     []string
+	RedactBodyFields  []string
+}
+
+func DefaultAccessLogOptions() AccessLogOptions {
+	return AccessLogOptions{
+		SampleRate:        1,
+		SlowThreshold:     500 * time.Millisecond,
+		VerySlowThreshold: 2 * time.Second,
+		MaxBodyBytes:      4096,
+		RedactHeaders:     []string{"Authorization", "Cookie", "Set-Cookie", "X-Api-Key"},
+		RedactBodyFields:  []string{"password", "token", "secret", "accessToken", "refreshToken"},
+	}
+}
+
+func AccessLogMiddleware(logger *logrus.Logger, opts AccessLogOptions) gin.HandlerFunc {
+	skipPaths := toLowerSet(opts.SkipPaths)
+	redactHeaders := toLowerSet(opts.RedactHeaders)
+	redactFields := toLowerSet(opts.RedactBodyFields)
+
+	return func(c *gin.Context) {
+		if _, ok := skipPaths[strings.ToLower(c.Request.URL.Path)]; ok {
+			c.Next()
+			return
+		}
+
+		start := time.Now()
+
+		var body []byte
+		counter := &countingReader{}
+		if c.Request.Body != nil {
+			if opts.LogBody {
+				body, _ = io.ReadAll(io.LimitReader(c.Request.Body, maxCapturedBodyBytes+1))
+				c.Request.Body = struct {
+					io.Reader
+					io.Closer
+				}{io.MultiReader(bytes.NewReader(body), c.Request.Body), c.Request.Body}
+			}
+			counter.reader = c.Request.Body
+			c.Request.Body = struct {
+				io.Reader
+				io.Closer
+			}{counter, c.Request.Body}
+		}
+
+		// Logging from a deferred func also covers handlers that panic; the
+		// panic is passed on to Recovery, which responds with a 500.
+		defer func() {
+			status := c.Writer.Status()
+			if err := recover(); err != nil {
+				status = http.StatusInternalServerError
+				defer panic(err)
+			}
+
+			latency := time.Since(start)
+			level := accessLogLevel(status, latency, opts)
+
+			if level == logrus.InfoLevel && !sampled(opts.SampleRate) {
+				return
+			}
+
+			route := c.FullPath()
+			if route == "" {
+				route = c.Request.URL.Path
+			}
+
+			bytesIn := c.Request.ContentLength
+			if bytesIn < 0 {
+				bytesIn = counter.bytes
+			}
+
+			bytesOut := c.Writer.Size()
+			if bytesOut < 0 {
+				bytesOut = 0
+			}
+
+			fields := logrus.Fields{
+				"requestId": GetRequestID(c.Request.Context()),
+				"method":    c.Request.Method,
+				"route":     route,
+				"status":    status,
+				"latencyMs": float64(latency.Microseconds()) / 1000,
+				"bytesIn":   bytesIn,
+				"bytesOut":  bytesOut,
+				"clientIp":  c.ClientIP(),
+				"userAgent": c.Request.UserAgent(),
+			}
+
+			if identity, ok := GetClientIdentity(c.Request.Context()); ok {
+				fields["clientCn"] = identity.CommonName
+			}
+
+			if spanContext := trace.SpanContextFromContext(c.Request.Context()); spanContext.IsValid() {
+				fields["traceId"] = spanContext.TraceID().String()
+			}
+
+			if opts.LogHeaders {
+				fields["headers"] = redactHeaderValues(c.Request.Header, redactHeaders)
+			}
+
+			switch {
+			case !opts.LogBody || len(body) == 0:
+			case len(body) > maxCapturedBodyBytes:
+				fields["body"] = fmt.Sprintf("(omitted: larger than %d bytes)", maxCapturedBodyBytes)
+			default:
+				fields["body"] = redactBody(body, c.ContentType(), redactFields, opts.MaxBodyBytes)
+			}
+
+			if len(c.Errors) > 0 {
+				fields["errors"] = c.Errors.String()
+			}
+
+			logger.WithFields(fields).Logf(level, "[AccessLog] %s %s %d", c.Request.Method, route, status)
+		}()
+
+		c.Next()
+	}
+}
+
+func accessLogLevel(status int, latency time.Duration, opts AccessLogOptions) logrus.Level {
+	level := logrus.InfoLevel
+
+	switch {
+	case status >= http.StatusInternalServerError:
+		level = logrus.ErrorLevel
+	case status >= http.StatusBadRequest:
+		level = logrus.WarnLevel
+	}
+
+	switch {
+	case opts.VerySlowThreshold > 0 && latency >= opts.VerySlowThreshold:
+		level = logrus.ErrorLevel
+	case opts.SlowThreshold > 0 && latency >= opts.SlowThreshold && level > logrus.WarnLevel:
+		level = logrus.WarnLevel
+	}
+
+	return level
+}
+
+func sampled(rate float64) bool {
+	if rate >= 1 {
+		return true
+	}
+	if rate <= 0 {
+		return false
+	}
+	return rand.Float64() < rate
+}
+
+func redactHeaderValues(header http.Header, redact map[string]struct{}) map[string]string {
+	values := make(map[string]string, len(header))
+	for key := range header {
+		if _, ok := redact[strings.ToLower(key)]; ok {
+			values[key] = redactedValue
+			continue
+		}
+		values[key] = header.Get(key)
+	}
+	return values
+}
+
+func redactBody(body []byte, contentType string, redact map[string]struct{}, maxBytes int) string {
+	if contentType == binding.MIMEPOSTForm {
+		body = redactForm(body, redact)
+	} else {
+		// A body that is not valid JSON, such as a truncated or multipart
+		// one, cannot be redacted, so only its size is logged.
+		var payload interface{}
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return fmt.Sprintf("[unparsed %d bytes]", len(body))
+		}
+		redacted, err := json.Marshal(redactJSON(payload, redact))
+		if err != nil {
+			return fmt.Sprintf("[unparsed %d bytes]", len(body))
+		}
+		body = redacted
+	}
+
+	if maxBytes > 0 && len(body) > maxBytes {
+		return string(body[:maxBytes]) + "...(truncated)"
+	}
+	return string(body)
+}
+
+// redactForm masks the values of redacted keys in a form-encoded body,
+// keeping the other pairs as they were sent.
+func redactForm(body []byte, redact map[string]struct{}) []byte {
+	pairs := strings.Split(string(body), "&")
+	for i, pair := range pairs {
+		key, _, _ := strings.Cut(pair, "=")
+		name := key
+		if unescaped, err := url.QueryUnescape(key); err == nil {
+			name = unescaped
+		}
+		if _, ok := redact[strings.ToLower(name)]; ok {
+			pairs[i] = key + "=" + redactedValue
+		}
+	}
+	return []byte(strings.Join(pairs, "&"))
+}
+
+func redactJSON(value interface{}, redact map[string]struct{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if _, ok := redact[strings.ToLower(key)]; ok {
+				v[key] = redactedValue
+				continue
+			}
+			v[key] = redactJSON(item, redact)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactJSON(item, redact)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+func toLowerSet(values []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(values))
+	for _, value := range values {
+		set[strings.ToLower(value)] = struct{}{}
+	}
+	return set
+}
+
+type countingReader struct {
+	reader io.Reader
+	bytes  int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.bytes += int64(n)
+	return n, err
+}
@@ -0,0 +1,304 @@
+package middleware
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupAccessLogRouter(opts AccessLogOptions) (*gin.Engine, *test.Hook) {
+	gin.SetMode(gin.TestMode)
+	logger, hook := test.NewNullLogger()
+	logger.SetLevel(logrus.DebugLevel)
+
+	router := gin.New()
+	router.Use(RequestIDMiddleware())
+	router.Use(AccessLogMiddleware(logger, opts))
+
+	router.GET("/book/:id", func(c *gin.Context) {
+		c.String(http.StatusOK, "hello")
+	})
+	router.POST("/book", func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		c.String(http.StatusCreated, string(body))
+	})
+	router.GET("/fail", func(c *gin.Context) {
+		c.Status(http.StatusInternalServerError)
+	})
+	router.GET("/missing", func(c *gin.Context) {
+		c.Status(http.StatusNotFound)
+	})
+	router.GET("/slow", func(c *gin.Context) {
+		time.Sleep(20 * time.Millisecond)
+		c.Status(http.StatusOK)
+	})
+	router.GET("/health", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	return router, hook
+}
+
+func TestAccessLogMiddleware_Fields(t *testing.T) {
+	router, hook := setupAccessLogRouter(DefaultAccessLogOptions())
+
+	req := httptest.NewRequest(http.MethodGet, "/book/123", nil)
+	req.Header.Set(RequestIDHeader, "req-123")
+	req.Header.Set("User-Agent", "test-agent")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	entry := hook.LastEntry()
+	assert.NotNil(t, entry)
+	assert.Equal(t, logrus.InfoLevel, entry.Level)
+	assert.Equal(t, "[AccessLog] GET /book/:id 200", entry.Message)
+	assert.Equal(t, "req-123", entry.Data["requestId"])
+	assert.Equal(t, http.MethodGet, entry.Data["method"])
+	assert.Equal(t, "/book/:id", entry.Data["route"])
+	assert.Equal(t, http.StatusOK, entry.Data["status"])
+	assert.Equal(t, int64(0), entry.Data["bytesIn"])
+	assert.Equal(t, 5, entry.Data["bytesOut"])
+	assert.Equal(t, "test-agent", entry.Data["userAgent"])
+	assert.Contains(t, entry.Data, "latencyMs")
+	assert.Contains(t, entry.Data, "clientIp")
+	assert.NotContains(t, entry.Data, "headers")
+	assert.NotContains(t, entry.Data, "body")
+}
+
+func TestAccessLogMiddleware_Levels(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		opts     AccessLogOptions
+		expected logrus.Level
+	}{
+		{
+			name:     "server error logs at error level",
+			path:     "/fail",
+			opts:     DefaultAccessLogOptions(),
+			expected: logrus.ErrorLevel,
+		},
+		{
+			name:     "client error logs at warn level",
+			path:     "/missing",
+			opts:     DefaultAccessLogOptions(),
+			expected: logrus.WarnLevel,
+		},
+		{
+			name:     "slow request escalates to warn level",
+			path:     "/slow",
+			opts:     AccessLogOptions{SampleRate: 1, SlowThreshold: time.Millisecond, VerySlowThreshold: time.Hour},
+			expected: logrus.WarnLevel,
+		},
+		{
+			name:     "very slow request escalates to error level",
+			path:     "/slow",
+			opts:     AccessLogOptions{SampleRate: 1, SlowThreshold: time.Millisecond, VerySlowThreshold: 2 * time.Millisecond},
+			expected: logrus.ErrorLevel,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, hook := setupAccessLogRouter(tt.opts)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			entry := hook.LastEntry()
+			assert.NotNil(t, entry)
+			assert.Equal(t, tt.expected, entry.Level)
+		})
+	}
+}
+
+func TestAccessLogMiddleware_Sampling(t *testing.T) {
+	opts := DefaultAccessLogOptions()
+	opts.SampleRate = 0
+	router, hook := setupAccessLogRouter(opts)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/book/123", nil))
+	assert.Empty(t, hook.AllEntries())
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/fail", nil))
+	assert.Len(t, hook.AllEntries(), 1)
+}
+
+func TestAccessLogMiddleware_SkipPaths(t *testing.T) {
+	opts := DefaultAccessLogOptions()
+	opts.SkipPaths = []string{"/health"}
+	router, hook := setupAccessLogRouter(opts)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, hook.AllEntries())
+}
+
+func TestAccessLogMiddleware_Redaction(t *testing.T) {
+	opts := DefaultAccessLogOptions()
+	opts.LogHeaders = true
+	opts.LogBody = true
+	router, hook := setupAccessLogRouter(opts)
+
+	body := `{"name":"Book","password":"p@ss","nested":{"Token":"abc"}}`
+	req := httptest.NewRequest(http.MethodPost, "/book", bytes.NewBufferString(body))
+	req.Header.Set("Authorization", "Bearer secret")
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, body, w.Body.String())
+
+	entry := hook.LastEntry()
+	assert.NotNil(t, entry)
+	assert.Equal(t, int64(len(body)), entry.Data["bytesIn"])
+
+	headers := entry.Data["headers"].(map[string]string)
+	assert.Equal(t, "[REDACTED]", headers["Authorization"])
+	assert.Equal(t, "application/json", headers["Content-Type"])
+
+	loggedBody := entry.Data["body"].(string)
+	assert.Contains(t, loggedBody, `"name":"Book"`)
+	assert.Contains(t, loggedBody, `"password":"[REDACTED]"`)
+	assert.Contains(t, loggedBody, `"Token":"[REDACTED]"`)
+	assert.NotContains(t, loggedBody, "p@ss")
+}
+
+func TestAccessLogMiddleware_FormRedaction(t *testing.T) {
+	opts := DefaultAccessLogOptions()
+	opts.LogBody = true
+	router, hook := setupAccessLogRouter(opts)
+
+	body := "name=Book&Password=p%40ss&access%54oken=abc&tags=a&tags=b"
+	req := httptest.NewRequest(http.MethodPost, "/book", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, body, w.Body.String())
+
+	entry := hook.LastEntry()
+	assert.NotNil(t, entry)
+	assert.Equal(t, "name=Book&Password=[REDACTED]&access%54oken=[REDACTED]&tags=a&tags=b", entry.Data["body"])
+}
+
+func TestAccessLogMiddleware_BodyTruncation(t *testing.T) {
+	opts := DefaultAccessLogOptions()
+	opts.LogBody = true
+	opts.MaxBodyBytes = 8
+	router, hook := setupAccessLogRouter(opts)
+
+	req := httptest.NewRequest(http.MethodPost, "/book", bytes.NewBufferString(`{"name":"Book"}`))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	entry := hook.LastEntry()
+	assert.NotNil(t, entry)
+	assert.Equal(t, `{"name":...(truncated)`, entry.Data["body"])
+}
+
+func TestAccessLogMiddleware_UnparsedBody(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		contentType string
+	}{
+		{
+			name:        "truncated JSON",
+			body:        `{"password":"p@ss",`,
+			contentType: "application/json",
+		},
+		{
+			name:        "multipart",
+			body:        "--b\r\nContent-Disposition: form-data; name=\"password\"\r\n\r\np@ss\r\n--b--\r\n",
+			contentType: "multipart/form-data; boundary=b",
+		},
+		{
+			name:        "plain text",
+			body:        "password=p@ss",
+			contentType: "text/plain",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := DefaultAccessLogOptions()
+			opts.LogBody = true
+			router, hook := setupAccessLogRouter(opts)
+
+			req := httptest.NewRequest(http.MethodPost, "/book", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.body, w.Body.String())
+
+			entry := hook.LastEntry()
+			assert.NotNil(t, entry)
+			assert.Equal(t, fmt.Sprintf("[unparsed %d bytes]", len(tt.body)), entry.Data["body"])
+		})
+	}
+}
+
+func TestAccessLogMiddleware_Panic(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger, hook := test.NewNullLogger()
+
+	router := gin.New()
+	router.Use(gin.RecoveryWithWriter(io.Discard))
+	router.Use(AccessLogMiddleware(logger, DefaultAccessLogOptions()))
+	router.GET("/panic", func(c *gin.Context) {
+		panic("boom")
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/panic", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	entry := hook.LastEntry()
+	assert.NotNil(t, entry)
+	assert.Equal(t, logrus.ErrorLevel, entry.Level)
+	assert.Equal(t, http.StatusInternalServerError, entry.Data["status"])
+	assert.Equal(t, "/panic", entry.Data["route"])
+}
+
+func TestAccessLogMiddleware_LargeBody(t *testing.T) {
+	opts := DefaultAccessLogOptions()
+	opts.LogBody = true
+	router, hook := setupAccessLogRouter(opts)
+
+	body := `{"password":"` + strings.Repeat("a", maxCapturedBodyBytes) + `"}`
+	req := httptest.NewRequest(http.MethodPost, "/book", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, len(body), w.Body.Len())
+
+	entry := hook.LastEntry()
+	assert.NotNil(t, entry)
+	assert.Equal(t, "(omitted: larger than 1048576 bytes)", entry.Data["body"])
+}
+
+func TestSampled(t *testing.T) {
+	assert.True(t, sampled(1))
+	assert.True(t, sampled(1.5))
+	assert.False(t, sampled(0))
+	assert.False(t, sampled(-1))
+}
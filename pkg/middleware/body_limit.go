@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BodyLimitOptions struct {
+	MaxBytes int64
+	// RouteMaxBytes overrides MaxBytes by gin route pattern, e.g.
+	// "/v1/book/".
+	RouteMaxBytes map[string]int64
+}
+
+// BodyLimitMiddleware caps request bodies at the route's limit. A request
+// whose Content-Length is over the limit is rejected up front by calling
+// reject; a longer body without one fails with *http.MaxBytesError when it
+// is read.
+func BodyLimitMiddleware(opts BodyLimitOptions, reject gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit, ok := opts.RouteMaxBytes[c.FullPath()]
+		if !ok {
+			limit = opts.MaxBytes
+		}
+		if limit <= 0 {
+			c.Next()
+			return
+		}
+
+		if c.Request.ContentLength > limit {
+			reject(c)
+			if !c.IsAborted() {
+				c.AbortWithStatus(http.StatusRequestEntityTooLarge)
+			}
+			return
+		}
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+		c.Next()
+	}
+}
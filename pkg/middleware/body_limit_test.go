@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBodyLimitMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(BodyLimitMiddleware(BodyLimitOptions{
+		MaxBytes:      8,
+		RouteMaxBytes: map[string]int64{"/large": 64},
+	}, func(c *gin.Context) {
+		c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{"rejected": true})
+	}))
+	var readErr error
+	handler := func(c *gin.Context) {
+		_, readErr = io.ReadAll(c.Request.Body)
+		c.Status(http.StatusOK)
+	}
+	router.POST("/small", handler)
+	router.POST("/large", handler)
+
+	tests := []struct {
+		name            string
+		path            string
+		body            string
+		chunked         bool
+		expectedStatus  int
+		expectedReadErr bool
+	}{
+		{name: "within limit", path: "/small", body: "12345678", expectedStatus: http.StatusOK},
+		{name: "over limit", path: "/small", body: "123456789", expectedStatus: http.StatusRequestEntityTooLarge},
+		{name: "route override", path: "/large", body: "123456789", expectedStatus: http.StatusOK},
+		{name: "chunked over limit", path: "/small", body: "123456789", chunked: true, expectedStatus: http.StatusOK, expectedReadErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			readErr = nil
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			if tt.chunked {
+				req.ContentLength = -1
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			var maxBytesErr *http.MaxBytesError
+			assert.Equal(t, tt.expectedReadErr, errors.As(readErr, &maxBytesErr))
+		})
+	}
+}
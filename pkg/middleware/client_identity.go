@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ClientIdentity describes the verified certificate a client presented over
+// mutual TLS.
+type ClientIdentity struct {
+	CommonName   string
+	Organization []string
+	DNSNames     []string
+	URIs         []string
+	SerialNumber string
+	// Fingerprint is the hex SHA-256 of the DER certificate.
+	Fingerprint string
+}
+
+type clientIdentityKey struct{}
+
+// ClientIdentityMiddleware stores the identity of a verified client
+// certificate in the request context. Requests without one pass through
+// unchanged.
+func ClientIdentityMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		state := c.Request.TLS
+		if state == nil || len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+			c.Next()
+			return
+		}
+
+		cert := state.VerifiedChains[0][0]
+		fingerprint := sha256.Sum256(cert.Raw)
+		identity := &ClientIdentity{
+			CommonName:   cert.Subject.CommonName,
+			Organization: cert.Subject.Organization,
+			DNSNames:     cert.DNSNames,
+			SerialNumber: cert.SerialNumber.Text(16),
+			Fingerprint:  hex.EncodeToString(fingerprint[:]),
+		}
+		for _, uri := range cert.URIs {
+			identity.URIs = append(identity.URIs, uri.String())
+		}
+
+		ctx := context.WithValue(c.Request.Context(), clientIdentityKey{}, identity)
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// GetClientIdentity returns the client certificate identity, if any.
+func GetClientIdentity(ctx context.Context) (*ClientIdentity, bool) {
+	identity, ok := ctx.Value(clientIdentityKey{}).(*ClientIdentity)
+	return identity, ok
+}
@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientIdentityMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(ClientIdentityMiddleware())
+	var identity *ClientIdentity
+	var found bool
+	router.GET("/", func(c *gin.Context) {
+		identity, found = GetClientIdentity(c.Request.Context())
+		c.Status(http.StatusOK)
+	})
+
+	spiffe, _ := url.Parse("spiffe://example.org/service/web")
+	cert := &x509.Certificate{
+		Raw:          []byte("certificate"),
+		SerialNumber: big.NewInt(255),
+		Subject:      pkix.Name{CommonName: "web", Organization: []string{"Example"}},
+		DNSNames:     []string{"web.example.org"},
+		URIs:         []*url.URL{spiffe},
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.True(t, found)
+	assert.Equal(t, &ClientIdentity{
+		CommonName:   "web",
+		Organization: []string{"Example"},
+		DNSNames:     []string{"web.example.org"},
+		URIs:         []string{"spiffe://example.org/service/web"},
+		SerialNumber: "ff",
+		Fingerprint:  "03d66dd08835c1ca3f128cceacd1f31ac94163096b20f445ae84285bc0832d72",
+	}, identity)
+
+	// Unverified peer certificates are ignored.
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}
+	router.ServeHTTP(httptest.NewRecorder(), req)
+	assert.False(t, found)
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.False(t, found)
+}
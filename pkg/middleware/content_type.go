@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"mime"
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ContentTypeMiddleware rejects requests that carry a body with a
+// Content-Type other than mediaTypes, calling reject to write the response.
+// Requests without a body are passed through.
+func ContentTypeMiddleware(mediaTypes []string, reject gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.ContentLength == 0 {
+			c.Next()
+			return
+		}
+
+		mediaType, _, err := mime.ParseMediaType(c.GetHeader("Content-Type"))
+		if err == nil && slices.Contains(mediaTypes, mediaType) {
+			c.Next()
+			return
+		}
+
+		reject(c)
+		if !c.IsAborted() {
+			c.AbortWithStatus(http.StatusUnsupportedMediaType)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContentTypeMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(ContentTypeMiddleware([]string{"application/json"}, func(c *gin.Context) {
+		c.AbortWithStatusJSON(http.StatusUnsupportedMediaType, gin.H{"rejected": true})
+	}))
+	router.POST("/", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	tests := []struct {
+		name           string
+		contentType    string
+		body           string
+		expectedStatus int
+	}{
+		{name: "json", contentType: "application/json", body: "{}", expectedStatus: http.StatusOK},
+		{name: "json with charset", contentType: "application/json; charset=utf-8", body: "{}", expectedStatus: http.StatusOK},
+		{name: "form", contentType: "application/x-www-form-urlencoded", body: "a=1", expectedStatus: http.StatusUnsupportedMediaType},
+		{name: "missing", body: "{}", expectedStatus: http.StatusUnsupportedMediaType},
+		{name: "malformed", contentType: "application/", body: "{}", expectedStatus: http.StatusUnsupportedMediaType},
+		{name: "no body", expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
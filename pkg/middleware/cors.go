@@ -0,0 +1,123 @@
+package middleware
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	CORSPresetDevelopment = "development"
+	CORSPresetProduction  = "production"
+)
+
+// CORSOptions configures CORS. "*" in AllowOrigins allows every origin.
+type CORSOptions struct {
+	AllowOrigins     []string
+	AllowMethods     []string
+	AllowHeaders     []string
+	ExposeHeaders    []string
+	AllowCredentials bool
+	MaxAge           time.Duration
+}
+
+var corsPresets = map[string]CORSOptions{
+	// development allows every origin so local frontends work out of the box.
+	CORSPresetDevelopment: {
+		AllowOrigins:  []string{"*"},
+		AllowMethods:  []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"},
+		AllowHeaders:  []string{"Origin", "Content-Length", "Content-Type", "Authorization", "X-Request-ID"},
+		ExposeHeaders: []string{"X-Request-ID", "Retry-After"},
+		MaxAge:        12 * time.Hour,
+	},
+	// production allows no origin until they are listed explicitly.
+	CORSPresetProduction: {
+		AllowOrigins:  []string{},
+		AllowMethods:  []string{"GET", "POST", "PUT", "DELETE", "HEAD", "OPTIONS"},
+		AllowHeaders:  []string{"Origin", "Content-Type", "Authorization", "X-Request-ID"},
+		ExposeHeaders: []string{"X-Request-ID", "Retry-After"},
+		MaxAge:        time.Hour,
+	},
+}
+
+// CORSPreset returns a copy of the named preset.
+func CORSPreset(name string) (CORSOptions, bool) {
+	preset, ok := corsPresets[name]
+	if !ok {
+		return CORSOptions{}, false
+	}
+	preset.AllowOrigins = append([]string{}, preset.AllowOrigins...)
+	preset.AllowMethods = append([]string{}, preset.AllowMethods...)
+	preset.AllowHeaders = append([]string{}, preset.AllowHeaders...)
+	preset.ExposeHeaders = append([]string{}, preset.ExposeHeaders...)
+	return preset, true
+}
+
+// ErrCORSCredentialsWithAnyOrigin rejects options that allow credentials
+// together with the "*" origin. gin-contrib/cors only guards against this
+// when it matches origins itself, so with our AllowOriginFunc every origin
+// would be reflected with Access-Control-Allow-Credentials: true.
+var ErrCORSCredentialsWithAnyOrigin = errors.New(`cors: AllowCredentials cannot be used with the "*" origin`)
+
+// Validate reports whether the options can be applied.
+func (opts CORSOptions) Validate() error {
+	if !opts.AllowCredentials {
+		return nil
+	}
+	for _, origin := range opts.AllowOrigins {
+		if origin == "*" {
+			return ErrCORSCredentialsWithAnyOrigin
+		}
+	}
+	return nil
+}
+
+// CORS wraps gin-contrib/cors with options that can be replaced while
+// serving.
+type CORS struct {
+	handler atomic.Pointer[gin.HandlerFunc]
+}
+
+func NewCORS(opts CORSOptions) (*CORS, error) {
+	c := &CORS{}
+	if err := c.SetOptions(opts); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// SetOptions replaces the options. Invalid options are rejected and the
+// current ones are kept.
+func (c *CORS) SetOptions(opts CORSOptions) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+
+	origins := map[string]bool{}
+	for _, origin := range opts.AllowOrigins {
+		origins[origin] = true
+	}
+
+	config := cors.Config{
+		AllowOriginFunc: func(origin string) bool {
+			return origins["*"] || origins[origin]
+		},
+		AllowMethods:     opts.AllowMethods,
+		AllowHeaders:     opts.AllowHeaders,
+		ExposeHeaders:    opts.ExposeHeaders,
+		AllowCredentials: opts.AllowCredentials,
+		MaxAge:           opts.MaxAge,
+	}
+	handler := cors.New(config)
+	c.handler.Store(&handler)
+	return nil
+}
+
+func (c *CORS) Middleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		(*c.handler.Load())(ctx)
+	}
+}
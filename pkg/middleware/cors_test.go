@@ -0,0 +1,108 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCORS_SetOptions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cors, err := NewCORS(CORSOptions{AllowOrigins: []string{"https://a.example"}})
+	assert.NoError(t, err)
+	router := gin.New()
+	router.Use(cors.Middleware())
+	router.GET("/", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	request := func(origin string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Origin", origin)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := request("https://a.example")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "https://a.example", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, http.StatusForbidden, request("https://b.example").Code)
+
+	assert.NoError(t, cors.SetOptions(CORSOptions{AllowOrigins: []string{"https://b.example"}, AllowCredentials: true}))
+	assert.Equal(t, http.StatusForbidden, request("https://a.example").Code)
+	w = request("https://b.example")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "true", w.Header().Get("Access-Control-Allow-Credentials"))
+
+	assert.NoError(t, cors.SetOptions(CORSOptions{AllowOrigins: []string{"*"}}))
+	assert.Equal(t, http.StatusOK, request("https://c.example").Code)
+}
+
+func TestCORS_RejectsCredentialsWithAnyOrigin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	invalid := CORSOptions{AllowOrigins: []string{"https://a.example", "*"}, AllowCredentials: true}
+
+	_, err := NewCORS(invalid)
+	assert.ErrorIs(t, err, ErrCORSCredentialsWithAnyOrigin)
+
+	cors, err := NewCORS(CORSOptions{AllowOrigins: []string{"https://a.example"}, AllowCredentials: true})
+	assert.NoError(t, err)
+	assert.ErrorIs(t, cors.SetOptions(invalid), ErrCORSCredentialsWithAnyOrigin)
+
+	router := gin.New()
+	router.Use(cors.Middleware())
+	router.GET("/", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Origin", "https://evil.example")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code, "the previous options stay in place")
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Credentials"))
+}
+
+func TestCORS_Preflight(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	opts, ok := CORSPreset(CORSPresetDevelopment)
+	assert.True(t, ok)
+	router := gin.New()
+	cors, err := NewCORS(opts)
+	assert.NoError(t, err)
+	router.Use(cors.Middleware())
+
+	req := httptest.NewRequest(http.MethodOptions, "/", nil)
+	req.Header.Set("Origin", "https://a.example")
+	req.Header.Set("Access-Control-Request-Method", http.MethodPut)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, "https://a.example", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Contains(t, w.Header().Get("Access-Control-Allow-Methods"), "PUT")
+	assert.Contains(t, w.Header().Get("Access-Control-Allow-Headers"), "X-Request-Id")
+	assert.Equal(t, "43200", w.Header().Get("Access-Control-Max-Age"))
+}
+
+func TestCORSPreset(t *testing.T) {
+	production, ok := CORSPreset(CORSPresetProduction)
+	assert.True(t, ok)
+	assert.Empty(t, production.AllowOrigins)
+	assert.False(t, production.AllowCredentials)
+	assert.Equal(t, time.Hour, production.MaxAge)
+
+	// Presets are copied so callers cannot change them.
+	production.AllowMethods[0] = "TRACE"
+	again, _ := CORSPreset(CORSPresetProduction)
+	assert.Equal(t, "GET", again.AllowMethods[0])
+
+	_, ok = CORSPreset("staging")
+	assert.False(t, ok)
+}
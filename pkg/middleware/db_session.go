@@ -0,0 +1,15 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/sirawatc/simple-gin-crud/pkg/repository"
+)
+
+// DBSessionMiddleware attaches a database session to the request context so
+// reads that follow a write in the same request stay on the primary.
+func DBSessionMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(repository.WithSession(c.Request.Context()))
+		c.Next()
+	}
+}
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirawatc/simple-gin-crud/pkg/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDBSessionMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(DBSessionMiddleware())
+	router.GET("/", func(c *gin.Context) {
+		ctx := c.Request.Context()
+		// WithSession is a no-op when a session is already attached.
+		assert.Equal(t, ctx, repository.WithSession(ctx))
+		c.Status(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
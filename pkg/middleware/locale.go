@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/text/language"
+)
+
+type localeKey struct{}
+
+// LocaleMiddleware picks the best match for Accept-Language among locales
+// (e.g. "en", "th"), falling back to the first one, stores it in the request
+// context and echoes it in Content-Language.
+func LocaleMiddleware(locales ...string) gin.HandlerFunc {
+	tags := make([]language.Tag, 0, len(locales))
+	for _, locale := range locales {
+		tags = append(tags, language.Make(locale))
+	}
+	matcher := language.NewMatcher(tags)
+
+	return func(c *gin.Context) {
+		_, index := language.MatchStrings(matcher, c.GetHeader("Accept-Language"))
+		locale := locales[index]
+
+		c.Header("Content-Language", locale)
+		c.Writer.Header().Add("Vary", "Accept-Language")
+		ctx := context.WithValue(c.Request.Context(), localeKey{}, locale)
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// GetLocale returns the locale chosen by LocaleMiddleware, or "" when none
+// was chosen.
+func GetLocale(ctx context.Context) string {
+	if locale, ok := ctx.Value(localeKey{}).(string); ok {
+		return locale
+	}
+	return ""
+}
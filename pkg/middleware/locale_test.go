@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocaleMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(LocaleMiddleware("en", "th"))
+	var locale string
+	router.GET("/", func(c *gin.Context) {
+		locale = GetLocale(c.Request.Context())
+		c.Status(http.StatusOK)
+	})
+
+	tests := []struct {
+		acceptLanguage string
+		expected       string
+	}{
+		{acceptLanguage: "", expected: "en"},
+		{acceptLanguage: "th", expected: "th"},
+		{acceptLanguage: "th-TH,th;q=0.9", expected: "th"},
+		{acceptLanguage: "en-US,en;q=0.9,th;q=0.8", expected: "en"},
+		{acceptLanguage: "fr, th;q=0.5", expected: "th"},
+		{acceptLanguage: "fr", expected: "en"},
+		{acceptLanguage: "*", expected: "en"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.acceptLanguage, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Accept-Language", tt.acceptLanguage)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expected, locale)
+			assert.Equal(t, tt.expected, w.Header().Get("Content-Language"))
+			assert.Equal(t, "Accept-Language", w.Header().Get("Vary"))
+		})
+	}
+}
+
+func TestGetLocale_Unset(t *testing.T) {
+	assert.Equal(t, "", GetLocale(httptest.NewRequest(http.MethodGet, "/", nil).Context()))
+}
@@ -0,0 +1,108 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RateLimitOptions struct {
+	Enabled           bool
+	RequestsPerSecond float64
+	Burst             int
+}
+
+// RateLimiter is a token bucket per client IP whose options can be changed
+// while serving.
+type RateLimiter struct {
+	mu        sync.Mutex
+	opts      RateLimitOptions
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+func NewRateLimiter(opts RateLimitOptions) *RateLimiter {
+	return &RateLimiter{
+		opts:    opts,
+		buckets: map[string]*bucket{},
+		now:     time.Now,
+	}
+}
+
+func (l *RateLimiter) SetOptions(opts RateLimitOptions) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.opts = opts
+}
+
+// Allow takes a token from key's bucket. When none is left it returns false
+// and how long until the next one is available.
+func (l *RateLimiter) Allow(key string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.opts.Enabled {
+		return true, 0
+	}
+
+	now := l.now()
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(l.opts.Burst), last: now}
+		l.buckets[key] = b
+	}
+	b.tokens = math.Min(float64(l.opts.Burst), b.tokens+now.Sub(b.last).Seconds()*l.opts.RequestsPerSecond)
+	b.last = now
+
+	if b.tokens < 1 {
+		return false, time.Duration((1 - b.tokens) / l.opts.RequestsPerSecond * float64(time.Second))
+	}
+	b.tokens--
+	return true, 0
+}
+
+// sweep drops buckets that have refilled completely, since a new bucket
+// starts full anyway.
+func (l *RateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < time.Minute {
+		return
+	}
+	l.lastSweep = now
+
+	refill := time.Duration(float64(l.opts.Burst) / l.opts.RequestsPerSecond * float64(time.Second))
+	for key, b := range l.buckets {
+		if now.Sub(b.last) >= refill {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// RateLimitMiddleware limits requests per client IP, setting Retry-After
+// and calling reject to write the response when the limit is exceeded.
+func RateLimitMiddleware(limiter *RateLimiter, reject gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		allowed, retryAfter := limiter.Allow(c.ClientIP())
+		if allowed {
+			c.Next()
+			return
+		}
+
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+		reject(c)
+		if !c.IsAborted() {
+			c.AbortWithStatus(http.StatusTooManyRequests)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecurityHeadersMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(SecurityHeadersMiddleware(SecurityHeadersOptions{
+		HSTSMaxAge:            365 * 24 * time.Hour,
+		HSTSIncludeSubdomains: true,
+		ContentSecurityPolicy: "default-src 'none'",
+		RouteContentSecurityPolicy: map[string]string{
+			"/docs/*any": "default-src 'self'",
+			"/raw":       "",
+		},
+		ReferrerPolicy:     "no-referrer",
+		FrameOptions:       "DENY",
+		ContentTypeNosniff: true,
+		TrustedProxies:     []string{"10.0.0.0/8", "::1"},
+	}))
+	handler := func(c *gin.Context) { c.Status(http.StatusOK) }
+	router.GET("/", handler)
+	router.GET("/docs/*any", handler)
+	router.GET("/raw", handler)
+	router.GET("/embed", func(c *gin.Context) {
+		c.Header("X-Frame-Options", "SAMEORIGIN")
+		c.Status(http.StatusOK)
+	})
+
+	request := func(path string, prepare func(req *http.Request)) http.Header {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if prepare != nil {
+			prepare(req)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Header()
+	}
+
+	header := request("/", nil)
+	assert.Equal(t, "default-src 'none'", header.Get("Content-Security-Policy"))
+	assert.Equal(t, "no-referrer", header.Get("Referrer-Policy"))
+	assert.Equal(t, "DENY", header.Get("X-Frame-Options"))
+	assert.Equal(t, "nosniff", header.Get("X-Content-Type-Options"))
+	assert.Empty(t, header.Get("Strict-Transport-Security"))
+
+	header = request("/", func(req *http.Request) { req.TLS = &tls.ConnectionState{} })
+	assert.Equal(t, "max-age=31536000; includeSubDomains", header.Get("Strict-Transport-Security"))
+
+	forwarded := func(remoteAddr string) func(req *http.Request) {
+		return func(req *http.Request) {
+			req.RemoteAddr = remoteAddr
+			req.Header.Set("X-Forwarded-Proto", "https")
+		}
+	}
+	header = request("/", forwarded("10.1.2.3:4321"))
+	assert.Equal(t, "max-age=31536000; includeSubDomains", header.Get("Strict-Transport-Security"))
+	header = request("/", forwarded("[::1]:4321"))
+	assert.Equal(t, "max-age=31536000; includeSubDomains", header.Get("Strict-Transport-Security"))
+	header = request("/", forwarded("203.0.113.7:4321"))
+	assert.Empty(t, header.Get("Strict-Transport-Security"))
+
+	assert.Equal(t, "default-src 'self'", request("/docs/index.html", nil).Get("Content-Security-Policy"))
+	assert.NotContains(t, request("/raw", nil), "Content-Security-Policy")
+	assert.Equal(t, "SAMEORIGIN", request("/embed", nil).Get("X-Frame-Options"))
+}
+
+func TestSecurityHeadersMiddleware_Disabled(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(SecurityHeadersMiddleware(SecurityHeadersOptions{}))
+	router.GET("/", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.TLS = &tls.ConnectionState{}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	for _, name := range []string{"Strict-Transport-Security", "Content-Security-Policy", "Referrer-Policy", "X-Frame-Options", "X-Content-Type-Options"} {
+		assert.Empty(t, w.Header().Get(name), name)
+	}
+}
@@ -0,0 +1,103 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+type Dialect interface {
+	CreateTableSQL(table string) string
+	HasTable(ctx context.Context, conn *sql.Conn, table string) (bool, error)
+	Placeholder(index int) string
+	Lock(ctx context.Context, conn *sql.Conn) error
+	Unlock(ctx context.Context, conn *sql.Conn) error
+}
+
+// Postgres serialises concurrent runners with a session-level advisory lock
+// held on the migration connection.
+type Postgres struct {
+	LockID int64
+}
+
+const defaultLockID int64 = 727_190_425
+
+func (d Postgres) CreateTableSQL(table string) string {
+	return fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	version BIGINT PRIMARY KEY,
+	name TEXT NOT NULL,
+	checksum TEXT NOT NULL,
+	applied_at TIMESTAMPTZ NOT NULL
+)`, table)
+}
+
+func (d Postgres) HasTable(ctx context.Context, conn *sql.Conn, table string) (bool, error) {
+	var exists bool
+	err := conn.QueryRowContext(ctx, "SELECT to_regclass($1) IS NOT NULL", table).Scan(&exists)
+	return exists, err
+}
+
+func (d Postgres) Placeholder(index int) string {
+	return fmt.Sprintf("$%d", index)
+}
+
+// Lock waits for the advisory lock without the statement_timeout the DSN may
+// set, so a runner waits for another one however long its migrations take.
+// The timeout is restored afterwards for the migrations themselves.
+func (d Postgres) Lock(ctx context.Context, conn *sql.Conn) error {
+	if _, err := conn.ExecContext(ctx, "SET statement_timeout = 0"); err != nil {
+		return err
+	}
+	_, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", d.lockID())
+	if _, resetErr := conn.ExecContext(context.Background(), "RESET statement_timeout"); err == nil {
+		err = resetErr
+	}
+	return err
+}
+
+func (d Postgres) Unlock(ctx context.Context, conn *sql.Conn) error {
+	_, err := conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", d.lockID())
+	return err
+}
+
+func (d Postgres) lockID() int64 {
+	if d.LockID == 0 {
+		return defaultLockID
+	}
+	return d.LockID
+}
+
+// SQLite takes no lock, so concurrent runners against the same file are not
+// supported. SQLite only admits one writer at a time and each migration runs
+// in its own transaction, so a second runner fails on the migration the first
+// already applied instead of applying it twice, but it still fails. Run the
+// migrations from a single process, e.g. the migrate CLI before the servers
+// start.
+type SQLite struct{}
+
+func (d SQLite) CreateTableSQL(table string) string {
+	return fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	version INTEGER PRIMARY KEY,
+	name TEXT NOT NULL,
+	checksum TEXT NOT NULL,
+	applied_at DATETIME NOT NULL
+)`, table)
+}
+
+func (d SQLite) HasTable(ctx context.Context, conn *sql.Conn, table string) (bool, error) {
+	var count int
+	err := conn.QueryRowContext(ctx, "SELECT count(*) FROM sqlite_master WHERE type = 'table' AND name = ?", table).Scan(&count)
+	return count > 0, err
+}
+
+func (d SQLite) Placeholder(index int) string {
+	return "?"
+}
+
+func (d SQLite) Lock(ctx context.Context, conn *sql.Conn) error {
+	return nil
+}
+
+func (d SQLite) Unlock(ctx context.Context, conn *sql.Conn) error {
+	return nil
+}
@@ -0,0 +1,238 @@
+package migrate
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"regexp"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var testFS = fstest.MapFS{
+	"migrations/0001_create_authors.up.sql":   {Data: []byte("CREATE TABLE authors (id UUID)")},
+	"migrations/0001_create_authors.down.sql": {Data: []byte("DROP TABLE authors")},
+	"migrations/0002_create_books.up.sql":     {Data: []byte("CREATE TABLE books (id UUID)")},
+	"migrations/0002_create_books.down.sql":   {Data: []byte("DROP TABLE books")},
+	"migrations/README.md":                    {Data: []byte("ignored")},
+}
+
+func newTestMigrator(t *testing.T) (*Migrator, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+
+	migrator, err := New(db, testFS, "migrations", Options{})
+	assert.NoError(t, err)
+	return migrator, mock
+}
+
+func expectLockAndTable(mock sqlmock.Sqlmock) {
+	mock.ExpectExec("SET statement_timeout = 0").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_lock($1)")).WithArgs(defaultLockID).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("RESET statement_timeout").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func expectUnlock(mock sqlmock.Sqlmock) {
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_unlock($1)")).WithArgs(defaultLockID).WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func appliedRows(migrator *Migrator, versions ...int64) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"version", "checksum", "applied_at"})
+	for _, version := range versions {
+		rows.AddRow(version, migrator.find(version).Checksum, time.Now())
+	}
+	return rows
+}
+
+func TestLoad(t *testing.T) {
+	migrations, err := Load(testFS, "migrations")
+
+	assert.NoError(t, err)
+	assert.Len(t, migrations, 2)
+	assert.Equal(t, int64(1), migrations[0].Version)
+	assert.Equal(t, "create_authors", migrations[0].Name)
+	assert.Equal(t, "DROP TABLE authors", migrations[0].Down)
+	assert.Len(t, migrations[0].Checksum, 64)
+	assert.NotEqual(t, migrations[0].Checksum, migrations[1].Checksum)
+}
+
+func TestLoad_MissingUp(t *testing.T) {
+	_, err := Load(fstest.MapFS{
+		"migrations/0001_create_authors.down.sql": {Data: []byte("DROP TABLE authors")},
+	}, "migrations")
+
+	assert.EqualError(t, err, "migration 1_create_authors has no up script")
+}
+
+func TestUp(t *testing.T) {
+	migrator, mock := newTestMigrator(t)
+
+	expectLockAndTable(mock)
+	mock.ExpectQuery("SELECT version, checksum, applied_at FROM schema_migrations").WillReturnRows(appliedRows(migrator, 1))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE books (id UUID)")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO schema_migrations (version, name, checksum, applied_at) VALUES ($1, $2, $3, $4)")).
+		WithArgs(int64(2), "create_books", migrator.find(2).Checksum, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	expectUnlock(mock)
+
+	applied, err := migrator.Up(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, applied, 1)
+	assert.Equal(t, int64(2), applied[0].Version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUp_FailedMigrationRollsBack(t *testing.T) {
+	migrator, mock := newTestMigrator(t)
+
+	expectLockAndTable(mock)
+	mock.ExpectQuery("SELECT version, checksum, applied_at FROM schema_migrations").WillReturnRows(appliedRows(migrator))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE authors (id UUID)")).WillReturnError(errors.New("syntax error"))
+	mock.ExpectRollback()
+	expectUnlock(mock)
+
+	applied, err := migrator.Up(context.Background())
+
+	assert.EqualError(t, err, "failed to run up migration 1_create_authors: syntax error")
+	assert.Empty(t, applied)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUp_ChecksumMismatch(t *testing.T) {
+	migrator, mock := newTestMigrator(t)
+
+	expectLockAndTable(mock)
+	mock.ExpectQuery("SELECT version, checksum, applied_at FROM schema_migrations").
+		WillReturnRows(sqlmock.NewRows([]string{"version", "checksum", "applied_at"}).AddRow(int64(1), "edited", time.Now()))
+	expectUnlock(mock)
+
+	_, err := migrator.Up(context.Background())
+
+	assert.ErrorIs(t, err, ErrChecksumMismatch)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUp_LockFailure(t *testing.T) {
+	migrator, mock := newTestMigrator(t)
+
+	mock.ExpectExec("SET statement_timeout = 0").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_lock($1)")).WillReturnError(errors.New("canceled"))
+	mock.ExpectExec("RESET statement_timeout").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	_, err := migrator.Up(context.Background())
+
+	assert.EqualError(t, err, "failed to acquire migration lock: canceled")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDown(t *testing.T) {
+	migrator, mock := newTestMigrator(t)
+
+	expectLockAndTable(mock)
+	mock.ExpectQuery("SELECT version, checksum, applied_at FROM schema_migrations").WillReturnRows(appliedRows(migrator, 1, 2))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DROP TABLE books")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM schema_migrations WHERE version = $1")).WithArgs(int64(2)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	expectUnlock(mock)
+
+	reverted, err := migrator.Down(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Len(t, reverted, 1)
+	assert.Equal(t, int64(2), reverted[0].Version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGoto(t *testing.T) {
+	t.Run("unknown version", func(t *testing.T) {
+		migrator, mock := newTestMigrator(t)
+
+		_, err := migrator.Goto(context.Background(), 42)
+
+		assert.ErrorIs(t, err, ErrUnknownVersion)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("zero reverts everything", func(t *testing.T) {
+		migrator, mock := newTestMigrator(t)
+
+		expectLockAndTable(mock)
+		mock.ExpectQuery("SELECT version, checksum, applied_at FROM schema_migrations").WillReturnRows(appliedRows(migrator, 1, 2))
+		for _, version := range []int64{2, 1} {
+			mock.ExpectBegin()
+			mock.ExpectExec("DROP TABLE").WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec("DELETE FROM schema_migrations").WithArgs(version).WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+		}
+		expectUnlock(mock)
+
+		changed, err := migrator.Goto(context.Background(), 0)
+
+		assert.NoError(t, err)
+		assert.Len(t, changed, 2)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestStatus(t *testing.T) {
+	tests := []struct {
+		name            string
+		tableExists     bool
+		rows            func(m *Migrator) *sqlmock.Rows
+		expectedApplied []bool
+		expectedPending []string
+	}{
+		{
+			name:            "no schema_migrations table",
+			tableExists:     false,
+			expectedApplied: []bool{false, false},
+			expectedPending: []string{"1_create_authors", "2_create_books"},
+		},
+		{
+			name:            "partially applied",
+			tableExists:     true,
+			rows:            func(m *Migrator) *sqlmock.Rows { return appliedRows(m, 1) },
+			expectedApplied: []bool{true, false},
+			expectedPending: []string{"2_create_books"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migrator, mock := newTestMigrator(t)
+
+			expect := func() {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT to_regclass($1) IS NOT NULL")).
+					WithArgs(DefaultTable).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(driver.Value(tt.tableExists)))
+				if tt.tableExists {
+					mock.ExpectQuery("SELECT version, checksum, applied_at FROM schema_migrations").WillReturnRows(tt.rows(migrator))
+				}
+			}
+
+			expect()
+			statuses, err := migrator.Status(context.Background())
+			assert.NoError(t, err)
+			for i, applied := range tt.expectedApplied {
+				assert.Equal(t, applied, statuses[i].Applied)
+				assert.False(t, statuses[i].Modified)
+			}
+
+			expect()
+			pending, err := migrator.Pending(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedPending, pending)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// Snapshotter is implemented by in-memory repositories that take part in
+// MemoryTransactionManager transactions. Snapshot captures the current state
+// and returns a function that restores it.
+type Snapshotter interface {
+	Snapshot() (restore func())
+}
+
+// MemoryTransactionManager gives in-memory repositories the same rollback
+// semantics as TransactionManager. Transactions are serialised and roll back
+// by restoring a snapshot of every registered repository, so writes made
+// outside a transaction while one is running are not isolated from it.
+type MemoryTransactionManager struct {
+	mu           sync.Mutex
+	participants []Snapshotter
+}
+
+func NewMemoryTransactionManager() *MemoryTransactionManager {
+	return &MemoryTransactionManager{}
+}
+
+func (tm *MemoryTransactionManager) Register(participant Snapshotter) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.participants = append(tm.participants, participant)
+}
+
+// WithinTransaction serialises outermost transactions; nested calls take a
+// further snapshot so they roll back like savepoints. Isolation options
+// have no effect.
+func (tm *MemoryTransactionManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error, opts ...TxOption) error {
+	if parent := txStateFrom(ctx); parent != nil {
+		return tm.run(ctx, &txState{root: parent.root}, fn)
+	}
+
+	state := &txState{}
+	state.root = state
+	if err := tm.runExclusive(ctx, state, fn); err != nil {
+		return err
+	}
+
+	state.runAfterCommit(ctx)
+	return nil
+}
+
+func (tm *MemoryTransactionManager) runExclusive(ctx context.Context, state *txState, fn func(ctx context.Context) error) error {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	return tm.run(ctx, state, fn)
+}
+
+func (tm *MemoryTransactionManager) run(ctx context.Context, state *txState, fn func(ctx context.Context) error) error {
+	restores := make([]func(), 0, len(tm.participants))
+	for _, participant := range tm.participants {
+		restores = append(restores, participant.Snapshot())
+	}
+
+	if err := fn(withTxState(ctx, state)); err != nil {
+		for _, restore := range restores {
+			restore()
+		}
+		return err
+	}
+	return nil
+}
+
+// errNoDatabase is raised when code that expects a database runs on a
+// MemoryTransactionManager, instead of handing it a nil *gorm.DB.
+var errNoDatabase = errors.New("repository: MemoryTransactionManager has no database to query")
+
+// GetDB panics: there is no database behind a MemoryTransactionManager.
+func (tm *MemoryTransactionManager) GetDB() *gorm.DB {
+	panic(errNoDatabase)
+}
+
+// GetDBWithContext panics like GetDB, inside a transaction or not.
+func (tm *MemoryTransactionManager) GetDBWithContext(ctx context.Context) *gorm.DB {
+	panic(errNoDatabase)
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type counter struct {
+	value int
+}
+
+func (c *counter) Snapshot() func() {
+	value := c.value
+	return func() { c.value = value }
+}
+
+func TestMemoryTransactionManager_WithinTransaction(t *testing.T) {
+	tests := []struct {
+		name     string
+		fnErr    error
+		expected int
+	}{
+		{
+			name:     "commit keeps changes",
+			fnErr:    nil,
+			expected: 2,
+		},
+		{
+			name:     "error rolls back changes",
+			fnErr:    errors.New("boom"),
+			expected: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tm := NewMemoryTransactionManager()
+			c := &counter{value: 1}
+			tm.Register(c)
+
+			err := tm.WithinTransaction(context.Background(), func(ctx context.Context) error {
+				assert.True(t, InTransaction(ctx))
+				c.value++
+				return tt.fnErr
+			})
+
+			assert.Equal(t, tt.fnErr, err)
+			assert.Equal(t, tt.expected, c.value)
+		})
+	}
+}
+
+func TestMemoryTransactionManager_GetDB(t *testing.T) {
+	tm := NewMemoryTransactionManager()
+
+	assert.PanicsWithError(t, errNoDatabase.Error(), func() { tm.GetDB() })
+	assert.PanicsWithError(t, errNoDatabase.Error(), func() { tm.GetDBWithContext(context.Background()) })
+
+	err := tm.WithinTransaction(context.Background(), func(ctx context.Context) error {
+		assert.PanicsWithError(t, errNoDatabase.Error(), func() { tm.GetDBWithContext(ctx) })
+		return nil
+	})
+	assert.NoError(t, err)
+
+	var _ ITransactionManager = tm
+}
+
+func TestMemoryTransactionManager_WithinTransaction_Nested(t *testing.T) {
+	tm := NewMemoryTransactionManager()
+	c := &counter{value: 1}
+	tm.Register(c)
+
+	var committed bool
+	err := tm.WithinTransaction(context.Background(), func(ctx context.Context) error {
+		c.value++
+		AfterCommit(ctx, func(ctx context.Context) { committed = true })
+
+		err := tm.WithinTransaction(ctx, func(ctx context.Context) error {
+			c.value++
+			return errors.New("inner failed")
+		})
+		assert.Error(t, err)
+		assert.Equal(t, 2, c.value)
+		assert.False(t, committed)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, c.value)
+	assert.True(t, committed)
+}
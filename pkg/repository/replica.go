@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	DefaultStickyWindow        = 5 * time.Second
+	DefaultHealthCheckInterval = 10 * time.Second
+	DefaultHealthCheckTimeout  = 2 * time.Second
+)
+
+type Replica struct {
+	Name string
+	DB   *sql.DB
+}
+
+type ReplicaOptions struct {
+	// StickyWindow keeps reads on the primary for this long after a write
+	// made with a context carrying a session, so callers read their own
+	// writes despite replication lag.
+	StickyWindow        time.Duration
+	HealthCheckInterval time.Duration
+	HealthCheckTimeout  time.Duration
+}
+
+type replica struct {
+	Replica
+	healthy atomic.Bool
+}
+
+// ReplicaResolver is a GORM plugin that sends queries run outside a
+// transaction to a healthy replica, round robin, and leaves writes, raw SQL
+// and transactions on the primary. Once installed, every *gorm.DB returned by
+// TransactionManager.GetDB is routed this way, so repositories need no
+// changes. Replicas failing a health check are ejected until they recover;
+// with none healthy, reads fall back to the primary.
+type ReplicaResolver struct {
+	replicas []*replica
+	opts     ReplicaOptions
+	logger   *logrus.Logger
+	next     atomic.Uint64
+}
+
+func NewReplicaResolver(replicas []Replica, opts ReplicaOptions, logger *logrus.Logger) *ReplicaResolver {
+	if opts.StickyWindow <= 0 {
+		opts.StickyWindow = DefaultStickyWindow
+	}
+	if opts.HealthCheckInterval <= 0 {
+		opts.HealthCheckInterval = DefaultHealthCheckInterval
+	}
+	if opts.HealthCheckTimeout <= 0 {
+		opts.HealthCheckTimeout = DefaultHealthCheckTimeout
+	}
+
+	r := &ReplicaResolver{opts: opts, logger: logger}
+	for _, rep := range replicas {
+		item := &replica{Replica: rep}
+		item.healthy.Store(true)
+		r.replicas = append(r.replicas, item)
+	}
+	return r
+}
+
+func (r *ReplicaResolver) Name() string {
+	return "replica_resolver"
+}
+
+func (r *ReplicaResolver) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+	return errors.Join(
+		callbacks.Query().Before("gorm:query").Register("replica:route", r.route),
+		callbacks.Create().After("gorm:create").Register("replica:mark_write", r.markWrite),
+		callbacks.Update().After("gorm:update").Register("replica:mark_write", r.markWrite),
+		callbacks.Delete().After("gorm:delete").Register("replica:mark_write", r.markWrite),
+		callbacks.Raw().After("gorm:raw").Register("replica:mark_write", r.markWrite),
+	)
+}
+
+// Run checks replica health immediately and then every
+// HealthCheckInterval until ctx is done.
+func (r *ReplicaResolver) Run(ctx context.Context) {
+	r.CheckHealth(ctx)
+
+	ticker := time.NewTicker(r.opts.HealthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.CheckHealth(ctx)
+		}
+	}
+}
+
+// CheckHealth pings every replica, ejecting those that fail and restoring
+// those that recover.
+func (r *ReplicaResolver) CheckHealth(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, rep := range r.replicas {
+		wg.Add(1)
+		go func(rep *replica) {
+			defer wg.Done()
+
+			pingCtx, cancel := context.WithTimeout(ctx, r.opts.HealthCheckTimeout)
+			defer cancel()
+
+			err := rep.DB.PingContext(pingCtx)
+			if healthy := err == nil; rep.healthy.Swap(healthy) != healthy {
+				if healthy {
+					r.logger.Infof("[ReplicaResolver] Replica %s is healthy again", rep.Name)
+				} else {
+					r.logger.Warnf("[ReplicaResolver] Ejecting replica %s: %v", rep.Name, err)
+				}
+			}
+		}(rep)
+	}
+	wg.Wait()
+}
+
+// Healthy returns the names of the replicas currently receiving reads.
+func (r *ReplicaResolver) Healthy() []string {
+	names := []string{}
+	for _, rep := range r.replicas {
+		if rep.healthy.Load() {
+			names = append(names, rep.Name)
+		}
+	}
+	return names
+}
+
+func (r *ReplicaResolver) Close() error {
+	var errs []error
+	for _, rep := range r.replicas {
+		errs = append(errs, rep.DB.Close())
+	}
+	return errors.Join(errs...)
+}
+
+func (r *ReplicaResolver) route(db *gorm.DB) {
+	if db.Error != nil {
+		return
+	}
+	if _, ok := db.Statement.ConnPool.(gorm.TxCommitter); ok {
+		return
+	}
+	if s := sessionFrom(db.Statement.Context); s != nil && s.wroteWithin(r.opts.StickyWindow) {
+		return
+	}
+	if rep := r.pick(); rep != nil {
+		db.Statement.ConnPool = rep.DB
+	}
+}
+
+func (r *ReplicaResolver) markWrite(db *gorm.DB) {
+	if s := sessionFrom(db.Statement.Context); s != nil {
+		s.lastWrite.Store(time.Now().UnixNano())
+	}
+}
+
+func (r *ReplicaResolver) pick() *replica {
+	count := uint64(len(r.replicas))
+	start := r.next.Add(1)
+	for i := uint64(0); i < count; i++ {
+		if rep := r.replicas[(start+i)%count]; rep.healthy.Load() {
+			return rep
+		}
+	}
+	return nil
+}
+
+type sessionKey struct{}
+
+type session struct {
+	lastWrite atomic.Int64
+}
+
+func (s *session) wroteWithin(window time.Duration) bool {
+	lastWrite := s.lastWrite.Load()
+	return lastWrite != 0 && time.Since(time.Unix(0, lastWrite)) < window
+}
+
+// WithSession returns a context that tracks writes for sticky-primary
+// reads. It is typically attached once per request.
+func WithSession(ctx context.Context) context.Context {
+	if sessionFrom(ctx) != nil {
+		return ctx
+	}
+	return context.WithValue(ctx, sessionKey{}, &session{})
+}
+
+func sessionFrom(ctx context.Context) *session {
+	if ctx == nil {
+		return nil
+	}
+	s, _ := ctx.Value(sessionKey{}).(*session)
+	return s
+}
@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type record struct {
+	ID   int
+	Name string
+}
+
+type ReplicaResolverTestSuite struct {
+	suite.Suite
+	db          *gorm.DB
+	primaryMock sqlmock.Sqlmock
+	replicaMock []sqlmock.Sqlmock
+	resolver    *ReplicaResolver
+}
+
+func (suite *ReplicaResolverTestSuite) SetupTest() {
+	suite.db, suite.primaryMock = setupDB(suite.T())
+
+	var replicas []Replica
+	suite.replicaMock = nil
+	for _, name := range []string{"replica-1", "replica-2"} {
+		db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+		suite.Require().NoError(err)
+		replicas = append(replicas, Replica{Name: name, DB: db})
+		suite.replicaMock = append(suite.replicaMock, mock)
+	}
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	suite.resolver = NewReplicaResolver(replicas, ReplicaOptions{}, logger)
+	suite.Require().NoError(suite.db.Use(suite.resolver))
+}
+
+func (suite *ReplicaResolverTestSuite) TearDownTest() {
+	suite.NoError(suite.primaryMock.ExpectationsWereMet())
+	for _, mock := range suite.replicaMock {
+		suite.NoError(mock.ExpectationsWereMet())
+	}
+}
+
+func (suite *ReplicaResolverTestSuite) expectSelect(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery(`SELECT \* FROM "records"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "test"))
+}
+
+func (suite *ReplicaResolverTestSuite) find(ctx context.Context) {
+	var records []record
+	suite.Require().NoError(suite.db.WithContext(ctx).Find(&records).Error)
+	suite.Len(records, 1)
+}
+
+func (suite *ReplicaResolverTestSuite) TestReadsRoundRobinAcrossReplicas() {
+	suite.expectSelect(suite.replicaMock[1])
+	suite.expectSelect(suite.replicaMock[0])
+	suite.expectSelect(suite.replicaMock[1])
+
+	for i := 0; i < 3; i++ {
+		suite.find(context.Background())
+	}
+}
+
+func (suite *ReplicaResolverTestSuite) TestWritesUsePrimary() {
+	suite.primaryMock.ExpectBegin()
+	suite.primaryMock.ExpectQuery(`INSERT INTO "records"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	suite.primaryMock.ExpectCommit()
+
+	suite.NoError(suite.db.Create(&record{Name: "test"}).Error)
+}
+
+func (suite *ReplicaResolverTestSuite) TestTransactionReadsUsePrimary() {
+	suite.primaryMock.ExpectBegin()
+	suite.expectSelect(suite.primaryMock)
+	suite.primaryMock.ExpectCommit()
+
+	tm := NewTransactionManager(suite.db)
+	err := tm.WithinTransaction(context.Background(), func(ctx context.Context) error {
+		var records []record
+		return tm.GetDBWithContext(ctx).Find(&records).Error
+	})
+	suite.NoError(err)
+}
+
+func (suite *ReplicaResolverTestSuite) TestSessionReadsStickToPrimaryAfterWrite() {
+	ctx := WithSession(context.Background())
+
+	suite.expectSelect(suite.replicaMock[1])
+	suite.find(ctx)
+
+	suite.primaryMock.ExpectBegin()
+	suite.primaryMock.ExpectQuery(`INSERT INTO "records"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	suite.primaryMock.ExpectCommit()
+	suite.Require().NoError(suite.db.WithContext(ctx).Create(&record{Name: "test"}).Error)
+
+	suite.expectSelect(suite.primaryMock)
+	suite.find(ctx)
+
+	// Other requests are unaffected.
+	suite.expectSelect(suite.replicaMock[0])
+	suite.find(context.Background())
+}
+
+func (suite *ReplicaResolverTestSuite) TestEjectsUnhealthyReplicas() {
+	suite.replicaMock[0].ExpectPing().WillReturnError(errors.New("connection refused"))
+	suite.replicaMock[1].ExpectPing()
+	suite.resolver.CheckHealth(context.Background())
+	suite.Equal([]string{"replica-2"}, suite.resolver.Healthy())
+
+	suite.expectSelect(suite.replicaMock[1])
+	suite.expectSelect(suite.replicaMock[1])
+	suite.find(context.Background())
+	suite.find(context.Background())
+
+	suite.replicaMock[0].ExpectPing()
+	suite.replicaMock[1].ExpectPing().WillReturnError(errors.New("connection refused"))
+	suite.resolver.CheckHealth(context.Background())
+	suite.Equal([]string{"replica-1"}, suite.resolver.Healthy())
+
+	suite.expectSelect(suite.replicaMock[0])
+	suite.find(context.Background())
+}
+
+func (suite *ReplicaResolverTestSuite) TestFallsBackToPrimaryWithoutHealthyReplicas() {
+	for _, mock := range suite.replicaMock {
+		mock.ExpectPing().WillReturnError(errors.New("connection refused"))
+	}
+	suite.resolver.CheckHealth(context.Background())
+	suite.Empty(suite.resolver.Healthy())
+
+	suite.expectSelect(suite.primaryMock)
+	suite.find(context.Background())
+}
+
+func TestReplicaResolverTestSuite(t *testing.T) {
+	suite.Run(t, new(ReplicaResolverTestSuite))
+}
+
+func TestWithSession(t *testing.T) {
+	ctx := WithSession(context.Background())
+
+	assert.NotNil(t, sessionFrom(ctx))
+	assert.Equal(t, ctx, WithSession(ctx))
+	assert.Nil(t, sessionFrom(context.Background()))
+}
@@ -1,12 +1,38 @@
 package repository
 
 import (
+	"context"
+	"database/sql"
+	"sync"
+
 	"gorm.io/gorm"
 )
 
 type ITransactionManager interface {
-	Transaction(fn func(tx *gorm.DB) error) error
-	GetDB(tx ...*gorm.DB) *gorm.DB
+	// WithinTransaction runs fn in a transaction carried by ctx. Repositories
+	// resolving their handle through GetDBWithContext join it automatically.
+	// A call nested inside another runs as a savepoint of the outer
+	// transaction; options only apply to the outermost call.
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error, opts ...TxOption) error
+	GetDB() *gorm.DB
+	// GetDBWithContext returns the transaction carried by ctx, otherwise the
+	// default handle, bound to ctx. The context is the only way a transaction
+	// reaches a repository.
+	GetDBWithContext(ctx context.Context) *gorm.DB
+}
+
+type TxOption func(*sql.TxOptions)
+
+func WithIsolation(level sql.IsolationLevel) TxOption {
+	return func(opts *sql.TxOptions) {
+		opts.Isolation = level
+	}
+}
+
+func ReadOnly() TxOption {
+	return func(opts *sql.TxOptions) {
+		opts.ReadOnly = true
+	}
 }
 
 type TransactionManager struct {
@@ -19,22 +45,102 @@ func NewTransactionManager(db *gorm.DB) ITransactionManager {
 	}
 }
 
-func (tm *TransactionManager) Transaction(fn func(tx *gorm.DB) error) error {
-	tx := tm.db.Begin()
-	if tx.Error != nil {
-		return tx.Error
+func (tm *TransactionManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error, opts ...TxOption) error {
+	if state := txStateFrom(ctx); state != nil {
+		// GORM turns a transaction started on a transaction into a savepoint.
+		return state.tx.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			return fn(withTxState(ctx, &txState{tx: tx, root: state.root}))
+		})
 	}
-	err := fn(tx)
+
+	state := &txState{}
+	state.root = state
+	err := tm.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		state.tx = tx
+		return fn(withTxState(ctx, state))
+	}, buildTxOptions(opts)...)
 	if err != nil {
-		tx.Rollback()
 		return err
 	}
-	return tx.Commit().Error
+
+	state.runAfterCommit(ctx)
+	return nil
 }
 
-func (tm *TransactionManager) GetDB(tx ...*gorm.DB) *gorm.DB {
-	if len(tx) > 0 && tx[0] != nil {
-		return tx[0]
-	}
+func (tm *TransactionManager) GetDB() *gorm.DB {
 	return tm.db
 }
+
+func (tm *TransactionManager) GetDBWithContext(ctx context.Context) *gorm.DB {
+	if state := txStateFrom(ctx); state != nil {
+		return state.tx.WithContext(ctx)
+	}
+	return tm.db.WithContext(ctx)
+}
+
+// InTransaction reports whether ctx carries a transaction started by
+// WithinTransaction.
+func InTransaction(ctx context.Context) bool {
+	return txStateFrom(ctx) != nil
+}
+
+// AfterCommit runs fn once the outermost transaction carried by ctx has
+// committed, or immediately when ctx carries none. Callbacks registered in
+// a savepoint that is later rolled back still run if the outer transaction
+// commits.
+func AfterCommit(ctx context.Context, fn func(ctx context.Context)) {
+	state := txStateFrom(ctx)
+	if state == nil {
+		fn(ctx)
+		return
+	}
+
+	root := state.root
+	root.mu.Lock()
+	defer root.mu.Unlock()
+	root.afterCommit = append(root.afterCommit, fn)
+}
+
+type txKey struct{}
+
+type txState struct {
+	tx   *gorm.DB
+	root *txState
+
+	mu          sync.Mutex
+	afterCommit []func(ctx context.Context)
+}
+
+func (s *txState) runAfterCommit(ctx context.Context) {
+	s.mu.Lock()
+	callbacks := s.afterCommit
+	s.afterCommit = nil
+	s.mu.Unlock()
+
+	for _, fn := range callbacks {
+		fn(ctx)
+	}
+}
+
+func withTxState(ctx context.Context, state *txState) context.Context {
+	return context.WithValue(ctx, txKey{}, state)
+}
+
+func txStateFrom(ctx context.Context) *txState {
+	if ctx == nil {
+		return nil
+	}
+	state, _ := ctx.Value(txKey{}).(*txState)
+	return state
+}
+
+func buildTxOptions(opts []TxOption) []*sql.TxOptions {
+	if len(opts) == 0 {
+		return nil
+	}
+	txOptions := &sql.TxOptions{}
+	for _, opt := range opts {
+		opt(txOptions)
+	}
+	return []*sql.TxOptions{txOptions}
+}
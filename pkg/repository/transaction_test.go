@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"context"
+	"database/sql"
 	"errors"
 	"testing"
 
@@ -33,151 +35,105 @@ func TestNewTransactionManager(t *testing.T) {
 
 func TestTransactionManager_GetDB(t *testing.T) {
 	gormDB, _ := setupDB(t)
-	paramDB, _ := setupDB(t)
-	assert.NotEqual(t, gormDB, paramDB)
 
 	tm := NewTransactionManager(gormDB)
 
+	assert.Equal(t, gormDB, tm.GetDB())
+}
+
+func TestTransactionManager_WithinTransaction(t *testing.T) {
 	tests := []struct {
-		name     string
-		getTx    func() []*gorm.DB
-		expected *gorm.DB
+		name        string
+		fnErr       error
+		expectedErr error
 	}{
 		{
-			name: "no transaction provided",
-			getTx: func() []*gorm.DB {
-				return nil
-			},
-			expected: gormDB,
-		},
-		{
-			name: "empty transaction slice",
-			getTx: func() []*gorm.DB {
-				return []*gorm.DB{}
-			},
-			expected: gormDB,
-		},
-		{
-			name: "nil transaction",
-			getTx: func() []*gorm.DB {
-				return []*gorm.DB{nil}
-			},
-			expected: gormDB,
-		},
-		{
-			name: "valid transaction",
-			getTx: func() []*gorm.DB {
-				return []*gorm.DB{paramDB}
-			},
-			expected: paramDB,
+			name:        "commit",
+			fnErr:       nil,
+			expectedErr: nil,
 		},
 		{
-			name: "nil transaction with valid transaction",
-			getTx: func() []*gorm.DB {
-				return []*gorm.DB{nil, paramDB}
-			},
-			expected: gormDB,
-		},
-		{
-			name: "multiple transactions (should use first)",
-			getTx: func() []*gorm.DB {
-				paramDB2, _ := setupDB(t)
-				return []*gorm.DB{paramDB, paramDB2}
-			},
-			expected: paramDB,
+			name:        "rollback",
+			fnErr:       errors.New("boom"),
+			expectedErr: errors.New("boom"),
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := tm.GetDB(tt.getTx()...)
-			assert.Equal(t, tt.expected, result)
+			gormDB, mock := setupDB(t)
+			tm := NewTransactionManager(gormDB)
+
+			mock.ExpectBegin()
+			mock.ExpectExec("UPDATE records").WillReturnResult(sqlmock.NewResult(0, 1))
+			if tt.fnErr != nil {
+				mock.ExpectRollback()
+			} else {
+				mock.ExpectCommit()
+			}
+
+			var committed bool
+			err := tm.WithinTransaction(context.Background(), func(ctx context.Context) error {
+				assert.True(t, InTransaction(ctx))
+				AfterCommit(ctx, func(ctx context.Context) { committed = true })
+				assert.NoError(t, tm.GetDBWithContext(ctx).Exec("UPDATE records SET name = 'x'").Error)
+				return tt.fnErr
+			})
+
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.fnErr == nil, committed)
+			assert.NoError(t, mock.ExpectationsWereMet())
 		})
 	}
 }
 
-func TestTransactionManager_Transaction(t *testing.T) {
+func TestTransactionManager_WithinTransaction_NestedUsesSavepoint(t *testing.T) {
 	gormDB, mock := setupDB(t)
+	tm := NewTransactionManager(gormDB)
 
-	scenarios := []struct {
-		scenarioName string
-		tests        []struct {
-			name        string
-			tx          func(*gorm.DB) error
-			expectedErr error
-		}
-	}{
-		{
-			scenarioName: "no transaction",
-			tests: []struct {
-				name        string
-				tx          func(*gorm.DB) error
-				expectedErr error
-			}{
-				{
-					name: "success",
-					tx: func(tx *gorm.DB) error {
-						assert.NotNil(t, tx)
-						return nil
-					},
-					expectedErr: nil,
-				},
-				{
-					name: "failed",
-					tx: func(tx *gorm.DB) error {
-						assert.NotNil(t, tx)
-						return errors.New("transaction failed")
-					},
-					expectedErr: errors.New("transaction failed"),
-				},
-			},
-		},
-		{
-			scenarioName: "single transaction",
-			tests: []struct {
-				name        string
-				tx          func(*gorm.DB) error
-				expectedErr error
-			}{
-				{
-					name: "success",
-					tx: func(tx *gorm.DB) error {
-						tx.Exec("CREATE TABLE test (id INTEGER PRIMARY KEY, name TEXT)")
-						return nil
-					},
-					expectedErr: nil,
-				},
-				{
-					name: "failed",
-					tx: func(tx *gorm.DB) error {
-						tx.Exec("CREATE TABLE test (id INTEGER PRIMARY KEY, name TEXT)")
-						return errors.New("transaction failed")
-					},
-					expectedErr: errors.New("transaction failed"),
-				},
-			},
-		},
-	}
+	mock.ExpectBegin()
+	mock.ExpectExec("SAVEPOINT").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("UPDATE records").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("ROLLBACK TO SAVEPOINT").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	innerErr := errors.New("inner failed")
+	err := tm.WithinTransaction(context.Background(), func(ctx context.Context) error {
+		err := tm.WithinTransaction(ctx, func(ctx context.Context) error {
+			assert.NoError(t, tm.GetDBWithContext(ctx).Exec("UPDATE records SET name = 'x'").Error)
+			return innerErr
+		})
+		assert.Equal(t, innerErr, err)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTransactionManager_GetDBWithContext(t *testing.T) {
+	gormDB, _ := setupDB(t)
+	txDB, _ := setupDB(t)
+	tm := NewTransactionManager(gormDB)
 
-	for _, sc := range scenarios {
-		t.Run(sc.scenarioName, func(t *testing.T) {
-			for _, tc := range sc.tests {
-				t.Run(tc.name, func(t *testing.T) {
-					tm := NewTransactionManager(gormDB)
+	assert.Equal(t, gormDB.ConnPool, tm.GetDBWithContext(context.Background()).Statement.ConnPool)
 
-					mock.ExpectBegin()
+	ctx := withTxState(context.Background(), &txState{tx: txDB})
+	assert.Equal(t, txDB.ConnPool, tm.GetDBWithContext(ctx).Statement.ConnPool)
+	assert.False(t, InTransaction(context.Background()))
+}
 
-					if tc.expectedErr != nil {
-						mock.ExpectRollback()
-					} else {
-						mock.ExpectCommit()
-					}
+func TestAfterCommit_WithoutTransaction(t *testing.T) {
+	var called bool
+	AfterCommit(context.Background(), func(ctx context.Context) { called = true })
 
-					err := tm.Transaction(tc.tx)
-					assert.Equal(t, tc.expectedErr, err)
-					assert.NoError(t, mock.ExpectationsWereMet())
-				})
-			}
-		})
-	}
+	assert.True(t, called)
+}
+
+func TestBuildTxOptions(t *testing.T) {
+	assert.Nil(t, buildTxOptions(nil))
+	assert.Equal(t,
+		[]*sql.TxOptions{{Isolation: sql.LevelSerializable, ReadOnly: true}},
+		buildTxOptions([]TxOption{WithIsolation(sql.LevelSerializable), ReadOnly()}),
+	)
 }
@@ -0,0 +1,222 @@
+package tlsconfig
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	ClientAuthNone     = "none"
+	ClientAuthOptional = "optional"
+	ClientAuthRequire  = "require"
+)
+
+var versions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+type Options struct {
+	CertFile   string
+	KeyFile    string
+	MinVersion string
+	// CipherSuites lists cipher suite names as in crypto/tls, e.g.
+	// "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256". They only apply to TLS 1.2
+	// and below; empty uses the Go defaults.
+	CipherSuites []string
+	// ClientAuth is none, optional (verify a certificate when one is sent)
+	// or require. Client certificates are verified against ClientCAFile.
+	ClientAuth   string
+	ClientCAFile string
+}
+
+// Reloader serves the certificate, key and client CA bundle read from disk
+// and swaps them when the files change, so renewed certificates are used
+// for new handshakes without a restart.
+type Reloader struct {
+	opts         Options
+	minVersion   uint16
+	cipherSuites []uint16
+	clientAuth   tls.ClientAuthType
+	logger       *logrus.Logger
+
+	mu          sync.RWMutex
+	certificate *tls.Certificate
+	clientCAs   *x509.CertPool
+}
+
+// New validates opts and loads the files once.
+func New(opts Options, logger *logrus.Logger) (*Reloader, error) {
+	minVersion, ok := versions[opts.MinVersion]
+	if !ok {
+		return nil, fmt.Errorf("unsupported TLS version %q", opts.MinVersion)
+	}
+
+	cipherSuites, err := parseCipherSuites(opts.CipherSuites)
+	if err != nil {
+		return nil, err
+	}
+
+	var clientAuth tls.ClientAuthType
+	switch opts.ClientAuth {
+	case "", ClientAuthNone:
+		clientAuth = tls.NoClientCert
+	case ClientAuthOptional:
+		clientAuth = tls.VerifyClientCertIfGiven
+	case ClientAuthRequire:
+		clientAuth = tls.RequireAndVerifyClientCert
+	default:
+		return nil, fmt.Errorf("unsupported client auth %q", opts.ClientAuth)
+	}
+	if clientAuth != tls.NoClientCert && opts.ClientCAFile == "" {
+		return nil, errors.New("client CA file is required to verify client certificates")
+	}
+
+	r := &Reloader{
+		opts:         opts,
+		minVersion:   minVersion,
+		cipherSuites: cipherSuites,
+		clientAuth:   clientAuth,
+		logger:       logger,
+	}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func parseCipherSuites(names []string) ([]uint16, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	available := map[string]uint16{}
+	for _, suite := range tls.CipherSuites() {
+		available[suite.Name] = suite.ID
+	}
+
+	ids := make([]uint16, 0, len(names))
+	for _, name := range names {
+		id, ok := available[name]
+		if !ok {
+			return nil, fmt.Errorf("unsupported or insecure cipher suite %q", name)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// Reload reads the files again. On error the current certificate is kept.
+func (r *Reloader) Reload() error {
+	certificate, err := tls.LoadX509KeyPair(r.opts.CertFile, r.opts.KeyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load certificate: %w", err)
+	}
+
+	var clientCAs *x509.CertPool
+	if r.clientAuth != tls.NoClientCert {
+		pem, err := os.ReadFile(r.opts.ClientCAFile)
+		if err != nil {
+			return fmt.Errorf("failed to read client CA file: %w", err)
+		}
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no certificates found in client CA file %s", r.opts.ClientCAFile)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.certificate = &certificate
+	r.clientCAs = clientCAs
+	return nil
+}
+
+// TLSConfig returns a server config that resolves the current certificate
+// and client CAs on every handshake.
+func (r *Reloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion:         r.minVersion,
+		GetCertificate:     r.getCertificate,
+		GetConfigForClient: r.configForClient,
+	}
+}
+
+func (r *Reloader) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.certificate, nil
+}
+
+func (r *Reloader) configForClient(*tls.ClientHelloInfo) (*tls.Config, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return &tls.Config{
+		MinVersion:   r.minVersion,
+		CipherSuites: r.cipherSuites,
+		Certificates: []tls.Certificate{*r.certificate},
+		ClientAuth:   r.clientAuth,
+		ClientCAs:    r.clientCAs,
+		NextProtos:   []string{"h2", "http/1.1"},
+	}, nil
+}
+
+// Run polls the certificate, key and client CA files every interval and
+// reloads them when any of them changes, until ctx is done.
+func (r *Reloader) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	last := r.fileState()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			state := r.fileState()
+			if state == last {
+				continue
+			}
+			if err := r.Reload(); err != nil {
+				r.logger.Errorf("[TLS] Failed to reload certificates, keeping the current ones: %v", err)
+				continue
+			}
+			last = state
+			r.logger.Infof("[TLS] Reloaded certificates from %s", strings.Join(r.files(), ", "))
+		}
+	}
+}
+
+func (r *Reloader) files() []string {
+	files := []string{r.opts.CertFile, r.opts.KeyFile}
+	if r.clientAuth != tls.NoClientCert {
+		files = append(files, r.opts.ClientCAFile)
+	}
+	return files
+}
+
+// fileState summarises the modification time and size of every file.
+func (r *Reloader) fileState() string {
+	var state strings.Builder
+	for _, file := range r.files() {
+		if info, err := os.Stat(file); err == nil {
+			fmt.Fprintf(&state, "%s:%d:%d;", file, info.ModTime().UnixNano(), info.Size())
+		}
+	}
+	return state.String()
+}
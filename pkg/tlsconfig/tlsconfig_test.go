@@ -0,0 +1,239 @@
+package tlsconfig
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCert struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+	keyPEM  []byte
+}
+
+func newTestCert(t *testing.T, commonName string, serial int64, parent *testCert) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	signer, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature
+	} else {
+		signer, signerKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return &testCert{
+		cert:    cert,
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+func (c *testCert) tlsCertificate(t *testing.T) tls.Certificate {
+	certificate, err := tls.X509KeyPair(c.certPEM, c.keyPEM)
+	require.NoError(t, err)
+	return certificate
+}
+
+func writeCert(t *testing.T, dir string, cert *testCert) (string, string) {
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	require.NoError(t, os.WriteFile(certFile, cert.certPEM, 0o600))
+	require.NoError(t, os.WriteFile(keyFile, cert.keyPEM, 0o600))
+	return certFile, keyFile
+}
+
+func testLogger() *logrus.Logger {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	return logger
+}
+
+// serve accepts one connection on a TLS listener and completes the handshake.
+func serve(t *testing.T, config *tls.Config) string {
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", config)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			_ = conn.(*tls.Conn).Handshake()
+			_ = conn.Close()
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func dial(addr string, roots *x509.CertPool, clientCert *tls.Certificate) (*x509.Certificate, error) {
+	config := &tls.Config{RootCAs: roots, ServerName: "localhost"}
+	if clientCert != nil {
+		config.Certificates = []tls.Certificate{*clientCert}
+	}
+	conn, err := tls.Dial("tcp", addr, config)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	// TLS 1.3 reports client certificate errors after the handshake.
+	if _, err := conn.Read(make([]byte, 1)); err != nil && err != io.EOF {
+		return nil, err
+	}
+	return conn.ConnectionState().PeerCertificates[0], nil
+}
+
+func TestNew_InvalidOptions(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCert(t, "ca", 1, nil)
+	certFile, keyFile := writeCert(t, dir, newTestCert(t, "server", 2, ca))
+
+	tests := []struct {
+		name        string
+		opts        Options
+		expectedErr string
+	}{
+		{
+			name:        "unsupported version",
+			opts:        Options{CertFile: certFile, KeyFile: keyFile, MinVersion: "1.4"},
+			expectedErr: `unsupported TLS version "1.4"`,
+		},
+		{
+			name:        "unknown cipher suite",
+			opts:        Options{CertFile: certFile, KeyFile: keyFile, MinVersion: "1.2", CipherSuites: []string{"TLS_RSA_WITH_RC4_128_SHA"}},
+			expectedErr: `unsupported or insecure cipher suite "TLS_RSA_WITH_RC4_128_SHA"`,
+		},
+		{
+			name:        "client auth without CA",
+			opts:        Options{CertFile: certFile, KeyFile: keyFile, MinVersion: "1.2", ClientAuth: ClientAuthRequire},
+			expectedErr: "client CA file is required to verify client certificates",
+		},
+		{
+			name:        "missing certificate",
+			opts:        Options{CertFile: filepath.Join(dir, "missing.crt"), KeyFile: keyFile, MinVersion: "1.2"},
+			expectedErr: "failed to load certificate",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.opts, testLogger())
+			assert.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestReloader_MutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCert(t, "ca", 1, nil)
+	otherCA := newTestCert(t, "other-ca", 1, nil)
+	certFile, keyFile := writeCert(t, dir, newTestCert(t, "server", 2, ca))
+	caFile := filepath.Join(dir, "ca.crt")
+	require.NoError(t, os.WriteFile(caFile, ca.certPEM, 0o600))
+
+	reloader, err := New(Options{
+		CertFile:     certFile,
+		KeyFile:      keyFile,
+		MinVersion:   "1.2",
+		ClientAuth:   ClientAuthRequire,
+		ClientCAFile: caFile,
+	}, testLogger())
+	require.NoError(t, err)
+	addr := serve(t, reloader.TLSConfig())
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	client := newTestCert(t, "client", 3, ca).tlsCertificate(t)
+	untrusted := newTestCert(t, "client", 3, otherCA).tlsCertificate(t)
+
+	_, err = dial(addr, roots, &client)
+	assert.NoError(t, err)
+	_, err = dial(addr, roots, nil)
+	assert.Error(t, err)
+	_, err = dial(addr, roots, &untrusted)
+	assert.Error(t, err)
+}
+
+func TestReloader_RunReloadsChangedFiles(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCert(t, "ca", 1, nil)
+	certFile, keyFile := writeCert(t, dir, newTestCert(t, "server", 2, ca))
+
+	reloader, err := New(Options{CertFile: certFile, KeyFile: keyFile, MinVersion: "1.2"}, testLogger())
+	require.NoError(t, err)
+	addr := serve(t, reloader.TLSConfig())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go reloader.Run(ctx, 10*time.Millisecond)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	served, err := dial(addr, roots, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), served.SerialNumber.Int64())
+
+	renewed := newTestCert(t, "server", 4, ca)
+	writeCert(t, dir, renewed)
+	future := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(certFile, future, future))
+
+	assert.Eventually(t, func() bool {
+		served, err := dial(addr, roots, nil)
+		return err == nil && served.SerialNumber.Int64() == 4
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestReloader_ReloadErrorKeepsCurrent(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCert(t, "ca", 1, nil)
+	certFile, keyFile := writeCert(t, dir, newTestCert(t, "server", 2, ca))
+
+	reloader, err := New(Options{CertFile: certFile, KeyFile: keyFile, MinVersion: "1.2"}, testLogger())
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(keyFile, []byte("not a key"), 0o600))
+	assert.Error(t, reloader.Reload())
+
+	certificate, err := reloader.getCertificate(nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, certificate)
+}
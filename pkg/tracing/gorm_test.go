@@ -0,0 +1,60 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type tracingTestModel struct {
+	ID   int
+	Name string
+}
+
+func TestGormPlugin(t *testing.T) {
+	recorder := setupRecorder(t)
+
+	sqlDB, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer sqlDB.Close()
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.Use(NewGormPlugin()))
+
+	mock.ExpectQuery(`SELECT \* FROM "tracing_test_models"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "name"))
+	mock.ExpectQuery(`SELECT \* FROM "tracing_test_models"`).
+		WillReturnError(errors.New("connection reset"))
+
+	ctx, parent := StartSpan(context.Background(), "Parent")
+	var model tracingTestModel
+	assert.NoError(t, db.WithContext(ctx).First(&model).Error)
+	assert.Error(t, db.WithContext(ctx).First(&model).Error)
+	parent.End()
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 3)
+
+	okSpan := spans[0]
+	assert.Equal(t, "gorm.query", okSpan.Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), okSpan.Parent().SpanID())
+	assert.Contains(t, okSpan.Attributes(), attribute.String("db.collection.name", "tracing_test_models"))
+	assert.Contains(t, okSpan.Attributes(), attribute.Int64("db.rows_affected", 1))
+	assert.Equal(t, codes.Unset, okSpan.Status().Code)
+
+	errorSpan := spans[1]
+	assert.Equal(t, codes.Error, errorSpan.Status().Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGormPlugin_Name(t *testing.T) {
+	assert.Equal(t, "tracing", NewGormPlugin().Name())
+}
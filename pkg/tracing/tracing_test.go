@@ -0,0 +1,76 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func setupRecorder(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+	})
+
+	return recorder
+}
+
+func TestInit(t *testing.T) {
+	tests := []struct {
+		name        string
+		exporter    string
+		expectError bool
+	}{
+		{name: "disabled by default", exporter: ""},
+		{name: "none exporter", exporter: ExporterNone},
+		{name: "stdout exporter", exporter: ExporterStdout},
+		{name: "invalid exporter", exporter: "zipkin", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			previous := otel.GetTracerProvider()
+			defer otel.SetTracerProvider(previous)
+
+			shutdown, err := Init(context.Background(), Options{
+				ServiceName: "test-service",
+				Exporter:    tt.exporter,
+				SampleRatio: 1,
+			})
+
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Nil(t, shutdown)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.NoError(t, shutdown(context.Background()))
+		})
+	}
+}
+
+func TestStartSpanAndIDs(t *testing.T) {
+	recorder := setupRecorder(t)
+
+	_, _, ok := IDs(context.Background())
+	assert.False(t, ok)
+
+	ctx, span := StartSpan(context.Background(), "TestSpan")
+	traceID, spanID, ok := IDs(ctx)
+	span.End()
+
+	assert.True(t, ok)
+	assert.Equal(t, span.SpanContext().TraceID().String(), traceID)
+	assert.Equal(t, span.SpanContext().SpanID().String(), spanID)
+	assert.Len(t, recorder.Ended(), 1)
+	assert.Equal(t, "TestSpan", recorder.Ended()[0].Name())
+}
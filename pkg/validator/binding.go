@@ -0,0 +1,154 @@
+package validator
+
+import (
+	"context"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// RuleMaxBytes is the rule of the error for a body over the size limit.
+const RuleMaxBytes = "maxBytes"
+
+// BindingErrors describes an error from binding a JSON request body in the
+// same shape as validation errors, with messages in the locale chosen for
+// the request in ctx.
+func (v *Validator) BindingErrors(ctx context.Context, err error) Errors {
+	var fieldErrors Errors
+	var validationErrors validator.ValidationErrors
+	var elementErrors sliceErrors
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	var maxBytesErr *http.MaxBytesError
+	var unknownErr *UnknownFieldError
+	var duplicateErr *DuplicateKeyError
+	var formatErr *FieldFormatError
+
+	switch {
+	case errors.As(err, &fieldErrors):
+		return fieldErrors
+	case errors.As(err, &maxBytesErr):
+		return Errors{{
+			Rule:    RuleMaxBytes,
+			Param:   fmt.Sprint(maxBytesErr.Limit),
+			Message: Message(ctx, "bodyTooLarge", maxBytesErr.Limit),
+		}}
+	case errors.As(err, &unknownErr):
+		return Errors{{
+			Field:   unknownErr.Field,
+			Path:    jsonPointer(unknownErr.Field),
+			Rule:    "unknown",
+			Message: Message(ctx, "unknownField", unknownErr.Field),
+		}}
+	case errors.As(err, &duplicateErr):
+		return Errors{{
+			Field:   duplicateErr.Field,
+			Path:    jsonPointer(duplicateErr.Field),
+			Rule:    "duplicate",
+			Message: Message(ctx, "duplicateKey", duplicateErr.Field),
+		}}
+	case errors.As(err, &formatErr):
+		name := formatErr.Field
+		if name == "" {
+			name = Message(ctx, "requestBody")
+		}
+		return Errors{{
+			Field:   formatErr.Field,
+			Path:    jsonPointer(formatErr.Field),
+			Rule:    "format",
+			Message: Message(ctx, "invalidFormat", name),
+		}}
+	case errors.Is(err, errTrailingData):
+		return Errors{{Rule: "json", Message: Message(ctx, "bodyTrailingData")}}
+	case errors.As(err, &validationErrors):
+		return v.binding.translate(Locale(ctx), validationErrors)
+	case errors.As(err, &elementErrors):
+		var indexed Errors
+		for _, i := range slices.Sorted(maps.Keys(elementErrors)) {
+			for _, fieldErr := range v.binding.translate(Locale(ctx), elementErrors[i]) {
+				fieldErr.Field = fmt.Sprintf("[%d].%s", i, fieldErr.Field)
+				fieldErr.Path = fmt.Sprintf("/%d%s", i, fieldErr.Path)
+				indexed = append(indexed, fieldErr)
+			}
+		}
+		return indexed
+	case errors.Is(err, io.EOF):
+		return Errors{{Rule: "required", Message: Message(ctx, "bodyRequired")}}
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return Errors{{Rule: "json", Message: Message(ctx, "bodyTruncated")}}
+	case errors.As(err, &syntaxErr):
+		return Errors{{
+			Rule:    "json",
+			Param:   fmt.Sprint(syntaxErr.Offset),
+			Message: Message(ctx, "bodySyntax", syntaxErr.Offset, strings.TrimPrefix(syntaxErr.Error(), "json: ")),
+		}}
+	case errors.As(err, &typeErr):
+		field := bracketIndexes(typeErr.Field)
+		expected := jsonType(typeErr.Type)
+		name := field
+		if name == "" {
+			name = Message(ctx, "requestBody")
+		}
+		return Errors{{
+			Field:   field,
+			Path:    jsonPointer(field),
+			Rule:    "type",
+			Param:   expected,
+			Message: Message(ctx, "bodyType", name, Message(ctx, expected)),
+		}}
+	default:
+		return Errors{{Rule: "json", Message: Message(ctx, "bodyInvalid")}}
+	}
+}
+
+// bracketIndexes rewrites encoding/json's "items.0.name" as "items[0].name"
+// to match validation errors.
+func bracketIndexes(field string) string {
+	var out strings.Builder
+	for i, segment := range strings.Split(field, ".") {
+		switch {
+		case segment != "" && strings.Trim(segment, "0123456789") == "":
+			out.WriteString("[" + segment + "]")
+		case i > 0:
+			out.WriteString("." + segment)
+		default:
+			out.WriteString(segment)
+		}
+	}
+	return out.String()
+}
+
+var textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+
+// jsonType names the JSON type a Go type is decoded from.
+func jsonType(t reflect.Type) string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return "string"
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.String:
+		return "string"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	default:
+		return "object"
+	}
+}
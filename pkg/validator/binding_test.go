@@ -0,0 +1,130 @@
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type bindingItem struct {
+	Name string `json:"name" binding:"required"`
+}
+
+type bindingRequest struct {
+	AuthorID uuid.UUID     `json:"authorId"`
+	Year     int           `json:"year" binding:"required"`
+	Items    []bindingItem `json:"items" binding:"dive"`
+	Internal string        `json:"-"`
+}
+
+func decode(body string) error {
+	var req bindingRequest
+	return json.NewDecoder(strings.NewReader(body)).Decode(&req)
+}
+
+func TestBindingErrors(t *testing.T) {
+	v := NewValidator()
+
+	tests := []struct {
+		name     string
+		err      error
+		expected Errors
+	}{
+		{
+			name:     "empty body",
+			err:      decode(""),
+			expected: Errors{{Rule: "required", Message: "Request body is required"}},
+		},
+		{
+			name:     "truncated body",
+			err:      decode(`{"year": 1`),
+			expected: Errors{{Rule: "json", Message: "Request body is not valid JSON: unexpected end of input"}},
+		},
+		{
+			name:     "malformed body",
+			err:      decode(`{"year": x}`),
+			expected: Errors{{Rule: "json", Param: "10", Message: "Request body is not valid JSON at offset 10: invalid character 'x' looking for beginning of value"}},
+		},
+		{
+			name:     "wrong type",
+			err:      decode(`{"year": "2000"}`),
+			expected: Errors{{Field: "year", Path: "/year", Rule: "type", Param: "number", Message: "year must be a number"}},
+		},
+		{
+			name:     "wrong nested type",
+			err:      decode(`{"items": [{"name": 1}]}`),
+			expected: Errors{{Field: "items[0].name", Path: "/items/0/name", Rule: "type", Param: "string", Message: "items[0].name must be a string"}},
+		},
+		{
+			name:     "text unmarshaler",
+			err:      decode(`{"authorId": 1}`),
+			expected: Errors{{Field: "authorId", Path: "/authorId", Rule: "type", Param: "string", Message: "authorId must be a string"}},
+		},
+		{
+			name:     "validation errors pass through",
+			err:      Errors{{Field: "year", Path: "/year", Rule: "required", Message: "year is a required field"}},
+			expected: Errors{{Field: "year", Path: "/year", Rule: "required", Message: "year is a required field"}},
+		},
+		{
+			name:     "other error",
+			err:      errors.New("boom"),
+			expected: Errors{{Rule: "json", Message: "Request body is not valid JSON"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, v.BindingErrors(context.Background(), tt.err))
+		})
+	}
+	assert.True(t, errors.Is(decode(""), io.EOF))
+}
+
+func TestBindingValidator_ValidateStruct(t *testing.T) {
+	v := NewValidator()
+	b := v.Binding()
+	ctx := context.Background()
+
+	err := b.ValidateStruct(&bindingRequest{Items: []bindingItem{{Name: "a"}, {}}})
+	assert.Equal(t, Errors{
+		{Field: "year", Path: "/year", Rule: "required", Message: "year is a required field"},
+		{Field: "items[1].name", Path: "/items/1/name", Rule: "required", Message: "name is a required field"},
+	}, v.BindingErrors(ctx, err))
+
+	err = b.ValidateStruct([]bindingItem{{Name: "a"}, {}})
+	assert.Equal(t, Errors{
+		{Field: "[1].name", Path: "/1/name", Rule: "required", Message: "name is a required field"},
+	}, v.BindingErrors(ctx, err))
+
+	assert.NoError(t, b.ValidateStruct(&bindingRequest{Year: 2000}))
+	assert.NoError(t, b.ValidateStruct("not a struct"))
+	assert.NoError(t, b.ValidateStruct(nil))
+}
+
+func TestBindingErrors_Thai(t *testing.T) {
+	v := NewValidator()
+	ctx := localeContext(t, "th")
+
+	assert.Equal(t, Errors{{Rule: "required", Message: "ต้องระบุเนื้อหาคำขอ"}}, v.BindingErrors(ctx, decode("")))
+	assert.Equal(t, Errors{
+		{Field: "year", Path: "/year", Rule: "type", Param: "number", Message: "year ต้องเป็นตัวเลข"},
+	}, v.BindingErrors(ctx, decode(`{"year": "2000"}`)))
+
+	err := v.Binding().ValidateStruct(&bindingRequest{})
+	assert.Equal(t, Errors{
+		{Field: "year", Path: "/year", Rule: "required", Message: "โปรดระบุ year"},
+	}, v.BindingErrors(ctx, err))
+}
+
+func TestJSONPointer(t *testing.T) {
+	assert.Equal(t, "", jsonPointer(""))
+	assert.Equal(t, "/penName", jsonPointer("penName"))
+	assert.Equal(t, "/items/0/name", jsonPointer("items[0].name"))
+	assert.Equal(t, "/a~1b/c~0d", jsonPointer("a/b.c~d"))
+}
@@ -0,0 +1,249 @@
+package validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+)
+
+// UnknownFieldError reports a key that matches no field of the target
+// struct. Keys are matched case-sensitively, unlike encoding/json.
+type UnknownFieldError struct {
+	Field string
+}
+
+func (e *UnknownFieldError) Error() string {
+	return fmt.Sprintf("unknown field %q", e.Field)
+}
+
+// DuplicateKeyError reports a key that appears twice in the same object.
+type DuplicateKeyError struct {
+	Field string
+}
+
+func (e *DuplicateKeyError) Error() string {
+	return fmt.Sprintf("duplicate key %q", e.Field)
+}
+
+// FieldFormatError reports a value that has the right JSON type but that
+// the field's type fails to decode, such as a malformed UUID.
+type FieldFormatError struct {
+	Field string
+	Err   error
+}
+
+func (e *FieldFormatError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Field, e.Err)
+}
+
+func (e *FieldFormatError) Unwrap() error {
+	return e.Err
+}
+
+var errTrailingData = errors.New("unexpected data after the JSON value")
+
+// JSONBinding decodes a JSON request body like gin's binding.JSON, but
+// reports values that fail their type's own decoding as a FieldFormatError.
+// Strict also rejects unknown fields, duplicate keys and data after the JSON
+// value. Install it as binding.JSON to use it from ShouldBindJSON.
+type JSONBinding struct {
+	Strict bool
+}
+
+func (JSONBinding) Name() string {
+	return "json"
+}
+
+func (b JSONBinding) Bind(req *http.Request, obj any) error {
+	if req == nil || req.Body == nil {
+		return errors.New("invalid request")
+	}
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return err
+	}
+	return b.BindBody(body, obj)
+}
+
+func (b JSONBinding) BindBody(body []byte, obj any) error {
+	scanner := json.NewDecoder(bytes.NewReader(body))
+	if err := b.scan(scanner, reflect.TypeOf(obj), ""); err != nil {
+		var unknownErr *UnknownFieldError
+		var duplicateErr *DuplicateKeyError
+		var formatErr *FieldFormatError
+		if errors.As(err, &unknownErr) || errors.As(err, &duplicateErr) || errors.As(err, &formatErr) {
+			return err
+		}
+		// Malformed JSON is reported by Decode below.
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	if b.Strict {
+		decoder.DisallowUnknownFields()
+	}
+	if err := decoder.Decode(obj); err != nil {
+		return err
+	}
+	if b.Strict {
+		if _, err := decoder.Token(); err != io.EOF {
+			return errTrailingData
+		}
+	}
+
+	if binding.Validator == nil {
+		return nil
+	}
+	return binding.Validator.ValidateStruct(obj)
+}
+
+var jsonUnmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+
+// scan reads the next JSON value from decoder and decodes every value whose
+// type has its own decoding on its own, so a failure can be tied to its
+// field. When strict, it also checks the keys of every object against t. A
+// nil t accepts any value.
+func (b JSONBinding) scan(decoder *json.Decoder, t reflect.Type, path string) error {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t != nil && (reflect.PointerTo(t).Implements(jsonUnmarshalerType) || reflect.PointerTo(t).Implements(textUnmarshalerType)) {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			return err
+		}
+		err := json.Unmarshal(raw, reflect.New(t).Interface())
+		var syntaxErr *json.SyntaxError
+		var typeErr *json.UnmarshalTypeError
+		if err != nil && !errors.As(err, &syntaxErr) && !errors.As(err, &typeErr) {
+			return &FieldFormatError{Field: path, Err: err}
+		}
+		// Type mismatches are reported with their field by Decode.
+		return nil
+	}
+
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	delim, ok := token.(json.Delim)
+	if !ok {
+		return nil
+	}
+
+	switch delim {
+	case '{':
+		var fields map[string]reflect.Type
+		var elem reflect.Type
+		switch {
+		case t == nil:
+		case t.Kind() == reflect.Struct:
+			fields = jsonFields(t)
+		case t.Kind() == reflect.Map:
+			elem = t.Elem()
+		}
+
+		seen := map[string]bool{}
+		for decoder.More() {
+			keyToken, err := decoder.Token()
+			if err != nil {
+				return err
+			}
+			key := keyToken.(string)
+			field := joinField(path, key)
+			if b.Strict && seen[key] {
+				return &DuplicateKeyError{Field: field}
+			}
+			seen[key] = true
+
+			valueType := elem
+			if fields != nil {
+				fieldType, found := b.lookupField(fields, key)
+				if !found && b.Strict {
+					return &UnknownFieldError{Field: field}
+				}
+				valueType = fieldType
+			}
+			if err := b.scan(decoder, valueType, field); err != nil {
+				return err
+			}
+		}
+	case '[':
+		var elem reflect.Type
+		if t != nil && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+			elem = t.Elem()
+		}
+		for i := 0; decoder.More(); i++ {
+			if err := b.scan(decoder, elem, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err = decoder.Token()
+	return err
+}
+
+// lookupField finds the field a key decodes into. Outside strict mode keys
+// match case-insensitively, as they do in encoding/json.
+func (b JSONBinding) lookupField(fields map[string]reflect.Type, key string) (reflect.Type, bool) {
+	if fieldType, found := fields[key]; found || b.Strict {
+		return fieldType, found
+	}
+	for name, fieldType := range fields {
+		if strings.EqualFold(name, key) {
+			return fieldType, true
+		}
+	}
+	return nil, false
+}
+
+// jsonFields maps the JSON names of t's fields, including those promoted
+// from embedded structs, to their types.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "-" && tag == "-" {
+			continue
+		}
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			for embeddedName, embeddedType := range jsonFields(fieldType) {
+				if _, exists := fields[embeddedName]; !exists {
+					fields[embeddedName] = embeddedType
+				}
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = field.Type
+	}
+	return fields
+}
+
+func joinField(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+var _ binding.BindingBody = JSONBinding{}
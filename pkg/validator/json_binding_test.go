@@ -0,0 +1,195 @@
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type strictBase struct {
+	ID uuid.UUID `json:"id"`
+}
+
+type strictRequest struct {
+	strictBase
+	AuthorID  uuid.UUID         `json:"authorId"`
+	AuthorIDs []uuid.UUID       `json:"authorIds"`
+	Items     []bindingItem     `json:"items"`
+	Labels    map[string]string `json:"labels"`
+	Extra     interface{}       `json:"extra"`
+	Internal  string            `json:"-"`
+}
+
+func TestJSONBinding_Strict(t *testing.T) {
+	id := uuid.New()
+
+	tests := []struct {
+		name        string
+		body        string
+		expectedErr error
+	}{
+		{
+			name: "valid",
+			body: `{"id": "` + id.String() + `", "items": [{"name": "a"}], "labels": {"a": "1"}, "extra": {"any": {"key": 1}}}`,
+		},
+		{
+			name:        "unknown field",
+			body:        `{"title": "x"}`,
+			expectedErr: &UnknownFieldError{Field: "title"},
+		},
+		{
+			name:        "case mismatch",
+			body:        `{"authorID": "` + id.String() + `"}`,
+			expectedErr: &UnknownFieldError{Field: "authorID"},
+		},
+		{
+			name:        "nested unknown field",
+			body:        `{"items": [{"name": "a"}, {"nmae": "b"}]}`,
+			expectedErr: &UnknownFieldError{Field: "items[1].nmae"},
+		},
+		{
+			name:        "ignored field",
+			body:        `{"Internal": "x"}`,
+			expectedErr: &UnknownFieldError{Field: "Internal"},
+		},
+		{
+			name:        "duplicate key",
+			body:        `{"authorId": "` + id.String() + `", "authorId": "` + id.String() + `"}`,
+			expectedErr: &DuplicateKeyError{Field: "authorId"},
+		},
+		{
+			name:        "duplicate map key",
+			body:        `{"labels": {"a": "1", "a": "2"}}`,
+			expectedErr: &DuplicateKeyError{Field: "labels.a"},
+		},
+		{
+			name:        "malformed uuid",
+			body:        `{"authorId": "abc"}`,
+			expectedErr: &FieldFormatError{Field: "authorId", Err: errors.New("invalid UUID length: 3")},
+		},
+		{
+			name:        "malformed uuid in array",
+			body:        `{"authorIds": ["` + id.String() + `", "abc"]}`,
+			expectedErr: &FieldFormatError{Field: "authorIds[1]", Err: errors.New("invalid UUID length: 3")},
+		},
+		{
+			name:        "trailing data",
+			body:        `{} {}`,
+			expectedErr: errTrailingData,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var req strictRequest
+			err := JSONBinding{Strict: true}.BindBody([]byte(tt.body), &req)
+			if tt.expectedErr == nil {
+				require.NoError(t, err)
+				assert.Equal(t, id, req.ID)
+				return
+			}
+			assert.IsType(t, tt.expectedErr, err)
+			assert.EqualError(t, err, tt.expectedErr.Error())
+		})
+	}
+}
+
+func TestJSONBinding_Lenient(t *testing.T) {
+	id := uuid.New()
+
+	tests := []struct {
+		name        string
+		body        string
+		expectedErr error
+	}{
+		{
+			name: "unknown field and trailing data",
+			body: `{"id": "` + id.String() + `", "title": "x"} {}`,
+		},
+		{
+			name:        "malformed uuid",
+			body:        `{"authorId": "abc"}`,
+			expectedErr: &FieldFormatError{Field: "authorId", Err: errors.New("invalid UUID length: 3")},
+		},
+		{
+			name:        "malformed uuid with case mismatch",
+			body:        `{"AUTHORID": "abc"}`,
+			expectedErr: &FieldFormatError{Field: "AUTHORID", Err: errors.New("invalid UUID length: 3")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var req strictRequest
+			err := JSONBinding{}.BindBody([]byte(tt.body), &req)
+			if tt.expectedErr == nil {
+				require.NoError(t, err)
+				assert.Equal(t, id, req.ID)
+				return
+			}
+			assert.IsType(t, tt.expectedErr, err)
+			assert.EqualError(t, err, tt.expectedErr.Error())
+		})
+	}
+
+	var req strictRequest
+	err := JSONBinding{}.BindBody([]byte(`{"authorId": 1}`), &req)
+	var typeErr *json.UnmarshalTypeError
+	require.ErrorAs(t, err, &typeErr)
+	assert.Equal(t, "authorId", typeErr.Field)
+}
+
+func TestJSONBinding_Bind(t *testing.T) {
+	var req strictRequest
+	err := JSONBinding{Strict: true}.Bind(httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"items": [`)), &req)
+	assert.Equal(t, Errors{
+		{Rule: "json", Message: "Request body is not valid JSON: unexpected end of input"},
+	}, NewValidator().BindingErrors(context.Background(), err))
+
+	limited := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"items": []}`))
+	limited.Body = http.MaxBytesReader(httptest.NewRecorder(), limited.Body, 4)
+	err = JSONBinding{Strict: true}.Bind(limited, &req)
+	var maxBytesErr *http.MaxBytesError
+	assert.True(t, errors.As(err, &maxBytesErr))
+}
+
+func TestBindingErrors_JSONBinding(t *testing.T) {
+	v := NewValidator()
+	ctx := context.Background()
+
+	assert.Equal(t, Errors{
+		{Field: "items[1].nmae", Path: "/items/1/nmae", Rule: "unknown", Message: "items[1].nmae is not a known field"},
+	}, v.BindingErrors(ctx, &UnknownFieldError{Field: "items[1].nmae"}))
+	assert.Equal(t, Errors{
+		{Field: "authorId", Path: "/authorId", Rule: "duplicate", Message: "authorId is set more than once"},
+	}, v.BindingErrors(ctx, &DuplicateKeyError{Field: "authorId"}))
+	assert.Equal(t, Errors{
+		{Field: "authorId", Path: "/authorId", Rule: "format", Message: "authorId has an invalid format"},
+	}, v.BindingErrors(ctx, &FieldFormatError{Field: "authorId", Err: errors.New("invalid UUID length: 3")}))
+	assert.Equal(t, Errors{
+		{Rule: "format", Message: "Request body has an invalid format"},
+	}, v.BindingErrors(ctx, &FieldFormatError{Err: errors.New("invalid UUID length: 3")}))
+	assert.Equal(t, Errors{
+		{Field: "authorIds[1]", Path: "/authorIds/1", Rule: "format", Message: "authorIds[1] มีรูปแบบไม่ถูกต้อง"},
+	}, v.BindingErrors(localeContext(t, "th"), &FieldFormatError{Field: "authorIds[1]", Err: errors.New("invalid UUID length: 3")}))
+	assert.Equal(t, Errors{
+		{Rule: "json", Message: "เนื้อหาคำขอไม่ใช่ JSON ที่ถูกต้อง"},
+	}, v.BindingErrors(localeContext(t, "th"), errors.New("invalid request")))
+	assert.Equal(t, Errors{
+		{Rule: "json", Message: "Request body must contain a single JSON value"},
+	}, v.BindingErrors(ctx, errTrailingData))
+	assert.Equal(t, Errors{
+		{Rule: RuleMaxBytes, Param: "1024", Message: "Request body must not be larger than 1024 bytes"},
+	}, v.BindingErrors(ctx, &http.MaxBytesError{Limit: 1024}))
+	assert.Equal(t, Errors{
+		{Field: "title", Path: "/title", Rule: "unknown", Message: "title ไม่ใช่ฟิลด์ที่รู้จัก"},
+	}, v.BindingErrors(localeContext(t, "th"), &UnknownFieldError{Field: "title"}))
+}
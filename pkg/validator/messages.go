@@ -0,0 +1,61 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+)
+
+// messages holds the messages this package writes itself, by locale. The
+// rule messages come from the validator translations instead.
+var messages = map[string]map[string]string{
+	"en": {
+		"bodyRequired":        "Request body is required",
+		"bodyInvalid":         "Request body is not valid JSON",
+		"bodyTruncated":       "Request body is not valid JSON: unexpected end of input",
+		"bodySyntax":          "Request body is not valid JSON at offset %d: %s",
+		"bodyType":            "%s must be %s",
+		"bodyTooLarge":        "Request body must not be larger than %d bytes",
+		"bodyTrailingData":    "Request body must contain a single JSON value",
+		"invalidFormat":       "%s has an invalid format",
+		"unknownField":        "%s is not a known field",
+		"duplicateKey":        "%s is set more than once",
+		"requestBody":         "Request body",
+		"boolean":             "a boolean",
+		"number":              "a number",
+		"string":              "a string",
+		"array":               "an array",
+		"object":              "an object",
+		"pageGreaterThan":     "Page must be greater than %d",
+		"pageSizeGreaterThan": "Page size must be greater than %d",
+	},
+	"th": {
+		"bodyRequired":        "ต้องระบุเนื้อหาคำขอ",
+		"bodyInvalid":         "เนื้อหาคำขอไม่ใช่ JSON ที่ถูกต้อง",
+		"bodyTruncated":       "เนื้อหาคำขอไม่ใช่ JSON ที่ถูกต้อง: ข้อมูลสิ้นสุดก่อนกำหนด",
+		"bodySyntax":          "เนื้อหาคำขอไม่ใช่ JSON ที่ถูกต้องที่ตำแหน่ง %d: %s",
+		"bodyType":            "%s ต้องเป็น%s",
+		"bodyTooLarge":        "เนื้อหาคำขอต้องมีขนาดไม่เกิน %d ไบต์",
+		"bodyTrailingData":    "เนื้อหาคำขอต้องมีค่า JSON เพียงค่าเดียว",
+		"invalidFormat":       "%s มีรูปแบบไม่ถูกต้อง",
+		"unknownField":        "%s ไม่ใช่ฟิลด์ที่รู้จัก",
+		"duplicateKey":        "%s ถูกระบุมากกว่าหนึ่งครั้ง",
+		"requestBody":         "เนื้อหาคำขอ",
+		"boolean":             "ค่าบูลีน",
+		"number":              "ตัวเลข",
+		"string":              "ข้อความ",
+		"array":               "อาร์เรย์",
+		"object":              "ออบเจ็กต์",
+		"pageGreaterThan":     "หน้าต้องมากกว่า %d",
+		"pageSizeGreaterThan": "ขนาดหน้าต้องมากกว่า %d",
+	},
+}
+
+// Message formats the message for key in the locale chosen for the request
+// in ctx, falling back to DefaultLocale.
+func Message(ctx context.Context, key string, args ...any) string {
+	format, ok := messages[Locale(ctx)][key]
+	if !ok {
+		format = messages[DefaultLocale][key]
+	}
+	return fmt.Sprintf(format, args...)
+}
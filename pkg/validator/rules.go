@@ -0,0 +1,120 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// Rule is a named validation rule usable from struct tags. Messages holds
+// its message for every locale in Locales, with {0} standing for the field
+// name and {1} for the tag parameter.
+type Rule struct {
+	Tag      string
+	Func     validator.Func
+	Messages map[string]string
+}
+
+// Register adds rule to both the validate and binding rules. Rules must be
+// registered at startup, before the Validator is used.
+func (v *Validator) Register(rule Rule) error {
+	if rule.Tag == "" {
+		return errors.New("validation rule tag is required")
+	}
+	if rule.Func == nil {
+		return fmt.Errorf("validation rule %q has no function", rule.Tag)
+	}
+	for _, locale := range Locales {
+		if rule.Messages[locale] == "" {
+			return fmt.Errorf("validation rule %q has no %s message", rule.Tag, locale)
+		}
+	}
+	if _, exists := v.rules[rule.Tag]; exists {
+		return fmt.Errorf("validation rule %q is already registered", rule.Tag)
+	}
+
+	for _, engine := range []*engine{v.validate, v.binding} {
+		if err := engine.register(rule); err != nil {
+			return fmt.Errorf("validation rule %q: %w", rule.Tag, err)
+		}
+	}
+	v.rules[rule.Tag] = struct{}{}
+	return nil
+}
+
+func (v *Validator) MustRegister(rules ...Rule) {
+	for _, rule := range rules {
+		if err := v.Register(rule); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func (e *engine) register(rule Rule) error {
+	if err := e.validate.RegisterValidation(rule.Tag, rule.Func); err != nil {
+		return err
+	}
+	for _, locale := range Locales {
+		message := rule.Messages[locale]
+		err := e.validate.RegisterTranslation(rule.Tag, e.translator(locale),
+			func(trans ut.Translator) error {
+				return trans.Add(rule.Tag, message, true)
+			},
+			func(trans ut.Translator, fe validator.FieldError) string {
+				text, err := trans.T(rule.Tag, fe.Field(), fe.Param())
+				if err != nil {
+					return fe.Error()
+				}
+				return text
+			},
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NotFutureYearRule accepts years up to the current one.
+var NotFutureYearRule = Rule{
+	Tag: "notfutureyear",
+	Func: func(fl validator.FieldLevel) bool {
+		return fl.Field().Int() <= int64(time.Now().Year())
+	},
+	Messages: map[string]string{
+		"en": "{0} must not be in the future",
+		"th": "{0} ต้องไม่เป็นปีในอนาคต",
+	},
+}
+
+// UUIDv4Rule accepts version 4 UUIDs, as strings or uuid.UUID values.
+var UUIDv4Rule = Rule{
+	Tag: "uuidv4",
+	Func: func(fl validator.FieldLevel) bool {
+		var id uuid.UUID
+		switch value := fl.Field().Interface().(type) {
+		case uuid.UUID:
+			id = value
+		case string:
+			parsed, err := uuid.Parse(value)
+			if err != nil {
+				return false
+			}
+			id = parsed
+		default:
+			return false
+		}
+		return id.Version() == 4 && id.Variant() == uuid.RFC4122
+	},
+	Messages: map[string]string{
+		"en": "{0} must be a valid version 4 UUID",
+		"th": "{0} ต้องเป็น UUID เวอร์ชัน 4 ที่ถูกต้อง",
+	},
+}
+
+// defaultRules are registered on every Validator.
+var defaultRules = []Rule{NotFutureYearRule, UUIDv4Rule}
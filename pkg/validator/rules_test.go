@@ -0,0 +1,115 @@
+package validator
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type ruleStruct struct {
+	Year int    `json:"year" validate:"notfutureyear"`
+	ID   string `json:"id" validate:"uuidv4"`
+}
+
+func TestValidator_Register(t *testing.T) {
+	always := func(validator.FieldLevel) bool { return true }
+	messages := map[string]string{"en": "{0} is odd", "th": "{0} เป็นเลขคี่"}
+
+	tests := []struct {
+		name        string
+		rule        Rule
+		expectedErr string
+	}{
+		{
+			name:        "missing tag",
+			rule:        Rule{Func: always, Messages: messages},
+			expectedErr: "validation rule tag is required",
+		},
+		{
+			name:        "missing function",
+			rule:        Rule{Tag: "odd", Messages: messages},
+			expectedErr: `validation rule "odd" has no function`,
+		},
+		{
+			name:        "missing translation",
+			rule:        Rule{Tag: "odd", Func: always, Messages: map[string]string{"en": "{0} is odd"}},
+			expectedErr: `validation rule "odd" has no th message`,
+		},
+		{
+			name:        "already registered",
+			rule:        Rule{Tag: "uuidv4", Func: always, Messages: messages},
+			expectedErr: `validation rule "uuidv4" is already registered`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, NewValidator().Register(tt.rule), tt.expectedErr)
+		})
+	}
+}
+
+func TestValidator_RegisteredRuleMessages(t *testing.T) {
+	v := NewValidator()
+	v.MustRegister(Rule{
+		Tag:      "odd",
+		Func:     func(fl validator.FieldLevel) bool { return fl.Field().Int()%2 == 1 },
+		Messages: map[string]string{"en": "{0} must be odd", "th": "{0} ต้องเป็นเลขคี่"},
+	})
+	input := struct {
+		Count int `json:"count" validate:"odd"`
+	}{Count: 2}
+
+	assert.Equal(t, Errors{
+		{Field: "count", Path: "/count", Rule: "odd", Message: "count must be odd"},
+	}, v.Validate(context.Background(), input))
+	assert.Equal(t, []string{"count ต้องเป็นเลขคี่"}, v.Validate(localeContext(t, "th"), input).Messages())
+
+	err := v.Binding().ValidateStruct(&struct {
+		Count int `json:"count" binding:"odd"`
+	}{Count: 2})
+	assert.Equal(t, []string{"count ต้องเป็นเลขคี่"}, v.BindingErrors(localeContext(t, "th"), err).Messages())
+}
+
+func TestDefaultRules(t *testing.T) {
+	v := NewValidator()
+	year := time.Now().Year()
+	v4 := uuid.New().String()
+
+	tests := []struct {
+		name     string
+		input    ruleStruct
+		expected []string
+	}{
+		{name: "valid", input: ruleStruct{Year: year, ID: v4}},
+		{name: "future year", input: ruleStruct{Year: year + 1, ID: v4}, expected: []string{"year must not be in the future"}},
+		{name: "uuid v1", input: ruleStruct{Year: year, ID: "6ba7b810-9dad-11d1-80b4-00c04fd430c8"}, expected: []string{"id must be a valid version 4 UUID"}},
+		{name: "not a uuid", input: ruleStruct{Year: year, ID: "abc"}, expected: []string{"id must be a valid version 4 UUID"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errors := v.Validate(context.Background(), tt.input)
+			if tt.expected == nil {
+				assert.Nil(t, errors)
+				return
+			}
+			assert.Equal(t, tt.expected, errors.Messages())
+		})
+	}
+
+	errors := v.Validate(localeContext(t, "th"), ruleStruct{Year: year + 1, ID: v4})
+	assert.Equal(t, []string{"year ต้องไม่เป็นปีในอนาคต"}, errors.Messages())
+
+	assert.Nil(t, v.Validate(context.Background(), struct {
+		ID uuid.UUID `validate:"uuidv4"`
+	}{ID: uuid.New()}))
+	assert.NotNil(t, v.Validate(context.Background(), struct {
+		ID uuid.UUID `validate:"uuidv4"`
+	}{ID: uuid.NewSHA1(uuid.NameSpaceURL, []byte(strconv.Itoa(year)))}))
+}
@@ -1,41 +1,144 @@
 package validator
 
 import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
 	english "github.com/go-playground/locales/en"
+	thai "github.com/go-playground/locales/th"
 	ut "github.com/go-playground/universal-translator"
 	"github.com/go-playground/validator/v10"
 	"github.com/go-playground/validator/v10/translations/en"
+	"github.com/go-playground/validator/v10/translations/th"
+	"github.com/sirawatc/simple-gin-crud/pkg/middleware"
 )
 
+// DefaultLocale is used when a request has no supported locale.
+const DefaultLocale = "en"
+
+// Locales lists the locales messages are translated to, default first.
+var Locales = []string{DefaultLocale, "th"}
+
+var registerDefaultTranslations = map[string]func(*validator.Validate, ut.Translator) error{
+	"en": en.RegisterDefaultTranslations,
+	"th": th.RegisterDefaultTranslations,
+}
+
+// Validator checks the rules in `validate` struct tags, and through Binding
+// the rules in `binding` struct tags. Both are set up with their
+// translations once, so build one Validator at startup, register the
+// domain rules on it and share it.
 type Validator struct {
+	validate *engine
+	binding  *engine
+	rules    map[string]struct{}
+}
+
+// engine is a validate instance with its own translators; translations are
+// registered per instance and cannot be shared between them.
+type engine struct {
 	validate *validator.Validate
+	uni      *ut.UniversalTranslator
+}
+
+// FieldError describes a field that failed a validation rule. Field is the
+// dotted JSON name (e.g. "items[0].name") and Path the matching JSON
+// pointer (e.g. "/items/0/name") into the request body. Both are empty for
+// errors about the whole body, and Path is empty for query parameters.
+type FieldError struct {
+	Field   string `json:"field,omitempty"`
+	Path    string `json:"path,omitempty"`
+	Rule    string `json:"rule"`
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message"`
+}
+
+// Errors lists every rule that failed.
+type Errors []FieldError
+
+func (e Errors) Error() string {
+	return strings.Join(e.Messages(), "; ")
 }
 
+// Messages returns the message of each error.
+func (e Errors) Messages() []string {
+	messages := make([]string, 0, len(e))
+	for _, fieldErr := range e {
+		messages = append(messages, fieldErr.Message)
+	}
+	return messages
+}
+
+// NewValidator sets up the validate and binding rules with their
+// translations, including the default rules. It panics if a translation
+// cannot be registered.
 func NewValidator() *Validator {
-	return &Validator{
-		validate: validator.New(),
+	v := &Validator{
+		validate: newEngine("validate"),
+		binding:  newEngine("binding"),
+		rules:    map[string]struct{}{},
 	}
+	v.MustRegister(defaultRules...)
+	return v
 }
 
-func (v *Validator) Validate(i interface{}) []string {
-	err := v.validate.Struct(i)
-	if err != nil {
-		if validationErrors, ok := err.(validator.ValidationErrors); ok {
-			return v.TranslateErrors(validationErrors)
+func newEngine(tagName string) *engine {
+	validate := validator.New()
+	validate.SetTagName(tagName)
+	validate.RegisterTagNameFunc(jsonName)
+
+	uni := ut.New(english.New(), english.New(), thai.New())
+	for _, locale := range Locales {
+		trans, _ := uni.GetTranslator(locale)
+		if err := registerDefaultTranslations[locale](validate, trans); err != nil {
+			panic(fmt.Sprintf("validator: failed to register %s translations: %v", locale, err))
 		}
-		return []string{err.Error()}
 	}
-	return nil
+	return &engine{
+		validate: validate,
+		uni:      uni,
+	}
 }
 
-func (v *Validator) TranslateErrors(validationErrors validator.ValidationErrors) []string {
-	eng := english.New()
-	uni := ut.New(eng, eng)
-	trans, _ := uni.GetTranslator("en")
-	err := en.RegisterDefaultTranslations(v.validate, trans)
-	if err != nil {
-		return []string{err.Error()}
+// jsonName names fields after their json tag so errors match the request
+// body.
+func jsonName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return field.Name
+	default:
+		return name
+	}
+}
+
+// Validate checks i against its rules, with messages in the locale chosen
+// for the request in ctx.
+func (v *Validator) Validate(ctx context.Context, i interface{}) Errors {
+	err := v.validate.validate.Struct(i)
+	if err == nil {
+		return nil
+	}
+
+	validationErrors, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return Errors{{Message: err.Error()}}
 	}
+	return v.validate.translate(Locale(ctx), validationErrors)
+}
+
+// Binding returns the validator to install as gin's binding.Validator. Its
+// errors are translated by BindingErrors.
+func (v *Validator) Binding() *BindingValidator {
+	return &BindingValidator{engine: v.binding}
+}
+
+func (v *Validator) TranslateErrors(validationErrors validator.ValidationErrors) []string {
+	trans := v.validate.translator(DefaultLocale)
 	errors := []string{}
 
 	for _, validationError := range validationErrors {
@@ -43,3 +146,117 @@ func (v *Validator) TranslateErrors(validationErrors validator.ValidationErrors)
 	}
 	return errors
 }
+
+// translator returns the translator for locale, or the default one when
+// locale is not supported.
+func (e *engine) translator(locale string) ut.Translator {
+	if trans, found := e.uni.GetTranslator(locale); found {
+		return trans
+	}
+	trans, _ := e.uni.GetTranslator(DefaultLocale)
+	return trans
+}
+
+func (e *engine) translate(locale string, validationErrors validator.ValidationErrors) Errors {
+	trans := e.translator(locale)
+	errors := make(Errors, 0, len(validationErrors))
+	for _, validationError := range validationErrors {
+		field := fieldPath(validationError.Namespace())
+		errors = append(errors, FieldError{
+			Field:   field,
+			Path:    jsonPointer(field),
+			Rule:    validationError.Tag(),
+			Param:   validationError.Param(),
+			Message: validationError.Translate(trans),
+		})
+	}
+	return errors
+}
+
+// BindingValidator implements gin's binding.StructValidator. It does not
+// know the request locale, so it returns untranslated errors for
+// Validator.BindingErrors to translate.
+type BindingValidator struct {
+	engine *engine
+}
+
+// sliceErrors holds the errors of each invalid element of a bound slice.
+type sliceErrors map[int]validator.ValidationErrors
+
+func (e sliceErrors) Error() string {
+	return fmt.Sprintf("%d invalid elements", len(e))
+}
+
+// ValidateStruct implements gin's binding.StructValidator.
+func (b *BindingValidator) ValidateStruct(obj any) error {
+	if obj == nil {
+		return nil
+	}
+
+	value := reflect.ValueOf(obj)
+	switch value.Kind() {
+	case reflect.Ptr:
+		if value.Elem().Kind() != reflect.Struct {
+			return b.ValidateStruct(value.Elem().Interface())
+		}
+	case reflect.Struct:
+	case reflect.Slice, reflect.Array:
+		errors := sliceErrors{}
+		for i := 0; i < value.Len(); i++ {
+			if err := b.ValidateStruct(value.Index(i).Interface()); err != nil {
+				if validationErrors, ok := err.(validator.ValidationErrors); ok {
+					errors[i] = validationErrors
+				}
+			}
+		}
+		if len(errors) == 0 {
+			return nil
+		}
+		return errors
+	default:
+		return nil
+	}
+
+	return b.engine.validate.Struct(obj)
+}
+
+// Engine implements gin's binding.StructValidator.
+func (b *BindingValidator) Engine() any {
+	return b.engine.validate
+}
+
+// Locale returns the locale chosen for the request in ctx, or DefaultLocale.
+func Locale(ctx context.Context) string {
+	if locale := middleware.GetLocale(ctx); locale != "" {
+		return locale
+	}
+	return DefaultLocale
+}
+
+// fieldPath drops the struct name from a namespace such as
+// "CreateBookRequest.items[0].name".
+func fieldPath(namespace string) string {
+	_, field, found := strings.Cut(namespace, ".")
+	if !found {
+		return namespace
+	}
+	return field
+}
+
+// jsonPointer converts a dotted field such as "items[0].name" into the
+// JSON pointer "/items/0/name".
+func jsonPointer(field string) string {
+	if field == "" {
+		return ""
+	}
+
+	var pointer strings.Builder
+	for _, segment := range strings.FieldsFunc(field, func(r rune) bool {
+		return r == '.' || r == '[' || r == ']'
+	}) {
+		segment = strings.ReplaceAll(segment, "~", "~0")
+		segment = strings.ReplaceAll(segment, "/", "~1")
+		pointer.WriteString("/" + segment)
+	}
+	return pointer.String()
+}
@@ -1,9 +1,14 @@
 package validator
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
+	"github.com/sirawatc/simple-gin-crud/pkg/middleware"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -15,6 +20,17 @@ type TestStruct struct {
 	Website  string `validate:"url"`
 }
 
+// localeContext returns the request context LocaleMiddleware builds for
+// Accept-Language: locale.
+func localeContext(t *testing.T, locale string) context.Context {
+	t.Helper()
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	c.Request.Header.Set("Accept-Language", locale)
+	middleware.LocaleMiddleware(Locales...)(c)
+	return c.Request.Context()
+}
+
 func TestNewValidator(t *testing.T) {
 	v := NewValidator()
 	assert.NotNil(t, v)
@@ -79,8 +95,51 @@ func TestValidator_Validate(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			errors := v.Validate(test.input)
-			assert.Equal(t, test.expected, errors)
+			errors := v.Validate(context.Background(), test.input)
+			if test.expected == nil {
+				assert.Nil(t, errors)
+				return
+			}
+			assert.Equal(t, test.expected, errors.Messages())
+		})
+	}
+}
+
+func TestValidator_Validate_FieldErrors(t *testing.T) {
+	v := NewValidator()
+
+	errors := v.Validate(context.Background(), TestStruct{
+		Username: "John Doe",
+		Password: "123",
+		Age:      25,
+		Email:    "john@example.com",
+		Website:  "https://example.com",
+	})
+
+	assert.Equal(t, Errors{
+		{Field: "Password", Path: "/Password", Rule: "min", Param: "8", Message: "Password must be at least 8 characters in length"},
+	}, errors)
+	assert.Equal(t, "Password must be at least 8 characters in length", errors.Error())
+}
+
+func TestValidator_Validate_Locale(t *testing.T) {
+	v := NewValidator()
+	input := TestStruct{Username: "John Doe", Password: "123", Age: 25, Email: "john@example.com", Website: "https://example.com"}
+
+	tests := []struct {
+		locale   string
+		expected string
+	}{
+		{locale: "th", expected: "Password ต้องมีความยาวอย่างน้อย 8 ตัวอักษร"},
+		{locale: "th-TH,th;q=0.9", expected: "Password ต้องมีความยาวอย่างน้อย 8 ตัวอักษร"},
+		{locale: "en", expected: "Password must be at least 8 characters in length"},
+		{locale: "fr", expected: "Password must be at least 8 characters in length"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			errors := v.Validate(localeContext(t, tt.locale), input)
+			assert.Equal(t, []string{tt.expected}, errors.Messages())
 		})
 	}
 }
@@ -103,7 +162,7 @@ func TestValidator_TranslateErrors(t *testing.T) {
 					Email:    "john@example.com",
 					Website:  "https://example.com",
 				}
-				err := v.validate.Struct(validStruct)
+				err := v.validate.validate.Struct(validStruct)
 				if err != nil {
 					return err.(validator.ValidationErrors)
 				}
@@ -126,7 +185,7 @@ func TestValidator_TranslateErrors(t *testing.T) {
 					Email:    "invalid-email",
 					Website:  "not-a-url",
 				}
-				err := v.validate.Struct(invalidStruct)
+				err := v.validate.validate.Struct(invalidStruct)
 				if err != nil {
 					return err.(validator.ValidationErrors)
 				}
@@ -0,0 +1,86 @@
+package server
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirawatc/simple-gin-crud/internal/shared/config"
+	"github.com/sirawatc/simple-gin-crud/internal/shared/dto"
+	"github.com/sirawatc/simple-gin-crud/pkg/logger"
+	"github.com/sirupsen/logrus"
+)
+
+type adminConfigResponse struct {
+	ReloadedAt time.Time      `json:"reloadedAt"`
+	Config     map[string]any `json:"config"`
+}
+
+func initAdminRoutes(router *gin.Engine, watcher *config.Watcher, token string, logger *logrus.Logger) {
+	admin := router.Group("/admin")
+	admin.Use(adminAuth(token))
+	{
+		admin.GET("/config", getAdminConfig(watcher, logger))
+		admin.POST("/config/reload", reloadAdminConfig(watcher, logger))
+	}
+}
+
+func adminAuth(token string) gin.HandlerFunc {
+	expected := []byte("Bearer " + token)
+	return func(c *gin.Context) {
+		if subtle.ConstantTimeCompare([]byte(c.GetHeader("Authorization")), expected) != 1 {
+			dto.AbortWithError(c, http.StatusUnauthorized, dto.Unauthorized, nil)
+			return
+		}
+		c.Next()
+	}
+}
+
+func getAdminConfig(watcher *config.Watcher, baseLogger *logrus.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		logPrefix := "[AdminHandler#GetConfig]"
+		logger := logger.InjectRequestIDWithLogger(c.Request.Context(), baseLogger)
+
+		values, err := config.Redacted(watcher.Current())
+		if err != nil {
+			logger.Errorf("%s Failed to render configuration: %v", logPrefix, err)
+			dto.RespondError(c, http.StatusInternalServerError, dto.InternalError, nil)
+			return
+		}
+
+		dto.Respond(c, http.StatusOK, dto.Success, adminConfigResponse{
+			ReloadedAt: watcher.ReloadedAt(),
+			Config:     values,
+		})
+	}
+}
+
+func reloadAdminConfig(watcher *config.Watcher, baseLogger *logrus.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		logPrefix := "[AdminHandler#ReloadConfig]"
+		logger := logger.InjectRequestIDWithLogger(c.Request.Context(), baseLogger)
+
+		err := watcher.Reload()
+		var validationErr *config.ValidationError
+		switch {
+		case errors.Is(err, config.ErrReloadDisabled):
+			dto.RespondError(c, http.StatusConflict, dto.Conflict, err.Error())
+			return
+		case errors.As(err, &validationErr):
+			messages := []string{}
+			for _, fieldErr := range validationErr.Errors {
+				messages = append(messages, fieldErr.Error())
+			}
+			dto.RespondError(c, http.StatusUnprocessableEntity, dto.UnprocessableEntity, messages)
+			return
+		case err != nil:
+			logger.Errorf("%s Failed to reload configuration: %v", logPrefix, err)
+			dto.RespondError(c, http.StatusInternalServerError, dto.InternalError, nil)
+			return
+		}
+
+		getAdminConfig(watcher, baseLogger)(c)
+	}
+}
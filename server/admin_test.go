@@ -0,0 +1,113 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirawatc/simple-gin-crud/internal/shared/config"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newAdminRouter(load func() (*config.Config, error)) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	watcher := config.NewWatcher(&config.Config{
+		Database: config.DatabaseConfig{Password: "s3cret"},
+		Log:      config.LogConfig{Level: "info"},
+	}, load, logger)
+
+	router := gin.New()
+	initAdminRoutes(router, watcher, "admin-token", logger)
+	return router
+}
+
+func adminRequest(router *gin.Engine, method string, path string, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestAdminConfig_RequiresToken(t *testing.T) {
+	router := newAdminRouter(nil)
+
+	assert.Equal(t, http.StatusUnauthorized, adminRequest(router, http.MethodGet, "/admin/config", "").Code)
+	assert.Equal(t, http.StatusUnauthorized, adminRequest(router, http.MethodGet, "/admin/config", "wrong").Code)
+}
+
+func TestAdminConfig_Get(t *testing.T) {
+	router := newAdminRouter(nil)
+
+	w := adminRequest(router, http.MethodGet, "/admin/config", "admin-token")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "s3cret")
+
+	var response struct {
+		Data struct {
+			ReloadedAt string         `json:"reloadedAt"`
+			Config     map[string]any `json:"config"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.NotEmpty(t, response.Data.ReloadedAt)
+	assert.Equal(t, "[REDACTED]", response.Data.Config["database"].(map[string]any)["password"])
+	assert.Equal(t, "info", response.Data.Config["log"].(map[string]any)["level"])
+}
+
+func TestAdminConfig_Reload(t *testing.T) {
+	tests := []struct {
+		name         string
+		load         func() (*config.Config, error)
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name: "reloaded",
+			load: func() (*config.Config, error) {
+				return &config.Config{Log: config.LogConfig{Level: "debug"}}, nil
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `"level":"debug"`,
+		},
+		{
+			name: "invalid configuration",
+			load: func() (*config.Config, error) {
+				return nil, &config.ValidationError{Errors: []config.FieldError{{Key: "log.level", Source: "file config.yaml", Message: "must be one of info"}}}
+			},
+			expectedCode: http.StatusUnprocessableEntity,
+			expectedBody: `log.level: must be one of info (from file config.yaml)`,
+		},
+		{
+			name:         "load error",
+			load:         func() (*config.Config, error) { return nil, errors.New("boom") },
+			expectedCode: http.StatusInternalServerError,
+		},
+		{
+			name:         "reload disabled",
+			expectedCode: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newAdminRouter(tt.load)
+
+			w := adminRequest(router, http.MethodPost, "/admin/config/reload", "admin-token")
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			assert.Contains(t, w.Body.String(), tt.expectedBody)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package server
+
+import (
+	"context"
+
+	"github.com/sirawatc/simple-gin-crud/database"
+	"github.com/sirawatc/simple-gin-crud/internal/shared/config"
+	"github.com/sirawatc/simple-gin-crud/pkg/health"
+	"gorm.io/gorm"
+)
+
+func newHealthRegistry(cfg config.HealthConfig, db *gorm.DB, isReady func() bool) *health.Registry {
+	registry := health.NewRegistry()
+	registry.MustRegister(
+		health.Check{
+			Name:     "shutdown",
+			Check:    health.ReadyCheck(isReady),
+			Probes:   []health.Probe{health.Readiness},
+			Critical: true,
+		},
+		health.Check{
+			Name:     "database",
+			Check:    health.DBPingCheck(db),
+			Probes:   []health.Probe{health.Readiness, health.Startup},
+			Timeout:  cfg.CheckTimeout,
+			Critical: true,
+			CacheTTL: cfg.CacheTTL,
+		},
+		// Pending migrations only degrade the probes: DB_AUTO_MIGRATE is off by
+		// default, and a new build must not be taken out of rotation while the
+		// migrations are still being applied.
+		health.Check{
+			Name: "migrations",
+			Check: health.MigrationCheck(func(ctx context.Context) ([]string, error) {
+				return database.PendingMigrations(ctx, db)
+			}),
+			Probes:   []health.Probe{health.Readiness, health.Startup},
+			Timeout:  cfg.CheckTimeout,
+			CacheTTL: cfg.CacheTTL,
+		},
+		health.Check{
+			Name:    "database_pool",
+			Check:   health.PoolSaturationCheck(db, cfg.PoolSaturationThreshold),
+			Probes:  []health.Probe{health.Readiness},
+			Timeout: cfg.CheckTimeout,
+		},
+		health.Check{
+			Name:     "disk_space",
+			Check:    health.DiskSpaceCheck(cfg.DiskPath, uint64(cfg.DiskMinFreeMB)*1024*1024),
+			Probes:   []health.Probe{health.Readiness},
+			Timeout:  cfg.CheckTimeout,
+			CacheTTL: cfg.CacheTTL,
+		},
+	)
+	return registry
+}
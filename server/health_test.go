@@ -0,0 +1,32 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/sirawatc/simple-gin-crud/internal/shared/config"
+	"github.com/sirawatc/simple-gin-crud/pkg/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestHealthRegistry_PendingMigrationsDegradeReadiness(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	registry := newHealthRegistry(config.HealthConfig{CheckTimeout: time.Second, DiskPath: "/", PoolSaturationThreshold: 1}, db, func() bool { return true })
+
+	report := registry.Run(context.Background(), health.Readiness)
+	assert.Equal(t, health.StatusDegraded, report.Status)
+	for _, check := range report.Checks {
+		if check.Name == "migrations" {
+			assert.False(t, check.Critical)
+			assert.Contains(t, check.Error, "pending migration(s)")
+			return
+		}
+	}
+	t.Fatal("readiness report has no migrations check")
+}
@@ -0,0 +1,144 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirawatc/simple-gin-crud/database"
+	"github.com/sirawatc/simple-gin-crud/internal/shared/config"
+	"github.com/sirawatc/simple-gin-crud/internal/shared/dto"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/suite"
+)
+
+type IntegrationTestSuite struct {
+	suite.Suite
+	srv *Server
+}
+
+func (suite *IntegrationTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{
+		Database: config.DatabaseConfig{
+			Driver:     database.DriverSQLite,
+			SQLitePath: filepath.Join(suite.T().TempDir(), "integration.db"),
+		},
+		Server: config.ServerConfig{Host: "127.0.0.1", Port: "0"},
+		Health: config.HealthConfig{CheckTimeout: time.Second, DiskPath: "/"},
+		HTTPCache: config.HTTPCacheConfig{
+			CacheControl:         "no-cache",
+			ResponseCacheEnabled: true,
+			ResponseCacheSize:    100,
+			ResponseCacheTTL:     time.Minute,
+		},
+	}
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	db, err := database.New(cfg, logger)
+	suite.Require().NoError(err)
+	suite.Require().NoError(database.Migrate(context.Background(), db))
+	suite.srv, err = InitServer(config.NewWatcher(cfg, nil, logger), db, logger)
+	suite.Require().NoError(err)
+}
+
+func (suite *IntegrationTestSuite) request(method string, path string, body interface{}) (int, map[string]interface{}) {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		suite.Require().NoError(err)
+		reader = bytes.NewReader(payload)
+	}
+
+	req := httptest.NewRequest(method, path, reader)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.srv.Router().ServeHTTP(w, req)
+
+	response := map[string]interface{}{}
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+	return w.Code, response
+}
+
+func (suite *IntegrationTestSuite) TestStartupProbe() {
+	status, _ := suite.request(http.MethodGet, "/startupz", nil)
+
+	suite.Equal(http.StatusOK, status)
+}
+
+func (suite *IntegrationTestSuite) TestAuthorAndBookLifecycle() {
+	status, response := suite.request(http.MethodPost, "/v1/author/", map[string]interface{}{
+		"penName":   "Haruki Murakami",
+		"birthYear": 1949,
+	})
+	suite.Require().Equal(http.StatusCreated, status)
+	authorID := response["data"].(map[string]interface{})["id"].(string)
+
+	status, response = suite.request(http.MethodPost, "/v1/author/", map[string]interface{}{
+		"penName":   "Haruki Murakami",
+		"birthYear": 1949,
+	})
+	suite.Equal(http.StatusConflict, status)
+	suite.Equal(string(dto.AuthorAlreadyExists), response["code"])
+
+	book := map[string]interface{}{
+		"authorId": authorID,
+		"name":     "Norwegian Wood",
+		"isbn":     "9780375704024",
+	}
+	status, response = suite.request(http.MethodPost, "/v1/book/", book)
+	suite.Require().Equal(http.StatusCreated, status)
+	bookID := response["data"].(map[string]interface{})["id"].(string)
+
+	status, response = suite.request(http.MethodPost, "/v1/book/", book)
+	suite.Equal(http.StatusConflict, status)
+	suite.Equal(string(dto.BookAlreadyExists), response["code"])
+
+	status, response = suite.request(http.MethodGet, "/v1/book/"+bookID, nil)
+	suite.Equal(http.StatusOK, status)
+	data := response["data"].(map[string]interface{})
+	suite.Equal("Norwegian Wood", data["name"])
+	suite.Equal("Haruki Murakami", data["author"].(map[string]interface{})["penName"])
+
+	status, response = suite.request(http.MethodGet, "/v1/book/author/"+authorID+"?page=1&pageSize=10", nil)
+	suite.Equal(http.StatusOK, status)
+	suite.Len(response["data"].(map[string]interface{})["items"], 1)
+
+	status, _ = suite.request(http.MethodDelete, "/v1/book/"+bookID, nil)
+	suite.Equal(http.StatusOK, status)
+
+	status, _ = suite.request(http.MethodGet, "/v1/book/"+bookID, nil)
+	suite.Equal(http.StatusNotFound, status)
+}
+
+func (suite *IntegrationTestSuite) TestAuthorListCacheFlushedOnWrite() {
+	countAuthors := func() int {
+		status, response := suite.request(http.MethodGet, "/v1/author/?pageSize=10&page=1", nil)
+		suite.Require().Equal(http.StatusOK, status)
+		return len(response["data"].(map[string]interface{})["items"].([]interface{}))
+	}
+
+	suite.Equal(0, countAuthors())
+
+	status, _ := suite.request(http.MethodPost, "/v1/author/", map[string]interface{}{
+		"penName":   "Haruki Murakami",
+		"birthYear": 1949,
+	})
+	suite.Require().Equal(http.StatusCreated, status)
+
+	suite.Equal(1, countAuthors())
+}
+
+func TestIntegrationTestSuite(t *testing.T) {
+	suite.Run(t, new(IntegrationTestSuite))
+}
@@ -1,33 +1,235 @@
 package server
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net"
+	"net/http"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+	"time"
 
-	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/sirawatc/simple-gin-crud/internal/shared/config"
+	"github.com/sirawatc/simple-gin-crud/pkg/middleware"
+	"github.com/sirawatc/simple-gin-crud/pkg/tlsconfig"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 )
 
-func InitServer(cfg *config.Config, db *gorm.DB, logger *logrus.Logger) *gin.Engine {
+type Server struct {
+	cfg           *config.Config
+	db            *gorm.DB
+	logger        *logrus.Logger
+	router        *gin.Engine
+	httpServer    *http.Server
+	metricsServer *http.Server
+	listener      net.Listener
+	stopTLSReload context.CancelFunc
+	ready         atomic.Bool
+	errCh         chan error
+}
+
+func InitServer(watcher *config.Watcher, db *gorm.DB, logger *logrus.Logger) (*Server, error) {
+	cfg := watcher.Current()
+
 	router := gin.New()
 	router.Use(gin.Recovery())
-	router.Use(cors.Default())
-	// Inject security headers if needed ref: https://gin-gonic.com/en/docs/examples/security-headers/
 
-	err := router.SetTrustedProxies(nil)
+	corsOptions, err := newCORSOptions(cfg)
+	if err != nil {
+		return nil, err
+	}
+	corsMiddleware, err := middleware.NewCORS(corsOptions)
+	if err != nil {
+		return nil, err
+	}
+	config.Watch(watcher, func(cfg *config.Config) config.CORSConfig { return cfg.CORS }, func(config.CORSConfig) {
+		opts, err := newCORSOptions(watcher.Current())
+		if err == nil {
+			err = corsMiddleware.SetOptions(opts)
+		}
+		if err != nil {
+			logger.Errorf("Failed to apply CORS settings, keeping the current ones: %v", err)
+		}
+	})
+	router.Use(corsMiddleware.Middleware())
+	if cfg.Security.HeadersEnabled {
+		router.Use(middleware.SecurityHeadersMiddleware(newSecurityHeadersOptions(cfg.Security)))
+	}
+
+	err = router.SetTrustedProxies(nil)
 	if err != nil {
 		logger.WithField("error", err.Error()).Error("Failed to set trusted proxies")
 	}
 
-	SetupRoutes(router, db, logger)
+	s := &Server{
+		cfg:    cfg,
+		db:     db,
+		logger: logger,
+		router: router,
+		errCh:  make(chan error, 2),
+	}
+
+	SetupRoutes(router, watcher, db, logger, newHealthRegistry(cfg.Health, db, s.IsReady))
+
+	s.httpServer = s.newHTTPServer(fmt.Sprintf("%s:%s", cfg.Server.Host, cfg.Server.Port), router)
+
+	if (cfg.Metrics.Enabled && !cfg.Metrics.Public) || cfg.Admin.Enabled {
+		internalRouter := gin.New()
+		internalRouter.Use(gin.Recovery())
+		SetupInternalRoutes(internalRouter, watcher, logger)
+		s.metricsServer = s.newHTTPServer(fmt.Sprintf("%s:%s", cfg.Metrics.Host, cfg.Metrics.Port), internalRouter)
+	}
+
+	return s, nil
+}
+
+func (s *Server) newHTTPServer(address string, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              address,
+		Handler:           handler,
+		ReadTimeout:       s.cfg.Server.ReadTimeout,
+		ReadHeaderTimeout: s.cfg.Server.ReadHeaderTimeout,
+		WriteTimeout:      s.cfg.Server.WriteTimeout,
+		IdleTimeout:       s.cfg.Server.IdleTimeout,
+		MaxHeaderBytes:    s.cfg.Server.MaxHeaderBytes,
+	}
+}
+
+func (s *Server) Router() *gin.Engine {
+	return s.router
+}
+
+func (s *Server) IsReady() bool {
+	return s.ready.Load()
+}
+
+func (s *Server) Addr() string {
+	if s.listener == nil {
+		return s.httpServer.Addr
+	}
+	return s.listener.Addr().String()
+}
+
+func (s *Server) Start() error {
+	var reloader *tlsconfig.Reloader
+	if s.cfg.TLS.Enabled {
+		var err error
+		reloader, err = tlsconfig.New(newTLSOptions(s.cfg.TLS), s.logger)
+		if err != nil {
+			return err
+		}
+		s.httpServer.TLSConfig = reloader.TLSConfig()
+	}
+
+	listener, err := net.Listen("tcp", s.httpServer.Addr)
+	if err != nil {
+		return err
+	}
+	s.listener = listener
+
+	if reloader != nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		s.stopTLSReload = cancel
+		go reloader.Run(ctx, s.cfg.TLS.ReloadInterval)
+		s.logger.Infof("Starting server in %s mode on %s with TLS (client auth: %s)", gin.Mode(), listener.Addr(), s.cfg.TLS.ClientAuth)
+	} else {
+		s.logger.Infof("Starting server in %s mode on %s", gin.Mode(), listener.Addr())
+	}
+	go s.serve(s.httpServer, listener)
+
+	if s.metricsServer != nil {
+		metricsListener, err := net.Listen("tcp", s.metricsServer.Addr)
+		if err != nil {
+			_ = listener.Close()
+			return err
+		}
+		s.logger.Infof("Starting metrics and admin server on %s", metricsListener.Addr())
+		go s.serve(s.metricsServer, metricsListener)
+	}
+
+	s.ready.Store(true)
+	return nil
+}
+
+func (s *Server) serve(server *http.Server, listener net.Listener) {
+	var err error
+	if server.TLSConfig != nil {
+		err = server.ServeTLS(listener, "", "")
+	} else {
+		err = server.Serve(listener)
+	}
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		s.errCh <- err
+	}
+}
+
+func (s *Server) Run(ctx context.Context) error {
+	if err := s.Start(); err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	var serveErr error
+	select {
+	case <-ctx.Done():
+		s.logger.Info("Shutdown signal received")
+	case serveErr = <-s.errCh:
+		s.logger.Errorf("Server stopped unexpectedly: %v", serveErr)
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.cfg.Server.ShutdownDelay+s.cfg.Server.ShutdownTimeout)
+	defer cancel()
+
+	if err := s.Shutdown(shutdownCtx); err != nil {
+		return errors.Join(serveErr, err)
+	}
+	return serveErr
+}
+
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.ready.Store(false)
+
+	if delay := s.cfg.Server.ShutdownDelay; delay > 0 {
+		s.logger.Infof("Marked server as not ready, waiting %v before draining", delay)
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+		}
+	}
+
+	if s.stopTLSReload != nil {
+		s.stopTLSReload()
+	}
+
+	var errs []error
+
+	s.logger.Info("Draining in-flight requests")
+	if err := s.httpServer.Shutdown(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("failed to shutdown server: %w", err))
+	}
+
+	if s.metricsServer != nil {
+		if err := s.metricsServer.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to shutdown metrics server: %w", err))
+		}
+	}
+
+	if sqlDB, err := s.db.DB(); err != nil {
+		errs = append(errs, fmt.Errorf("failed to get database connection: %w", err))
+	} else if err := sqlDB.Close(); err != nil {
+		errs = append(errs, fmt.Errorf("failed to close database connection: %w", err))
+	}
 
-	address := fmt.Sprintf("%s:%s", cfg.Server.Host, cfg.Server.Port)
-	logger.Infof("Starting server in %s mode on %s", gin.Mode(), address)
-	if err := router.Run(address); err != nil {
-		logger.Errorf("Failed to start server: %v", err)
+	if err := errors.Join(errs...); err != nil {
+		return err
 	}
 
-	return router
+	s.logger.Info("Server stopped gracefully")
+	return nil
 }
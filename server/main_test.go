@@ -0,0 +1,192 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/sirawatc/simple-gin-crud/internal/shared/config"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type ServerTestSuite struct {
+	suite.Suite
+	cfg  *config.Config
+	db   *gorm.DB
+	mock sqlmock.Sqlmock
+}
+
+func (suite *ServerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+
+	sqlDB, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	suite.NoError(err)
+	mock.ExpectPing()
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	suite.NoError(err)
+
+	suite.db = db
+	suite.mock = mock
+	suite.cfg = &config.Config{
+		Server: config.ServerConfig{
+			Host:              "127.0.0.1",
+			Port:              "0",
+			ReadTimeout:       time.Second,
+			ReadHeaderTimeout: time.Second,
+			WriteTimeout:      time.Second,
+			IdleTimeout:       time.Second,
+			MaxHeaderBytes:    1 << 20,
+			ShutdownTimeout:   time.Second,
+			ShutdownDelay:     100 * time.Millisecond,
+		},
+		Metrics: config.MetricsConfig{
+			Enabled: true,
+			Path:    "/metrics",
+			Host:    "127.0.0.1",
+			Port:    "0",
+		},
+	}
+}
+
+func (suite *ServerTestSuite) newServer() *Server {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	srv, err := InitServer(config.NewWatcher(suite.cfg, nil, logger), suite.db, logger)
+	suite.Require().NoError(err)
+	return srv
+}
+
+func (suite *ServerTestSuite) get(srv *Server, path string) int {
+	resp, err := http.Get(fmt.Sprintf("http://%s%s", srv.Addr(), path))
+	suite.NoError(err)
+	defer resp.Body.Close()
+	return resp.StatusCode
+}
+
+func (suite *ServerTestSuite) TestInitServer_ConfiguresTimeouts() {
+	srv := suite.newServer()
+
+	suite.Equal("127.0.0.1:0", srv.Addr())
+	suite.Equal(time.Second, srv.httpServer.ReadTimeout)
+	suite.Equal(time.Second, srv.httpServer.ReadHeaderTimeout)
+	suite.Equal(time.Second, srv.httpServer.WriteTimeout)
+	suite.Equal(time.Second, srv.httpServer.IdleTimeout)
+	suite.Equal(1<<20, srv.httpServer.MaxHeaderBytes)
+	suite.NotNil(srv.metricsServer)
+	suite.False(srv.IsReady())
+}
+
+func (suite *ServerTestSuite) TestInitServer_MetricsRoute() {
+	serve := func(srv *Server) int {
+		w := httptest.NewRecorder()
+		srv.Router().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		return w.Code
+	}
+
+	srv := suite.newServer()
+	suite.NotNil(srv.metricsServer)
+	suite.Equal(http.StatusNotFound, serve(srv), "metrics stay off the API router by default")
+
+	suite.cfg.Metrics.Public = true
+	srv = suite.newServer()
+	suite.Nil(srv.metricsServer)
+	suite.Equal(http.StatusOK, serve(srv))
+}
+
+func (suite *ServerTestSuite) TestInitServer_AdminRoutes() {
+	serve := func(handler http.Handler) int {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/admin/config", nil)
+		req.Header.Set("Authorization", "Bearer admin-token")
+		handler.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	suite.cfg.Admin = config.AdminConfig{Enabled: true, Token: "admin-token"}
+	suite.cfg.Metrics.Public = true
+	srv := suite.newServer()
+	suite.Require().NotNil(srv.metricsServer, "admin routes need the internal listener even with public metrics")
+	suite.Equal(http.StatusNotFound, serve(srv.Router()), "admin routes stay off the API router")
+	suite.Equal(http.StatusOK, serve(srv.metricsServer.Handler))
+}
+
+func (suite *ServerTestSuite) TestStartAndShutdown() {
+	srv := suite.newServer()
+	srv.Router().GET("/slow", func(c *gin.Context) {
+		time.Sleep(200 * time.Millisecond)
+		c.Status(http.StatusOK)
+	})
+
+	suite.NoError(srv.Start())
+	suite.True(srv.IsReady())
+
+	suite.Equal(http.StatusOK, suite.get(srv, "/livez"))
+
+	slowStatus := make(chan int, 1)
+	go func() {
+		slowStatus <- suite.get(srv, "/slow")
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	suite.mock.ExpectClose()
+	shutdownErr := make(chan error, 1)
+	go func() {
+		shutdownErr <- srv.Shutdown(context.Background())
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	suite.False(srv.IsReady())
+	suite.Equal(http.StatusServiceUnavailable, suite.get(srv, "/readyz"))
+
+	suite.NoError(<-shutdownErr)
+	suite.Equal(http.StatusOK, <-slowStatus)
+	suite.NoError(suite.mock.ExpectationsWereMet())
+
+	_, err := http.Get(fmt.Sprintf("http://%s/livez", srv.Addr()))
+	suite.Error(err)
+}
+
+func (suite *ServerTestSuite) TestRun_StopsWhenContextCancelled() {
+	suite.cfg.Server.ShutdownDelay = 0
+	srv := suite.newServer()
+	suite.mock.ExpectClose()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- srv.Run(ctx)
+	}()
+
+	suite.Eventually(srv.IsReady, time.Second, 10*time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-done:
+		suite.NoError(err)
+	case <-time.After(2 * time.Second):
+		suite.Fail("server did not stop")
+	}
+	suite.NoError(suite.mock.ExpectationsWereMet())
+}
+
+func (suite *ServerTestSuite) TestStart_InvalidAddress() {
+	suite.cfg.Server.Host = "invalid host"
+	srv := suite.newServer()
+
+	suite.Error(srv.Start())
+	suite.False(srv.IsReady())
+}
+
+func TestServerTestSuite(t *testing.T) {
+	suite.Run(t, new(ServerTestSuite))
+}
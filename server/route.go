@@ -1,94 +1,270 @@
 package server
 
 import (
+	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"github.com/sirawatc/simple-gin-crud/internal/author"
 	"github.com/sirawatc/simple-gin-crud/internal/book"
+	"github.com/sirawatc/simple-gin-crud/internal/shared/config"
+	"github.com/sirawatc/simple-gin-crud/internal/shared/dto"
+	"github.com/sirawatc/simple-gin-crud/pkg/cache"
+	"github.com/sirawatc/simple-gin-crud/pkg/health"
+	"github.com/sirawatc/simple-gin-crud/pkg/httpcache"
+	"github.com/sirawatc/simple-gin-crud/pkg/metrics"
 	"github.com/sirawatc/simple-gin-crud/pkg/middleware"
 	"github.com/sirawatc/simple-gin-crud/pkg/repository"
+	"github.com/sirawatc/simple-gin-crud/pkg/tlsconfig"
+	"github.com/sirawatc/simple-gin-crud/pkg/tracing"
+	"github.com/sirawatc/simple-gin-crud/pkg/validator"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 )
 
-func SetupRoutes(router *gin.Engine, db *gorm.DB, logger *logrus.Logger) {
+func SetupRoutes(router *gin.Engine, watcher *config.Watcher, db *gorm.DB, logger *logrus.Logger, healthRegistry *health.Registry) {
+	cfg := watcher.Current()
+
 	// Initialize shared dependencies
+	requestValidator := validator.NewValidator()
+	requestValidator.MustRegister(author.ValidationRules()...)
+	requestValidator.MustRegister(book.ValidationRules()...)
+	binding.Validator = requestValidator.Binding()
+	binding.JSON = validator.JSONBinding{Strict: cfg.Request.StrictJSON}
 	transactionManager := repository.NewTransactionManager(db)
 
 	// Initialize repositories
-	authorRepo := author.NewRepository(transactionManager, logger)
-	bookRepo := book.NewRepository(transactionManager, logger)
+	var authorRepo author.IRepository = author.NewRepository(transactionManager, logger)
+	var bookRepo book.IRepository = book.NewRepository(transactionManager, logger)
+	if cfg.Cache.Enabled {
+		backend := cache.NewLRU(cfg.Cache.Size)
+		opts := cacheOptions(cfg)
+		cachedAuthorRepo := author.NewCachedRepository(authorRepo, backend, opts)
+		cachedBookRepo := book.NewCachedRepository(bookRepo, cachedAuthorRepo, backend, opts)
+		config.Watch(watcher, cacheOptions, func(opts cache.Options) {
+			cachedAuthorRepo.SetCacheOptions(opts)
+			cachedBookRepo.SetCacheOptions(opts)
+		})
+		authorRepo, bookRepo = cachedAuthorRepo, cachedBookRepo
+	}
 
 	// Initialize services
-	authorService := author.NewService(authorRepo, logger)
-	bookService := book.NewService(bookRepo, authorService, logger)
+	authorService := author.NewService(authorRepo, transactionManager, logger)
+	bookService := book.NewService(bookRepo, authorService, transactionManager, logger)
 
 	// Initialize handlers
-	authorHandler := author.NewHandler(authorService, logger)
-	bookHandler := book.NewHandler(bookService, logger)
+	authorHandler := author.NewHandler(authorService, requestValidator, logger)
+	bookHandler := book.NewHandler(bookService, requestValidator, logger)
 
 	// Add middleware
 	router.Use(middleware.RequestIDMiddleware())
+	router.Use(middleware.LocaleMiddleware(validator.Locales...))
+	if cfg.TLS.Enabled {
+		router.Use(middleware.ClientIdentityMiddleware())
+	}
+	router.Use(tracing.Middleware())
+	router.Use(middleware.DBSessionMiddleware())
+	if cfg.AccessLog.Enabled {
+		router.Use(middleware.AccessLogMiddleware(logger, newAccessLogOptions(cfg.AccessLog)))
+	}
+	if cfg.Metrics.Enabled {
+		router.Use(metrics.HTTPMiddleware(cfg.Metrics.Path))
+	}
+
+	router.Use(httpcache.CacheControl(cfg.HTTPCache.CacheControl, cfg.HTTPCache.RouteCacheControl))
+
+	var responseCache *httpcache.ResponseCache
+	if cfg.HTTPCache.ResponseCacheEnabled {
+		responseCache = httpcache.NewResponseCache(cache.NewLRU(cfg.HTTPCache.ResponseCacheSize), cfg.HTTPCache.ResponseCacheTTL)
+		config.Watch(watcher, func(cfg *config.Config) time.Duration { return cfg.HTTPCache.ResponseCacheTTL }, responseCache.SetTTL)
+	}
+
+	initHealthRoutes(router, healthRegistry)
+	if cfg.Metrics.Enabled && cfg.Metrics.Public {
+		initMetricsRoutes(router, cfg.Metrics.Path)
+	}
+
+	// Health and metrics routes are registered first so they are not
+	// rate limited.
+	rateLimiter := middleware.NewRateLimiter(newRateLimitOptions(cfg))
+	config.Watch(watcher, newRateLimitOptions, rateLimiter.SetOptions)
+	router.Use(middleware.RateLimitMiddleware(rateLimiter, func(c *gin.Context) {
+		dto.AbortWithError(c, http.StatusTooManyRequests, dto.TooManyRequests, nil)
+	}))
+	router.Use(middleware.BodyLimitMiddleware(newBodyLimitOptions(cfg.Request), func(c *gin.Context) {
+		dto.AbortWithError(c, http.StatusRequestEntityTooLarge, dto.RequestEntityTooLarge, nil)
+	}))
+	if cfg.Request.RequireContentType {
+		router.Use(middleware.ContentTypeMiddleware([]string{binding.MIMEJSON}, func(c *gin.Context) {
+			dto.AbortWithError(c, http.StatusUnsupportedMediaType, dto.UnsupportedMediaType, nil)
+		}))
+	}
+
+	initAuthorRoutes(router, authorHandler, responseCache)
+	initBookRoutes(router, bookHandler, responseCache)
+}
+
+// SetupInternalRoutes registers the routes served on the metrics port, which
+// is kept off the public API listener.
+func SetupInternalRoutes(router *gin.Engine, watcher *config.Watcher, logger *logrus.Logger) {
+	cfg := watcher.Current()
+
+	router.Use(middleware.RequestIDMiddleware())
+	router.Use(middleware.LocaleMiddleware(validator.Locales...))
 
-	// Add cache if needed ref: https://github.com/gin-contrib/cache
-	// Add rate limit if needed ref: https://github.com/JGLTechnologies/gin-rate-limit
-	initHealthRoutes(router, db)
-	initAuthorRoutes(router, authorHandler)
-	initBookRoutes(router, bookHandler)
+	if cfg.Metrics.Enabled && !cfg.Metrics.Public {
+		initMetricsRoutes(router, cfg.Metrics.Path)
+	}
+	if cfg.Admin.Enabled {
+		initAdminRoutes(router, watcher, cfg.Admin.Token, logger)
+	}
 }
 
-func initAuthorRoutes(router *gin.Engine, authorHandler *author.Handler) {
+func initAuthorRoutes(router *gin.Engine, authorHandler *author.Handler, responseCache *httpcache.ResponseCache) {
 	v1 := router.Group("/v1")
 	authors := v1.Group("/author")
+	listHandlers := []gin.HandlerFunc{authorHandler.GetAllAuthors}
+	if responseCache != nil {
+		// Book responses embed the author, so author writes flush both.
+		authors.Use(responseCache.FlushOnWrite("author", "book"))
+		listHandlers = append([]gin.HandlerFunc{responseCache.Cache("author")}, listHandlers...)
+	}
 	{
 		authors.POST("/", authorHandler.CreateAuthor)
 		authors.GET("/:id", authorHandler.GetAuthor)
-		authors.GET("/", authorHandler.GetAllAuthors)
+		authors.GET("/", listHandlers...)
 		authors.PUT("/:id", authorHandler.UpdateAuthor)
 		authors.DELETE("/:id", authorHandler.DeleteAuthor)
 	}
 }
 
-func initBookRoutes(router *gin.Engine, bookHandler *book.Handler) {
+func initBookRoutes(router *gin.Engine, bookHandler *book.Handler, responseCache *httpcache.ResponseCache) {
 	v1 := router.Group("/v1")
 	books := v1.Group("/book")
+	listByAuthorHandlers := []gin.HandlerFunc{bookHandler.GetBooksByAuthorID}
+	listHandlers := []gin.HandlerFunc{bookHandler.GetAllBooks}
+	if responseCache != nil {
+		books.Use(responseCache.FlushOnWrite("book"))
+		listByAuthorHandlers = append([]gin.HandlerFunc{responseCache.Cache("book")}, listByAuthorHandlers...)
+		listHandlers = append([]gin.HandlerFunc{responseCache.Cache("book")}, listHandlers...)
+	}
 	{
 		books.POST("/", bookHandler.CreateBook)
 		books.GET("/:id", bookHandler.GetBook)
-		books.GET("/author/:authorId", bookHandler.GetBooksByAuthorID)
-		books.GET("/", bookHandler.GetAllBooks)
+		books.GET("/author/:authorId", listByAuthorHandlers...)
+		books.GET("/", listHandlers...)
 		books.PUT("/:id", bookHandler.UpdateBook)
 		books.DELETE("/:id", bookHandler.DeleteBook)
 	}
 }
 
-func initHealthRoutes(router *gin.Engine, db *gorm.DB) {
-	router.GET("/health", func(c *gin.Context) {
-		healthMsg := gin.H{
-			"status": "ok",
-			"checks": gin.H{
-				"database": "ok",
-			},
-			"timestamp": time.Now().Format(time.RFC3339),
-		}
+func initMetricsRoutes(router *gin.Engine, path string) {
+	router.GET(path, gin.WrapH(metrics.Handler()))
+}
 
-		dbInstance, err := db.DB()
-		if err != nil {
-			healthMsg["checks"].(gin.H)["database"] = "down"
-			healthMsg["status"] = "unhealthy"
-			c.JSON(http.StatusServiceUnavailable, healthMsg)
-			return
-		}
-		err = dbInstance.Ping()
-		if err != nil {
-			healthMsg["checks"].(gin.H)["database"] = "down"
-			healthMsg["status"] = "unhealthy"
-			c.JSON(http.StatusServiceUnavailable, healthMsg)
-			return
+func initHealthRoutes(router *gin.Engine, registry *health.Registry) {
+	router.GET("/livez", registry.Handler(health.Liveness))
+	router.GET("/readyz", registry.Handler(health.Readiness))
+	router.GET("/startupz", registry.Handler(health.Startup))
+	// /health keeps its original body and only checks the database.
+	router.GET("/health", registry.LegacyHandler("database"))
+}
+
+func cacheOptions(cfg *config.Config) cache.Options {
+	return cache.Options{TTL: cfg.Cache.TTL, NegativeTTL: cfg.Cache.NegativeTTL}
+}
+
+func newRateLimitOptions(cfg *config.Config) middleware.RateLimitOptions {
+	return middleware.RateLimitOptions{
+		Enabled:           cfg.RateLimit.Enabled,
+		RequestsPerSecond: cfg.RateLimit.RequestsPerSecond,
+		Burst:             cfg.RateLimit.Burst,
+	}
+}
+
+// newBodyLimitOptions converts the request size limits to int64 byte counts.
+func newBodyLimitOptions(cfg config.RequestConfig) middleware.BodyLimitOptions {
+	routeMaxBytes := make(map[string]int64, len(cfg.RouteMaxBodyBytes))
+	for route, maxBytes := range cfg.RouteMaxBodyBytes {
+		routeMaxBytes[route] = int64(maxBytes)
+	}
+	return middleware.BodyLimitOptions{
+		MaxBytes:      int64(cfg.MaxBodyBytes),
+		RouteMaxBytes: routeMaxBytes,
+	}
+}
+
+// newCORSOptions applies the CORS settings on top of the configured preset,
+// which defaults to production in release mode and development otherwise.
+// It fails when the preset is unknown.
+func newCORSOptions(cfg *config.Config) (middleware.CORSOptions, error) {
+	preset := cfg.CORS.Preset
+	if preset == "" {
+		preset = middleware.CORSPresetDevelopment
+		if cfg.Mode == gin.ReleaseMode {
+			preset = middleware.CORSPresetProduction
 		}
+	}
+	opts, ok := middleware.CORSPreset(preset)
+	if !ok {
+		return middleware.CORSOptions{}, fmt.Errorf("unknown CORS preset %q", preset)
+	}
 
-		c.JSON(http.StatusOK, healthMsg)
-	})
+	if len(cfg.CORS.AllowOrigins) > 0 {
+		opts.AllowOrigins = cfg.CORS.AllowOrigins
+	}
+	if len(cfg.CORS.AllowMethods) > 0 {
+		opts.AllowMethods = cfg.CORS.AllowMethods
+	}
+	if len(cfg.CORS.AllowHeaders) > 0 {
+		opts.AllowHeaders = cfg.CORS.AllowHeaders
+	}
+	if len(cfg.CORS.ExposeHeaders) > 0 {
+		opts.ExposeHeaders = cfg.CORS.ExposeHeaders
+	}
+	if cfg.CORS.MaxAge > 0 {
+		opts.MaxAge = cfg.CORS.MaxAge
+	}
+	opts.AllowCredentials = cfg.CORS.AllowCredentials
+	return opts, nil
+}
+
+func newSecurityHeadersOptions(cfg config.SecurityConfig) middleware.SecurityHeadersOptions {
+	return middleware.SecurityHeadersOptions{
+		HSTSMaxAge:                 cfg.HSTSMaxAge,
+		HSTSIncludeSubdomains:      cfg.HSTSIncludeSubdomains,
+		ContentSecurityPolicy:      cfg.ContentSecurityPolicy,
+		RouteContentSecurityPolicy: cfg.RouteContentSecurityPolicy,
+		ReferrerPolicy:             cfg.ReferrerPolicy,
+		FrameOptions:               cfg.FrameOptions,
+		ContentTypeNosniff:         cfg.ContentTypeNosniff,
+		TrustedProxies:             cfg.TrustedProxies,
+	}
+}
+
+func newTLSOptions(cfg config.TLSConfig) tlsconfig.Options {
+	return tlsconfig.Options{
+		CertFile:     cfg.CertFile,
+		KeyFile:      cfg.KeyFile,
+		MinVersion:   cfg.MinVersion,
+		CipherSuites: cfg.CipherSuites,
+		ClientAuth:   cfg.ClientAuth,
+		ClientCAFile: cfg.ClientCAFile,
+	}
+}
+
+func newAccessLogOptions(cfg config.AccessLogConfig) middleware.AccessLogOptions {
+	return middleware.AccessLogOptions{
+		SampleRate:        cfg.SampleRate,
+		SlowThreshold:     cfg.SlowThreshold,
+		VerySlowThreshold: cfg.VerySlowThreshold,
+		SkipPaths:         cfg.SkipPaths,
+		LogHeaders:        cfg.LogHeaders,
+		LogBody:           cfg.LogBody,
+		MaxBodyBytes:      cfg.MaxBodyBytes,
+		RedactHeaders:     cfg.RedactHeaders,
+		RedactBodyFields:  cfg.RedactBodyFields,
+	}
 }
@@ -0,0 +1,74 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirawatc/simple-gin-crud/internal/shared/config"
+	"github.com/sirawatc/simple-gin-crud/pkg/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCORSOptions(t *testing.T) {
+	development, _ := middleware.CORSPreset(middleware.CORSPresetDevelopment)
+	production, _ := middleware.CORSPreset(middleware.CORSPresetProduction)
+
+	tests := []struct {
+		name     string
+		cfg      config.Config
+		expected middleware.CORSOptions
+	}{
+		{
+			name:     "debug mode uses development preset",
+			cfg:      config.Config{Mode: gin.DebugMode},
+			expected: development,
+		},
+		{
+			name:     "release mode uses production preset",
+			cfg:      config.Config{Mode: gin.ReleaseMode},
+			expected: production,
+		},
+		{
+			name: "explicit preset and overrides",
+			cfg: config.Config{Mode: gin.DebugMode, CORS: config.CORSConfig{
+				Preset:           middleware.CORSPresetProduction,
+				AllowOrigins:     []string{"https://a.example"},
+				AllowHeaders:     []string{"Content-Type"},
+				AllowCredentials: true,
+				MaxAge:           time.Minute,
+			}},
+			expected: middleware.CORSOptions{
+				AllowOrigins:     []string{"https://a.example"},
+				AllowMethods:     production.AllowMethods,
+				AllowHeaders:     []string{"Content-Type"},
+				ExposeHeaders:    production.ExposeHeaders,
+				AllowCredentials: true,
+				MaxAge:           time.Minute,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := newCORSOptions(&tt.cfg)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, opts)
+		})
+	}
+
+	_, err := newCORSOptions(&config.Config{CORS: config.CORSConfig{Preset: "staging"}})
+	assert.EqualError(t, err, `unknown CORS preset "staging"`)
+}
+
+func TestNewBodyLimitOptions(t *testing.T) {
+	opts := newBodyLimitOptions(config.RequestConfig{
+		MaxBodyBytes:      1024,
+		RouteMaxBodyBytes: map[string]int{"/v1/book/": 4096},
+	})
+
+	assert.Equal(t, middleware.BodyLimitOptions{
+		MaxBytes:      1024,
+		RouteMaxBytes: map[string]int64{"/v1/book/": 4096},
+	}, opts)
+}